@@ -0,0 +1,62 @@
+package life
+
+import "math"
+
+// TrackAges enables or disables the age layer of the game.
+// While enabled, every tick records for each cell how many consecutive generations it has been alive,
+// see Field.Age. Enabling the layer considers every cell that is currently alive a newborn.
+func (g *Game) TrackAges(enabled bool) {
+	if !enabled {
+		g.current.age, g.next.age = nil, nil
+		return
+	}
+	if g.current.age != nil {
+		return
+	}
+	g.current.age, g.next.age = newAges(g.width, g.height), newAges(g.width, g.height)
+	for y, row := range g.current.s {
+		for x, alive := range row {
+			if alive {
+				g.current.age[y][x] = 1
+			}
+		}
+	}
+}
+
+func newAges(width, height uint) [][]uint32 {
+	age := make([][]uint32, height)
+	for i := range age {
+		age[i] = make([]uint32, width)
+	}
+	return age
+}
+
+// HasAges reports whether the field keeps track of the age of its cells.
+func (f *Field) HasAges() bool {
+	return f.age != nil
+}
+
+// Age returns the number of consecutive generations the cell at position x,y has been alive, counting the current one.
+// Newborn cells have age 1 and dead cells have age 0. Ages saturate instead of overflowing.
+// If the age layer isn't enabled, Age always returns 0.
+func (f *Field) Age(x, y uint) uint32 {
+	if f.age == nil {
+		return 0
+	}
+	return f.age[y][x]
+}
+
+// ageFrom computes the ages of f, the generation following prev.
+func (f *Field) ageFrom(prev *Field) {
+	for y, row := range f.s {
+		for x, alive := range row {
+			a := prev.age[y][x]
+			if !alive {
+				a = 0
+			} else if a < math.MaxUint32 {
+				a++
+			}
+			f.age[y][x] = a
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package life
+
+import "testing"
+
+func TestTrackAges(t *testing.T) {
+	g := gameFromRows(false,
+		".....",
+		".....",
+		".ooo.",
+		".....",
+		".....",
+	)
+	g.TrackAges(true)
+	// A blinker's center cell keeps aging while its ends are reborn every generation.
+	want := [][][]uint32{
+		{{0, 0, 0, 0, 0}, {0, 0, 1, 0, 0}, {0, 0, 2, 0, 0}, {0, 0, 1, 0, 0}, {0, 0, 0, 0, 0}},
+		{{0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}, {0, 1, 3, 1, 0}, {0, 0, 0, 0, 0}, {0, 0, 0, 0, 0}},
+	}
+	for i, ages := range want {
+		g.Tick()
+		for y, row := range ages {
+			for x, age := range row {
+				if got := g.Field().Age(uint(x), uint(y)); got != age {
+					t.Errorf("tick %d: age of %d,%d: got %d, wanted %d", i+1, x, y, got, age)
+				}
+			}
+		}
+	}
+
+	g.Field().Set(0, 0, true)
+	if got := g.Field().Age(0, 0); got != 1 {
+		t.Errorf("age of a cell brought to life: got %d, wanted 1", got)
+	}
+	g.TrackAges(false)
+	if g.Field().HasAges() {
+		t.Error("ages are still tracked after disabling them")
+	}
+}
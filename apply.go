@@ -0,0 +1,112 @@
+package life
+
+import "fmt"
+
+// Apply sets every cell of f to the result of fn for its position and state, row by row from the top-left corner.
+// The population, hash and ages are updated like Set updates them, in the same single pass over the field.
+// Apply is meant for setting up fields and takes O(width×height) time, Tick never calls it. Engines that keep state
+// between generations notice the changes like those made with Set.
+func (f *Field) Apply(fn func(x, y uint, alive bool) bool) {
+	changed := false
+	for y, row := range f.s {
+		for x, alive := range row {
+			v := fn(uint(x), uint(y), alive)
+			if v == alive {
+				continue
+			}
+			row[x] = v
+			changed = true
+			f.hash ^= cellKey(uint(x), uint(y))
+			var age uint32
+			if v {
+				f.pop++
+				age = 1
+			} else {
+				f.pop--
+			}
+			if f.age != nil {
+				f.age[y][x] = age
+			}
+		}
+	}
+	if changed {
+		f.edits++
+	}
+}
+
+// Invert brings every dead cell of f to life and kills every live one, see Apply.
+func (f *Field) Invert() {
+	f.Apply(func(x, y uint, alive bool) bool { return !alive })
+}
+
+// Mask kills every cell of f that is dead in other, which must be of the same size, see Apply.
+func (f *Field) Mask(other *Field) error {
+	if other.width != f.width || other.height != f.height {
+		return fmt.Errorf("can't mask a %dx%d field with a %dx%d one", f.width, f.height, other.width, other.height)
+	}
+	f.Apply(func(x, y uint, alive bool) bool { return alive && other.s[y][x] })
+	return nil
+}
+
+// Union returns a new field with the cells that are alive in f or other, which must be of the same size.
+// The new field has the wrapping, rule and ages of f.
+func (f *Field) Union(other *Field) (*Field, error) {
+	return f.combined(other, (*Field).UnionWith)
+}
+
+// Intersect returns a new field with the cells that are alive in both f and other, which must be of the same size.
+// The new field has the wrapping, rule and ages of f.
+func (f *Field) Intersect(other *Field) (*Field, error) {
+	return f.combined(other, (*Field).IntersectWith)
+}
+
+// Xor returns a new field with the cells that are alive in either f or other but not in both, which must be of the
+// same size, e.g. to show where two generations differ. The new field has the wrapping, rule and ages of f.
+func (f *Field) Xor(other *Field) (*Field, error) {
+	return f.combined(other, (*Field).XorWith)
+}
+
+// Subtract returns a new field with the cells that are alive in f but not in other, which must be of the same size,
+// e.g. to remove an object that was found in f. The new field has the wrapping, rule and ages of f.
+func (f *Field) Subtract(other *Field) (*Field, error) {
+	return f.combined(other, (*Field).SubtractWith)
+}
+
+// UnionWith brings every cell of f to life that is alive in other, which must be of the same size, see Apply.
+func (f *Field) UnionWith(other *Field) error {
+	return f.combine(other, func(a, b bool) bool { return a || b })
+}
+
+// IntersectWith kills every cell of f that is dead in other, which must be of the same size, see Apply. It does
+// the same as Mask.
+func (f *Field) IntersectWith(other *Field) error {
+	return f.combine(other, func(a, b bool) bool { return a && b })
+}
+
+// XorWith flips every cell of f that is alive in other, which must be of the same size, see Apply.
+func (f *Field) XorWith(other *Field) error {
+	return f.combine(other, func(a, b bool) bool { return a != b })
+}
+
+// SubtractWith kills every cell of f that is alive in other, which must be of the same size, see Apply.
+func (f *Field) SubtractWith(other *Field) error {
+	return f.combine(other, func(a, b bool) bool { return a && !b })
+}
+
+// combined returns a copy of f combined with other by the in-place operation op.
+func (f *Field) combined(other *Field, op func(f, other *Field) error) (*Field, error) {
+	c := f.Clone()
+	if err := op(c, other); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// combine sets every cell of f to the result of op for its state in f and in other, which must be of the same size.
+func (f *Field) combine(other *Field, op func(a, b bool) bool) error {
+	if other.width != f.width || other.height != f.height {
+		return fmt.Errorf("can't combine a %dx%d field with a %dx%d one", f.width, f.height, other.width, other.height)
+	}
+	f.Apply(func(x, y uint, alive bool) bool { return op(alive, other.s[y][x]) })
+	return nil
+}
@@ -0,0 +1,209 @@
+package life
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	f := fieldFromRows(true,
+		"o..o",
+		".o..",
+		"..oo",
+	)
+	f.age = newAges(f.width, f.height)
+	var visited []Cell
+	f.Apply(func(x, y uint, alive bool) bool {
+		visited = append(visited, Cell{x, y})
+		// Every cell of the left column comes to life, every other keeps its state.
+		return alive || x == 0
+	})
+	want := fieldFromRows(true,
+		"o..o",
+		"oo..",
+		"o.oo",
+	)
+	if !equalCells(f, want) || f.Population() != 7 || f.hash != want.hash {
+		t.Errorf("got\n%swith a population of %d, wanted\n%swith 7", f, f.Population(), want)
+	}
+	if len(visited) != 12 || visited[0] != (Cell{0, 0}) || visited[11] != (Cell{3, 2}) {
+		t.Errorf("visited %v, wanted every cell row by row", visited)
+	}
+	if f.age[1][0] != 1 || f.age[2][0] != 1 {
+		t.Errorf("the cells brought to life have the ages %d and %d, wanted newborns", f.age[1][0], f.age[2][0])
+	}
+}
+
+func TestInvert(t *testing.T) {
+	f := NewField(13, 7, false)
+	f.Randomize(0.3, rand.New(rand.NewSource(1)))
+	before := f.Clone()
+	f.Invert()
+	if f.Population() != 13*7-before.Population() {
+		t.Errorf("got a population of %d after inverting %d of %d cells", f.Population(), before.Population(), 13*7)
+	}
+	before.EachLive(func(x, y uint) {
+		if f.s[y][x] {
+			t.Fatalf("%d,%d is still alive", x, y)
+		}
+	})
+	f.Invert()
+	if !equalCells(f, before) || f.Population() != before.Population() || f.hash != before.hash {
+		t.Errorf("inverting twice gave\n%swanted\n%s", f, before)
+	}
+}
+
+func TestMask(t *testing.T) {
+	f := fieldFromRows(false,
+		"ooo",
+		"o.o",
+		"ooo",
+	)
+	mask := fieldFromRows(false,
+		".o.",
+		"ooo",
+		".o.",
+	)
+	if err := f.Mask(mask); err != nil {
+		t.Fatal(err)
+	}
+	want := fieldFromRows(false,
+		".o.",
+		"o.o",
+		".o.",
+	)
+	if !equalCells(f, want) || f.Population() != 4 {
+		t.Errorf("got\n%swanted\n%s", f, want)
+	}
+	if err := f.Mask(NewField(3, 4, false)); err == nil {
+		t.Error("got no error for a mask of another size")
+	}
+}
+
+// Engines that keep state between generations must notice the changes of Apply, as they notice those of Set.
+func TestApplyBetweenTicks(t *testing.T) {
+	for _, name := range EngineNames() {
+		e, _ := NewEngine(name)
+		g := NewRandomGame(32, 24, true, 0.3, rand.New(rand.NewSource(2))).WithEngine(e)
+		want := NewRandomGame(32, 24, true, 0.3, rand.New(rand.NewSource(2)))
+		for i := 0; i < 20; i++ {
+			if i%5 == 3 {
+				g.Field().Invert()
+				want.Field().Invert()
+			}
+			g.Tick()
+			want.Tick()
+			if !equalCells(g.Field(), want.Field()) {
+				t.Fatalf("%s: generation %d differs from the naive engine", name, g.Generation())
+			}
+		}
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, size := range [][2]uint{{1, 1}, {7, 5}, {64, 3}, {130, 20}} {
+		a := randomField(rng, size[0], size[1], true)
+		b := randomField(rng, size[0], size[1], false)
+		union, _ := a.Union(b)
+		intersection, _ := a.Intersect(b)
+		xor, _ := a.Xor(b)
+		difference, _ := a.Subtract(b)
+		empty := NewField(size[0], size[1], true)
+		// Identities of the operations, and the same results computed in other ways.
+		reUnion, _ := difference.Union(intersection)
+		reUnion, _ = reUnion.Union(b)
+		reXor, _ := union.Subtract(intersection)
+		reDifference, _ := a.Intersect(xor)
+		for _, test := range []struct {
+			name      string
+			got, want *Field
+		}{
+			{"A∪A = A", must(a.Union(a)), a},
+			{"A∩A = A", must(a.Intersect(a)), a},
+			{"A⊕A = ∅", must(a.Xor(a)), empty},
+			{"A−A = ∅", must(a.Subtract(a)), empty},
+			{"A∪∅ = A", must(a.Union(empty)), a},
+			{"A∩∅ = ∅", must(a.Intersect(empty)), empty},
+			{"A⊕∅ = A", must(a.Xor(empty)), a},
+			{"A∪B = B∪A", union, must(b.Union(a))},
+			{"A∩B = B∩A", intersection, must(b.Intersect(a))},
+			{"A⊕B = B⊕A", xor, must(b.Xor(a))},
+			{"(A−B)∪(A∩B)∪B = A∪B", reUnion, union},
+			{"A⊕B = (A∪B)−(A∩B)", reXor, xor},
+			{"A−B = A∩(A⊕B)", reDifference, difference},
+		} {
+			if !equalCells(test.got, test.want) {
+				t.Errorf("%dx%d: %s doesn't hold:\n%s\nwanted:\n%s", size[0], size[1], test.name, test.got, test.want)
+			}
+			if test.got.pop != test.want.pop || test.got.hash != test.want.hash {
+				t.Errorf("%dx%d: %s: got the population %d, wanted %d", size[0], size[1], test.name, test.got.pop,
+					test.want.pop)
+			}
+		}
+		if union.pop != a.pop+b.pop-intersection.pop {
+			t.Errorf("%dx%d: |A∪B| = %d, wanted |A|+|B|-|A∩B| = %d", size[0], size[1], union.pop,
+				a.pop+b.pop-intersection.pop)
+		}
+		if d, _ := a.HammingDistance(b); xor.pop != d {
+			t.Errorf("%dx%d: |A⊕B| = %d, wanted the Hamming distance %d", size[0], size[1], xor.pop, d)
+		}
+		// The results have the wrapping of the field they were called on, which stays untouched.
+		if !union.wrap || must(b.Union(a)).wrap {
+			t.Errorf("%dx%d: the results don't wrap like the field they were computed from", size[0], size[1])
+		}
+
+		// The bit-packed field combines words, with the same results.
+		for _, test := range []struct {
+			name string
+			op   func(x, y *BitField) error
+			want *Field
+		}{
+			{"union", (*BitField).UnionWith, union},
+			{"intersection", (*BitField).IntersectWith, intersection},
+			{"xor", (*BitField).XorWith, xor},
+			{"difference", (*BitField).SubtractWith, difference},
+		} {
+			packed := PackField(a)
+			if err := test.op(packed, PackField(b)); err != nil {
+				t.Fatal(err)
+			}
+			got := NewField(size[0], size[1], true)
+			packed.Store(got)
+			if !equalCells(got, test.want) || packed.Population() != test.want.pop {
+				t.Errorf("%dx%d: the bit-packed %s differs:\n%s\nwanted:\n%s", size[0], size[1], test.name, got,
+					test.want)
+			}
+		}
+	}
+
+	a, b := NewField(4, 3, true), NewField(3, 4, true)
+	for name, err := range map[string]error{
+		"Union":         errorOf(a.Union(b)),
+		"Intersect":     errorOf(a.Intersect(b)),
+		"Xor":           errorOf(a.Xor(b)),
+		"Subtract":      errorOf(a.Subtract(b)),
+		"UnionWith":     a.UnionWith(b),
+		"IntersectWith": a.IntersectWith(b),
+		"XorWith":       a.XorWith(b),
+		"SubtractWith":  a.SubtractWith(b),
+		"BitField":      PackField(a).XorWith(PackField(b)),
+	} {
+		if err == nil {
+			t.Errorf("%s: got no error for fields of different sizes", name)
+		}
+	}
+}
+
+// must returns f, panicking if err is set.
+func must(f *Field, err error) *Field {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// errorOf returns the error of a set operation.
+func errorOf(_ *Field, err error) error {
+	return err
+}
@@ -0,0 +1,255 @@
+package life
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// BitField is a field that stores every cell as a single bit, 64 cells to a word.
+// Its Step computes the next generation of 64 cells at once using bitwise operations on whole words.
+type BitField struct {
+	// w holds the rows back to back, every row takes up words words.
+	// Cell x,y is bit x%64 of word y*words+x/64. Bits past the width of the field are always zero.
+	w             []uint64
+	words         uint
+	width, height uint
+	wrap          bool
+	rule          Rule
+}
+
+// NewBitField allocates a new empty bit-packed board of the given height and width.
+// It panics if the size is invalid, see CheckSize.
+func NewBitField(width, height uint, wrap bool) *BitField {
+	if err := CheckSize(width, height); err != nil {
+		panic("life: " + err.Error())
+	}
+	words := (width + 63) / 64
+	return &BitField{
+		w:      make([]uint64, words*height),
+		words:  words,
+		width:  width,
+		height: height,
+		wrap:   wrap,
+		rule:   Conway,
+	}
+}
+
+// PackField returns a bit-packed copy of f.
+func PackField(f *Field) *BitField {
+	b := NewBitField(f.width, f.height, f.wrap)
+	b.Load(f)
+	return b
+}
+
+// Load copies the cells, wrapping and rule of f, which must have the same dimensions as b.
+func (b *BitField) Load(f *Field) {
+	b.wrap, b.rule = f.wrap, f.rule
+	for y, row := range f.s {
+		words := b.w[uint(y)*b.words : uint(y+1)*b.words]
+		for i := range words {
+			words[i] = 0
+		}
+		for x, alive := range row {
+			if alive {
+				words[x/64] |= 1 << (x % 64)
+			}
+		}
+	}
+}
+
+// Store copies the cells of b into f, which must have the same dimensions as b.
+func (b *BitField) Store(f *Field) {
+	f.pop = 0
+	for y, row := range f.s {
+		words := b.w[uint(y)*b.words : uint(y+1)*b.words]
+		for x := range row {
+			row[x] = words[x/64]&(1<<(x%64)) != 0
+		}
+		for _, word := range words {
+			f.pop += uint(bits.OnesCount64(word))
+		}
+	}
+}
+
+// Set sets the value v to the cell with position x,y on the field.
+func (b *BitField) Set(x, y uint, v bool) {
+	i := y*b.words + x/64
+	if v {
+		b.w[i] |= 1 << (x % 64)
+	} else {
+		b.w[i] &^= 1 << (x % 64)
+	}
+}
+
+// Alive reports whether the cell at position x,y is alive or dead.
+func (b *BitField) Alive(x, y uint) bool {
+	return b.w[y*b.words+x/64]&(1<<(x%64)) != 0
+}
+
+// Population returns the number of live cells on the field.
+func (b *BitField) Population() uint {
+	var n uint
+	for _, word := range b.w {
+		n += uint(bits.OnesCount64(word))
+	}
+	return n
+}
+
+// UnionWith brings every cell of b to life that is alive in other, which must be of the same size, a word of 64
+// cells at a time like the other set operations, see Field.Union.
+func (b *BitField) UnionWith(other *BitField) error {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// IntersectWith kills every cell of b that is dead in other, which must be of the same size, see Field.Intersect.
+func (b *BitField) IntersectWith(other *BitField) error {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// XorWith flips every cell of b that is alive in other, which must be of the same size, see Field.Xor.
+func (b *BitField) XorWith(other *BitField) error {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// SubtractWith kills every cell of b that is alive in other, which must be of the same size, see Field.Subtract.
+func (b *BitField) SubtractWith(other *BitField) error {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// combine sets every word of b to the result of op for it and the same word of other. The bits past the width stay
+// zero, as op keeps two zero bits zero.
+func (b *BitField) combine(other *BitField, op func(x, y uint64) uint64) error {
+	if other.width != b.width || other.height != b.height {
+		return fmt.Errorf("can't combine a %dx%d field with a %dx%d one", b.width, b.height, other.width, other.height)
+	}
+	for i, word := range other.w {
+		b.w[i] = op(b.w[i], word)
+	}
+	return nil
+}
+
+// Step writes the generation following b into next, which must have the same dimensions as b.
+func (b *BitField) Step(next *BitField) {
+	next.wrap, next.rule = b.wrap, b.rule
+	if b.width == 0 || b.height == 0 {
+		return
+	}
+	// Precompute which neighbour counts lead to a live cell, for dead and live cells respectively.
+	var counts [9]struct{ birth, survival uint64 }
+	for n := range counts {
+		if b.rule.Birth&(1<<n) != 0 {
+			counts[n].birth = ^uint64(0)
+		}
+		if b.rule.Survival&(1<<n) != 0 {
+			counts[n].survival = ^uint64(0)
+		}
+	}
+	lastMask := ^uint64(0) >> (b.words*64 - b.width)
+	zero := make([]uint64, b.words)
+	for y := uint(0); y < b.height; y++ {
+		above, below := zero, zero
+		if y > 0 {
+			above = b.row(y - 1)
+		} else if b.wrap {
+			above = b.row(b.height - 1)
+		}
+		if y+1 < b.height {
+			below = b.row(y + 1)
+		} else if b.wrap {
+			below = b.row(0)
+		}
+		current, out := b.row(y), next.row(y)
+		for i := uint(0); i < b.words; i++ {
+			// Sum the eight neighbours of all 64 cells as 4-bit numbers, bit n of the sum is stored in s[n].
+			var s [4]uint64
+			add := func(v uint64) {
+				for j := 0; j < 3 && v != 0; j++ {
+					s[j], v = s[j]^v, s[j]&v
+				}
+				s[3] |= v
+			}
+			aw, ae := b.shifted(above, i)
+			add(aw)
+			add(above[i])
+			add(ae)
+			cw, ce := b.shifted(current, i)
+			add(cw)
+			add(ce)
+			bw, be := b.shifted(below, i)
+			add(bw)
+			add(below[i])
+			add(be)
+
+			alive := current[i]
+			var word uint64
+			for n, c := range counts {
+				if c.birth == 0 && c.survival == 0 {
+					continue
+				}
+				eq := ^uint64(0)
+				for j := range s {
+					if n&(1<<j) != 0 {
+						eq &= s[j]
+					} else {
+						eq &^= s[j]
+					}
+				}
+				word |= eq & (c.birth&^alive | c.survival&alive)
+			}
+			if i == b.words-1 {
+				word &= lastMask
+			}
+			out[i] = word
+		}
+	}
+}
+
+func (b *BitField) row(y uint) []uint64 {
+	return b.w[y*b.words : (y+1)*b.words]
+}
+
+// shifted returns word i of row shifted so that every bit holds its west and east neighbour respectively.
+func (b *BitField) shifted(row []uint64, i uint) (west, east uint64) {
+	west = row[i] << 1
+	if i > 0 {
+		west |= row[i-1] >> 63
+	} else if b.wrap {
+		// The west neighbour of the first column is the last column.
+		last := b.width - 1
+		west |= row[last/64] >> (last % 64) & 1
+	}
+	east = row[i] >> 1
+	if i+1 < b.words {
+		east |= row[i+1] << 63
+	} else if b.wrap {
+		// The east neighbour of the last column is the first column.
+		east |= (row[0] & 1) << ((b.width - 1) % 64)
+	}
+	return west, east
+}
+
+// BitPackedEngine computes generations on a bit-packed copy of the field, see BitField.
+type BitPackedEngine struct {
+	current, next *BitField
+}
+
+// Step writes the generation following current into next.
+func (e *BitPackedEngine) Step(current, next *Field) {
+	if e.current == nil || e.current.width != current.width || e.current.height != current.height {
+		e.current = NewBitField(current.width, current.height, current.wrap)
+		e.next = NewBitField(current.width, current.height, current.wrap)
+	}
+	e.current.Load(current)
+	e.current.Step(e.next)
+	e.next.Store(next)
+	// The cells that changed are the bits that differ between the words of the generations.
+	var c changes
+	for i, word := range e.next.w {
+		for diff := word ^ e.current.w[i]; diff != 0; diff &= diff - 1 {
+			bit := diff & -diff
+			x := uint(i)%e.next.words*64 + uint(bits.TrailingZeros64(diff))
+			c.flip(x, uint(i)/e.next.words, word&bit != 0)
+		}
+	}
+	c.apply(current, next)
+}
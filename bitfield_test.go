@@ -0,0 +1,116 @@
+package life
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randomField returns a field of the given size where roughly a third of the cells are alive.
+func randomField(rng *rand.Rand, width, height uint, wrap bool) *Field {
+	f := NewField(width, height, wrap)
+	for y := uint(0); y < height; y++ {
+		for x := uint(0); x < width; x++ {
+			f.Set(x, y, rng.Intn(3) == 0)
+		}
+	}
+	return f
+}
+
+// assertSameEngine runs engine and NaiveEngine side by side on random fields and fails on the first difference.
+func assertSameEngine(t *testing.T, engine func() Engine) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	for _, width := range []uint{1, 2, 3, 63, 64, 65, 130} {
+		for _, height := range []uint{1, 2, 3, 17} {
+			for _, wrap := range []bool{true, false} {
+				want := &Game{current: randomField(rng, width, height, wrap), next: NewField(width, height, wrap), width: width, height: height}
+				got := &Game{current: NewField(width, height, wrap), next: NewField(width, height, wrap), width: width, height: height}
+				for y, row := range want.current.s {
+					copy(got.current.s[y], row)
+				}
+				got.current.pop = want.current.pop
+				got.SetEngine(engine())
+				for gen := 1; gen <= 20; gen++ {
+					want.Tick()
+					got.Tick()
+					if !equalCells(got.current, want.current) {
+						t.Fatalf("%dx%d wrap %t: generation %d differs:\n%s\nwanted:\n%s", width, height, wrap, gen, got.current, want.current)
+					}
+					if got.Population() != want.Population() {
+						t.Fatalf("%dx%d wrap %t: generation %d: got population %d, wanted %d", width, height, wrap, gen, got.Population(), want.Population())
+					}
+				}
+			}
+		}
+	}
+}
+
+func equalCells(a, b *Field) bool {
+	if a.width != b.width || a.height != b.height {
+		return false
+	}
+	for y, row := range a.s {
+		for x, alive := range row {
+			if b.s[y][x] != alive {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestBitPackedEngine(t *testing.T) {
+	assertSameEngine(t, func() Engine { return new(BitPackedEngine) })
+}
+
+func TestBitField(t *testing.T) {
+	f := fieldFromRows(true,
+		".o.",
+		"..o",
+		"ooo",
+	)
+	b := PackField(f)
+	if got := b.Population(); got != 5 {
+		t.Errorf("population: got %d, wanted 5", got)
+	}
+	for y := uint(0); y < 3; y++ {
+		for x := uint(0); x < 3; x++ {
+			if b.Alive(x, y) != f.Alive(int(x), int(y)) {
+				t.Errorf("cell %d,%d differs", x, y)
+			}
+		}
+	}
+	b.Set(0, 0, true)
+	b.Set(1, 0, false)
+	b.Store(f)
+	if !f.Alive(0, 0) || f.Alive(1, 0) || f.Population() != 5 {
+		t.Errorf("Store: got:\n%s", f)
+	}
+}
+
+func BenchmarkTick1024(b *testing.B) {
+	g := &Game{current: randomField(rand.New(rand.NewSource(1)), 1024, 1024, true), next: NewField(1024, 1024, true), width: 1024, height: 1024}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Tick()
+	}
+}
+
+func BenchmarkTick1024BitPacked(b *testing.B) {
+	g := &Game{current: randomField(rand.New(rand.NewSource(1)), 1024, 1024, true), next: NewField(1024, 1024, true), width: 1024, height: 1024}
+	g.SetEngine(new(BitPackedEngine))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Tick()
+	}
+}
+
+func BenchmarkBitFieldStep1024(b *testing.B) {
+	current := PackField(randomField(rand.New(rand.NewSource(1)), 1024, 1024, true))
+	next := NewBitField(1024, 1024, true)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		current.Step(next)
+		current, next = next, current
+	}
+}
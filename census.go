@@ -0,0 +1,344 @@
+package life
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Census is a count of the objects on a field, such as the still lifes, oscillators and spaceships that are left
+// when a soup has settled.
+type Census struct {
+	// Objects maps the names of known objects, e.g. "block" or "glider", to how many of them were found.
+	Objects map[string]int
+	// Unidentified maps the objects that aren't known by name to how many of them were found. An object is given
+	// by the cells of one of its orientations, as rows of 'o' for live and '.' for dead cells separated by '$'.
+	Unidentified map[string]int
+}
+
+// TakeCensus counts the objects on f. Live cells up to two cells apart are taken to belong to the same object, so
+// that objects whose cells don't all touch, like the toad, are counted as one. Groups of cells that aren't known
+// objects are joined with those nearby, for objects that fall apart in some of their phases, like the
+// pentadecathlon. Those that still aren't known are counted by their groups of touching cells, e.g. two blocks a
+// cell apart.
+// Oscillators and spaceships are recognised in any phase, and all objects in any orientation. The objects are
+// those of Conway's Game of Life, whatever the rule of f.
+func TakeCensus(f *Field) Census {
+	c := Census{Objects: make(map[string]int), Unidentified: make(map[string]int)}
+	seen := make([][]bool, f.height)
+	for y := range seen {
+		seen[y] = make([]bool, f.width)
+	}
+	var unknown [][]point
+	f.EachLive(func(x, y uint) {
+		if seen[y][x] {
+			return
+		}
+		group := f.group(seen, x, y)
+		if name, ok := objectName(group); ok {
+			c.Objects[name]++
+			return
+		}
+		unknown = append(unknown, group)
+	})
+	for i := range unknown {
+		if unknown[i] == nil {
+			continue
+		}
+		// Keep joining groups until the object is known or nothing is close enough anymore, because groups can
+		// come within reach through the ones joined after them.
+		a, joined := unknown[i], map[int]bool{i: true}
+	join:
+		for grown := true; grown; {
+			grown = false
+			for j := i + 1; j < len(unknown); j++ {
+				if unknown[j] == nil || joined[j] || len(a)+len(unknown[j]) > maxObjectCells {
+					continue
+				}
+				both, ok := f.join(a, unknown[j])
+				if !ok {
+					continue
+				}
+				a, joined[j], grown = both, true, true
+				if name, ok := objectName(a); ok {
+					c.Objects[name]++
+					for k := range joined {
+						unknown[k] = nil
+					}
+					break join
+				}
+			}
+		}
+	}
+	for _, group := range unknown {
+		for _, part := range touching(group) {
+			if name, ok := objectName(part); ok {
+				c.Objects[name]++
+			} else {
+				c.Unidentified[canonical(part)]++
+			}
+		}
+	}
+	return c
+}
+
+// point is the position of a cell of an object. Unlike the positions of the cells of a field, it may be negative,
+// so that objects lying across the edges of a wrapping field are in one piece.
+type point struct{ x, y int }
+
+// group returns the live cells of f that are connected to x,y through live cells up to two cells apart, and marks
+// them as seen.
+func (f *Field) group(seen [][]bool, x, y uint) []point {
+	type cell struct {
+		x, y uint
+		p    point
+	}
+	seen[y][x] = true
+	stack := []cell{{x, y, point{int(x), int(y)}}}
+	var cells []point
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cells = append(cells, c.p)
+		for dy := -2; dy <= 2; dy++ {
+			for dx := -2; dx <= 2; dx++ {
+				nx, ny := int(c.x)+dx, int(c.y)+dy
+				if f.wrap {
+					nx, ny = (nx+int(f.width))%int(f.width), (ny+int(f.height))%int(f.height)
+				} else if nx < 0 || ny < 0 || nx >= int(f.width) || ny >= int(f.height) {
+					continue
+				}
+				if f.s[ny][nx] && !seen[ny][nx] {
+					seen[ny][nx] = true
+					stack = append(stack, cell{uint(nx), uint(ny), point{c.p.x + dx, c.p.y + dy}})
+				}
+			}
+		}
+	}
+	return cells
+}
+
+// join returns the cells of a and b together, if they are close enough to be parts of the same object.
+// Across the edges of a wrapping field, b is moved next to a.
+func (f *Field) join(a, b []point) ([]point, bool) {
+	// The halves of the pentadecathlon are up to seven cells apart.
+	const distance = 7
+	for _, p := range a {
+		for _, q := range b {
+			dx, dy := q.x-p.x, q.y-p.y
+			if f.wrap {
+				dx, dy = nearest(dx, int(f.width)), nearest(dy, int(f.height))
+			}
+			if dx < -distance || dx > distance || dy < -distance || dy > distance {
+				continue
+			}
+			both := append([]point(nil), a...)
+			for _, r := range b {
+				both = append(both, point{r.x - q.x + p.x + dx, r.y - q.y + p.y + dy})
+			}
+			return both, true
+		}
+	}
+	return nil, false
+}
+
+// nearest returns the difference between two coordinates that is equivalent to d on a wrapping field of the given
+// size and closest to zero.
+func nearest(d, size int) int {
+	d %= size
+	if d > size/2 {
+		d -= size
+	} else if d < -size/2 {
+		d += size
+	}
+	return d
+}
+
+// touching splits cells into the groups of cells that touch each other, horizontally, vertically or diagonally.
+func touching(cells []point) [][]point {
+	left := make(map[point]bool, len(cells))
+	for _, p := range cells {
+		left[p] = true
+	}
+	var parts [][]point
+	for _, start := range cells {
+		if !left[start] {
+			continue
+		}
+		delete(left, start)
+		part := []point{start}
+		for i := 0; i < len(part); i++ {
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					n := point{part[i].x + dx, part[i].y + dy}
+					if left[n] {
+						delete(left, n)
+						part = append(part, n)
+					}
+				}
+			}
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// canonical returns the cells of an object in the orientation that comes first among its eight rotations and
+// reflections, written as rows of 'o' and '.' separated by '$'. All orientations of an object have the same
+// canonical form.
+func canonical(cells []point) string {
+	best := ""
+	oriented := make([]point, len(cells))
+	for t := 0; t < 8; t++ {
+		for i, p := range cells {
+			if t&1 != 0 {
+				p.x = -p.x
+			}
+			if t&2 != 0 {
+				p.y = -p.y
+			}
+			if t&4 != 0 {
+				p.x, p.y = p.y, p.x
+			}
+			oriented[i] = p
+		}
+		if s := rows(oriented); best == "" || s < best {
+			best = s
+		}
+	}
+	return best
+}
+
+// rows writes cells as rows of 'o' and '.' separated by '$', leaving out the dead cells at the end of each row.
+func rows(cells []point) string {
+	min := cells[0]
+	for _, p := range cells {
+		if p.x < min.x {
+			min.x = p.x
+		}
+		if p.y < min.y {
+			min.y = p.y
+		}
+	}
+	sorted := make([]point, len(cells))
+	for i, p := range cells {
+		sorted[i] = point{p.x - min.x, p.y - min.y}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		return a.y < b.y || a.y == b.y && a.x < b.x
+	})
+	var b strings.Builder
+	y, x := 0, 0
+	for _, p := range sorted {
+		for ; y < p.y; y++ {
+			b.WriteByte('$')
+			x = 0
+		}
+		for ; x < p.x; x++ {
+			b.WriteByte('.')
+		}
+		b.WriteByte('o')
+		x++
+	}
+	return b.String()
+}
+
+// knownObjects are the objects TakeCensus knows by name, each in one of its phases.
+var knownObjects = []struct {
+	name string
+	rows []string
+}{
+	{"block", []string{"oo", "oo"}},
+	{"beehive", []string{".oo.", "o..o", ".oo."}},
+	{"loaf", []string{".oo.", "o..o", ".o.o", "..o."}},
+	{"boat", []string{"oo.", "o.o", ".o."}},
+	{"ship", []string{"oo.", "o.o", ".oo"}},
+	{"tub", []string{".o.", "o.o", ".o."}},
+	{"pond", []string{".oo.", "o..o", "o..o", ".oo."}},
+	{"long boat", []string{"oo..", "o.o.", ".o.o", "..o."}},
+	{"barge", []string{".o..", "o.o.", ".o.o", "..o."}},
+	{"mango", []string{".oo..", "o..o.", ".o..o", "..oo."}},
+	{"eater 1", []string{"oo..", "o.o.", "..o.", "..oo"}},
+	{"aircraft carrier", []string{"oo..", "o..o", "..oo"}},
+	{"snake", []string{"oo.o", "o.oo"}},
+	{"blinker", []string{"ooo"}},
+	{"toad", []string{".ooo", "ooo."}},
+	{"beacon", []string{"oo..", "oo..", "..oo", "..oo"}},
+	{"pulsar", []string{
+		"..ooo...ooo..",
+		".............",
+		"o....o.o....o",
+		"o....o.o....o",
+		"o....o.o....o",
+		"..ooo...ooo..",
+		".............",
+		"..ooo...ooo..",
+		"o....o.o....o",
+		"o....o.o....o",
+		"o....o.o....o",
+		".............",
+		"..ooo...ooo..",
+	}},
+	{"pentadecathlon", []string{"..o....o..", "oo.oooo.oo", "..o....o.."}},
+	{"glider", []string{".o.", "..o", "ooo"}},
+	{"lightweight spaceship", []string{".o..o", "o....", "o...o", "oooo."}},
+	{"middleweight spaceship", []string{"..o...", "o...o.", ".....o", "o....o", ".ooooo"}},
+	{"heavyweight spaceship", []string{"..oo...", "o....o.", "......o", "o.....o", ".oooooo"}},
+}
+
+var (
+	objectNames     map[string]string
+	objectNamesOnce sync.Once
+	// maxObjectCells is the population of the largest phase of the known objects.
+	maxObjectCells int
+)
+
+// objectName returns the name of the object made of cells, if it is known.
+func objectName(cells []point) (string, bool) {
+	objectNamesOnce.Do(func() {
+		objectNames = make(map[string]string)
+		for _, o := range knownObjects {
+			for _, phase := range phases(o.rows) {
+				objectNames[phase] = o.name
+				if n := strings.Count(phase, "o"); n > maxObjectCells {
+					maxObjectCells = n
+				}
+			}
+		}
+	})
+	name, ok := objectNames[canonical(cells)]
+	return name, ok
+}
+
+// phases returns the canonical forms of every phase of the oscillator or spaceship given by rows, or the single
+// one of a still life.
+func phases(rows []string) []string {
+	// The known objects stay within a margin of 8 cells, so they evolve on a plane as they would on an infinite one.
+	const margin = 8
+	f := NewField(uint(len(rows[0]))+2*margin, uint(len(rows))+2*margin, false)
+	for y, row := range rows {
+		for x, c := range row {
+			if c == 'o' {
+				f.Set(uint(x)+margin, uint(y)+margin, true)
+			}
+		}
+	}
+	g := NewGameFromField(f)
+	var forms []string
+	for {
+		var cells []point
+		g.Field().EachLive(func(x, y uint) {
+			cells = append(cells, point{int(x), int(y)})
+		})
+		form := canonical(cells)
+		if len(forms) > 0 && form == forms[0] {
+			return forms
+		}
+		if len(forms) == 30 {
+			panic("life: known object " + rows[0] + " doesn't repeat within 30 generations")
+		}
+		forms = append(forms, form)
+		g.Tick()
+	}
+}
@@ -0,0 +1,63 @@
+package life
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTakeCensus(t *testing.T) {
+	// Every known object is recognised in every phase, orientation and position, also across the edges.
+	for _, o := range knownObjects {
+		for _, wrap := range []bool{true, false} {
+			g := NewGameFromField(NewField(40, 40, wrap))
+			x := uint(15)
+			if wrap {
+				x = 36
+			}
+			for y, row := range o.rows {
+				for dx, c := range row {
+					if c == 'o' {
+						g.Field().Set((x+uint(dx))%40, uint(y)+10, true)
+					}
+				}
+			}
+			for i := 0; i < 16; i++ {
+				c := TakeCensus(g.Field())
+				if want := map[string]int{o.name: 1}; !reflect.DeepEqual(c.Objects, want) || len(c.Unidentified) != 0 {
+					t.Errorf("%s wrap %t: generation %d: got %v and unidentified %v, wanted %v", o.name, wrap, i, c.Objects, c.Unidentified, want)
+					break
+				}
+				g.Tick()
+			}
+		}
+	}
+
+	f := fieldFromRows(false,
+		"oo.oo......",
+		"oo.oo...o..",
+		"........o..",
+		"..o.....o..",
+		".o.o.......",
+		"..o.....ooo",
+		"........o..",
+	)
+	got := TakeCensus(f)
+	want := Census{
+		Objects:      map[string]int{"block": 2, "tub": 1, "blinker": 1},
+		Unidentified: map[string]int{"..o$ooo": 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+func BenchmarkTakeCensus(b *testing.B) {
+	g := NewGame(256, 256, true)
+	for i := 0; i < 1000; i++ {
+		g.Tick()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		TakeCensus(g.Field())
+	}
+}
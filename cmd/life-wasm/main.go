@@ -0,0 +1,240 @@
+//go:build js && wasm
+
+// Command life-wasm runs games in the browser. Built with GOOS=js GOARCH=wasm, it sets a global object life with
+// functions to create games, whose methods tick them, read and set their cells and change their rule:
+//
+//	const g = life.newGame(80, 60, 0.25)      // width, height, density, optional wrap (true) and seed
+//	const h = life.fromRLE(text)               // an RLE pattern, optional wrap (true)
+//	g.tick(10)                                 // ticks once, or as often as given
+//	g.cells()                                  // Uint32Array of the live cells as x0, y0, x1, y1, ...
+//	g.board()                                  // the field as a string, like Field.String
+//	g.set(3, 4, true)
+//	g.setRule("B36/S23")
+//	g.width(), g.height(), g.generation(), g.population()
+//	g.release()                                // frees the methods once the game isn't needed anymore
+//
+// Arguments are checked, and a call that fails returns an Error instead of its result, so check results with
+// instanceof Error. Nothing panics across to JavaScript.
+//
+// See examples/wasm for a page that draws a game on a canvas.
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"syscall/js"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// maxSize is the largest width and height of a game, which keeps a typo from taking all of the memory of the page.
+const maxSize = 1 << 14
+
+func main() {
+	api := js.Global().Get("Object").New()
+	api.Set("newGame", export(newGame))
+	api.Set("fromRLE", export(fromRLE))
+	js.Global().Set("life", api)
+	// The functions are called for as long as the page is open.
+	select {}
+}
+
+// export wraps fn as a JavaScript function that returns an Error if fn fails or panics.
+func export(fn func(args []js.Value) (interface{}, error)) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) (result interface{}) {
+		defer func() {
+			if p := recover(); p != nil {
+				result = js.Global().Get("Error").New(fmt.Sprint(p))
+			}
+		}()
+		v, err := fn(args)
+		if err != nil {
+			return js.Global().Get("Error").New(err.Error())
+		}
+		return v
+	})
+}
+
+// newGame creates a random game from width, height and density, and optionally wrap and seed.
+func newGame(args []js.Value) (interface{}, error) {
+	if len(args) < 3 || len(args) > 5 {
+		return nil, errors.New("newGame takes width, height, density and optionally wrap and seed")
+	}
+	width, err := size(args[0], "width")
+	if err != nil {
+		return nil, err
+	}
+	height, err := size(args[1], "height")
+	if err != nil {
+		return nil, err
+	}
+	if err := life.CheckSize(width, height); err != nil {
+		return nil, err
+	}
+	density, err := number(args[2], "density")
+	if err != nil {
+		return nil, err
+	}
+	if density < 0 || density > 1 {
+		return nil, errors.New("density must be between 0 and 1")
+	}
+	wrap, err := optionalBool(args, 3, "wrap")
+	if err != nil {
+		return nil, err
+	}
+	seed := time.Now().UnixNano()
+	if len(args) > 4 {
+		s, err := number(args[4], "seed")
+		if err != nil {
+			return nil, err
+		}
+		seed = int64(s)
+	}
+	g, err := life.New(width, height, life.WithWrap(wrap), life.WithDensity(density), life.WithRand(rand.New(rand.NewSource(seed))))
+	if err != nil {
+		return nil, err
+	}
+	return newGameObject(g), nil
+}
+
+// fromRLE creates a game from an RLE pattern, and optionally wrap.
+func fromRLE(args []js.Value) (interface{}, error) {
+	if len(args) < 1 || len(args) > 2 || args[0].Type() != js.TypeString {
+		return nil, errors.New("fromRLE takes an RLE pattern as a string and optionally wrap")
+	}
+	wrap, err := optionalBool(args, 1, "wrap")
+	if err != nil {
+		return nil, err
+	}
+	l, err := life.ReadGame(strings.NewReader(args[0].String()), wrap)
+	if err != nil {
+		return nil, err
+	}
+	return newGameObject(l), nil
+}
+
+// newGameObject returns the JavaScript object of l, with its methods.
+func newGameObject(l *life.Game) js.Value {
+	o := js.Global().Get("Object").New()
+	var funcs []js.Func
+	method := func(name string, fn func(args []js.Value) (interface{}, error)) {
+		f := export(fn)
+		funcs = append(funcs, f)
+		o.Set(name, f)
+	}
+	method("tick", func(args []js.Value) (interface{}, error) {
+		n := 1
+		if len(args) > 0 {
+			v, err := number(args[0], "the number of ticks")
+			if err != nil {
+				return nil, err
+			}
+			if v < 0 || v != math.Trunc(v) || v > math.MaxInt32 {
+				return nil, errors.New("the number of ticks must be a whole number that isn't negative")
+			}
+			n = int(v)
+		}
+		for i := 0; i < n; i++ {
+			l.Tick()
+		}
+		return l.Generation(), nil
+	})
+	method("cells", func(args []js.Value) (interface{}, error) {
+		f := l.Field()
+		// Typed arrays are little-endian wherever browsers run.
+		b := make([]byte, 8*f.Population())
+		i := 0
+		f.EachLive(func(x, y uint) {
+			binary.LittleEndian.PutUint32(b[i:], uint32(x))
+			binary.LittleEndian.PutUint32(b[i+4:], uint32(y))
+			i += 8
+		})
+		bytes := js.Global().Get("Uint8Array").New(len(b))
+		js.CopyBytesToJS(bytes, b)
+		return js.Global().Get("Uint32Array").New(bytes.Get("buffer")), nil
+	})
+	method("board", func(args []js.Value) (interface{}, error) {
+		return l.Field().String(), nil
+	})
+	method("set", func(args []js.Value) (interface{}, error) {
+		if len(args) != 3 || args[2].Type() != js.TypeBoolean {
+			return nil, errors.New("set takes x, y and whether the cell is alive")
+		}
+		x, err := number(args[0], "x")
+		if err != nil {
+			return nil, err
+		}
+		y, err := number(args[1], "y")
+		if err != nil {
+			return nil, err
+		}
+		f := l.Field()
+		inside := x >= 0 && y >= 0 && x < float64(f.Width()) && y < float64(f.Height())
+		if !inside || x != math.Trunc(x) || y != math.Trunc(y) {
+			return nil, fmt.Errorf("%v,%v isn't a cell of the %dx%d field", x, y, f.Width(), f.Height())
+		}
+		f.Set(uint(x), uint(y), args[2].Bool())
+		return nil, nil
+	})
+	method("setRule", func(args []js.Value) (interface{}, error) {
+		if len(args) != 1 || args[0].Type() != js.TypeString {
+			return nil, errors.New("setRule takes a rule like B3/S23")
+		}
+		r, err := life.ParseRule(args[0].String())
+		if err != nil {
+			return nil, err
+		}
+		l.SetRule(r)
+		return nil, nil
+	})
+	method("width", func(args []js.Value) (interface{}, error) { return l.Field().Width(), nil })
+	method("height", func(args []js.Value) (interface{}, error) { return l.Field().Height(), nil })
+	method("generation", func(args []js.Value) (interface{}, error) { return l.Generation(), nil })
+	method("population", func(args []js.Value) (interface{}, error) { return l.Population(), nil })
+	method("release", func(args []js.Value) (interface{}, error) {
+		// The functions are released after this one returns, as it is one of them.
+		go func() {
+			for _, f := range funcs {
+				f.Release()
+			}
+		}()
+		return nil, nil
+	})
+	return o
+}
+
+// number returns v if it is a number.
+func number(v js.Value, name string) (float64, error) {
+	if v.Type() != js.TypeNumber {
+		return 0, fmt.Errorf("%s must be a number, not %s", name, v.Type())
+	}
+	return v.Float(), nil
+}
+
+// size returns v if it is a valid width or height.
+func size(v js.Value, name string) (uint, error) {
+	n, err := number(v, name)
+	if err != nil {
+		return 0, err
+	}
+	if n < 1 || n > maxSize || n != math.Trunc(n) {
+		return 0, fmt.Errorf("%s must be a whole number from 1 to %d", name, maxSize)
+	}
+	return uint(n), nil
+}
+
+// optionalBool returns args[i] if it is a boolean, or true if there is no such argument.
+func optionalBool(args []js.Value, i int, name string) (bool, error) {
+	if len(args) <= i || args[i].IsUndefined() {
+		return true, nil
+	}
+	if args[i].Type() != js.TypeBoolean {
+		return false, fmt.Errorf("%s must be a boolean, not %s", name, args[i].Type())
+	}
+	return args[i].Bool(), nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/418Coffee/life"
+)
+
+// maxAt is the most generations -at can list, so that a range like 0-1000000000 doesn't use up the memory.
+const maxAt = 100000
+
+// genList is the value of the -at flag: generations, sorted and without duplicates. It is given as a comma-separated
+// list of generations and ranges FROM-TO, which hold every generation from FROM to TO, or every STEPth one as
+// FROM-TO:STEP.
+type genList []uint
+
+func (g *genList) String() string {
+	if g == nil {
+		return ""
+	}
+	s := make([]string, len(*g))
+	for i, gen := range *g {
+		s[i] = strconv.FormatUint(uint64(gen), 10)
+	}
+	return strings.Join(s, ",")
+}
+
+func (g *genList) Set(s string) error {
+	parse := func(s string) (uint, error) {
+		n, err := strconv.ParseUint(s, 10, strconv.IntSize)
+		if err != nil {
+			return 0, fmt.Errorf("%q isn't a generation", s)
+		}
+		return uint(n), nil
+	}
+	var gens []uint
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		dash := strings.IndexByte(item, '-')
+		if dash < 0 {
+			gen, err := parse(item)
+			if err != nil {
+				return err
+			}
+			gens = append(gens, gen)
+			continue
+		}
+		to, step := item[dash+1:], "1"
+		if colon := strings.IndexByte(to, ':'); colon >= 0 {
+			to, step = to[:colon], to[colon+1:]
+		}
+		from, err := parse(item[:dash])
+		if err != nil {
+			return err
+		}
+		last, err := parse(to)
+		if err != nil {
+			return err
+		}
+		n, err := strconv.ParseUint(step, 10, strconv.IntSize)
+		if err != nil || n == 0 {
+			return fmt.Errorf("the step of %q must be a positive number", item)
+		}
+		if from > last {
+			return fmt.Errorf("the range %q ends before it starts", item)
+		}
+		if (last-from)/uint(n) >= maxAt {
+			return fmt.Errorf("%q holds more than %d generations", item, maxAt)
+		}
+		for gen := from; ; gen += uint(n) {
+			gens = append(gens, gen)
+			if last-gen < uint(n) {
+				break
+			}
+		}
+	}
+	sort.Slice(gens, func(i, j int) bool { return gens[i] < gens[j] })
+	*g = (*g)[:0]
+	for i, gen := range gens {
+		if i == 0 || gen != gens[i-1] {
+			*g = append(*g, gen)
+		}
+	}
+	if len(*g) > maxAt {
+		return fmt.Errorf("more than %d generations", maxAt)
+	}
+	return nil
+}
+
+// contains reports whether gen is one of the generations of g.
+func (g genList) contains(gen uint) bool {
+	i := sort.Search(len(g), func(i int) bool { return g[i] >= gen })
+	return i < len(g) && g[i] == gen
+}
+
+// beyond returns the generations of g after the last one of a run of ticks generations.
+func (g genList) beyond(ticks uint) genList {
+	i := sort.Search(len(g), func(i int) bool { return g[i] > ticks })
+	return g[i:]
+}
+
+// atWriter draws the generations of -at as a run reaches them, each after a line with its generation like the
+// frames of runs that aren't drawn to a terminal, or writes them to files in dir.
+type atWriter struct {
+	gens   genList
+	dir    string
+	format string
+	r      life.Renderer
+	header bool
+	w      *bufio.Writer
+}
+
+// write draws or writes the current generation of l if it is one of the listed ones.
+func (a *atWriter) write(l *life.Game) error {
+	gen := l.Generation()
+	if !a.gens.contains(gen) {
+		return nil
+	}
+	if a.dir != "" {
+		format := recordFormats[a.format]
+		return format.write(recordingName(a.dir, gen, a.format), l.Field(), fmt.Sprintf("Generation %d.", gen))
+	}
+	if a.header {
+		a.w.WriteString("--- " + l.Header(0))
+	} else {
+		a.w.WriteString("--- gen " + strconv.FormatUint(uint64(gen), 10))
+	}
+	a.w.WriteByte('\n')
+	if err := a.r.Render(a.w, l.Field()); err != nil {
+		return err
+	}
+	return a.w.Flush()
+}
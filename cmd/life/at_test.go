@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenList(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want genList
+	}{
+		{"0,10,100,1000", genList{0, 10, 100, 1000}},
+		{"100,0,10,10", genList{0, 10, 100}},
+		{"0-100:20", genList{0, 20, 40, 60, 80, 100}},
+		{"5-12:5,3-5", genList{3, 4, 5, 10}},
+		{"7-7", genList{7}},
+	} {
+		var g genList
+		if err := g.Set(tt.s); err != nil {
+			t.Errorf("%q: %v", tt.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(g, tt.want) {
+			t.Errorf("%q: got %v, wanted %v", tt.s, g, tt.want)
+		}
+	}
+	for _, s := range []string{"", "a", "1,,2", "5-1", "0-10:0", "0-10:x", "-5", "0-1000000"} {
+		var g genList
+		if err := g.Set(s); err == nil {
+			t.Errorf("%q: got %v, wanted an error", s, g)
+		}
+	}
+}
+
+func TestGenListContains(t *testing.T) {
+	g := genList{0, 10, 100, 1000}
+	for gen, want := range map[uint]bool{0: true, 5: false, 100: true, 1000: true, 1001: false} {
+		if g.contains(gen) != want {
+			t.Errorf("contains(%d): got %v", gen, !want)
+		}
+	}
+	if got := g.beyond(100); !reflect.DeepEqual(got, genList{1000}) {
+		t.Errorf("beyond 100: got %v, wanted [1000]", got)
+	}
+	if got := g.beyond(1000); len(got) != 0 {
+		t.Errorf("beyond 1000: got %v, wanted none", got)
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// bench runs the bench subcommand with the given arguments. It times how long engines take to compute a number of
+// generations of the same random soup, without drawing anything.
+func bench(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s bench [options]\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	size := fs.Uint("size", 1024, "width and height of the field")
+	generations := fs.Uint("generations", 1000, "generations computed per measurement")
+	engines := fs.String("engine", "naive", "comma-separated engines to compare, or all: "+strings.Join(life.EngineNames(), ", "))
+	seed := fs.Int64("seed", 1, "seed for the random soup")
+	density := fs.Float64("density", life.DefaultDensity, "probability of a cell being alive in the random soup")
+	runs := fs.Int("runs", 5, "measurements per engine, of which the best and the median are reported")
+	nowrap := fs.Bool("nowrap", false, "don't wrap field toroidally")
+	verify := fs.String("verify", "", "run two engines side by side instead, e.g. naive,bitpacked, until they differ")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *size == 0 || *generations == 0 || *runs < 1 {
+		return fmt.Errorf("-size, -generations and -runs must be positive")
+	}
+	if err := life.CheckSize(*size, *size); err != nil {
+		return fmt.Errorf("-size: %w", err)
+	}
+	if *density < 0 || *density > 1 {
+		return fmt.Errorf("-density must be between 0 and 1")
+	}
+	if *verify != "" {
+		return verifyEngines(*verify, *size, *generations, *seed, *density, !*nowrap)
+	}
+	names := strings.Split(*engines, ",")
+	if *engines == "all" {
+		names = life.EngineNames()
+	}
+	for _, name := range names {
+		if _, err := life.NewEngine(name); err != nil {
+			return err
+		}
+	}
+
+	soup := life.NewField(*size, *size, !*nowrap)
+	soup.Randomize(*density, rand.New(rand.NewSource(*seed)))
+	fmt.Printf("%dx%d soup with seed %d and density %g, %d generations, best and median of %d runs\n",
+		*size, *size, *seed, *density, *generations, *runs)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "engine\tbest\tmedian\tgenerations/s\tcell updates/s")
+	for _, name := range names {
+		times := make([]time.Duration, *runs)
+		for i := range times {
+			// Every run starts from the same soup, with a fresh engine that has no state left from the last.
+			e, _ := life.NewEngine(name)
+			g := life.NewGameFromField(soup.Clone()).WithEngine(e)
+			start := time.Now()
+			for n := uint(0); n < *generations; n++ {
+				g.Tick()
+			}
+			times[i] = time.Since(start)
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		best, median := times[0], times[len(times)/2]
+		perSecond := float64(*generations) / best.Seconds()
+		fmt.Fprintf(w, "%s\t%v\t%v\t%.1f\t%s\n", name, best.Round(time.Microsecond), median.Round(time.Microsecond),
+			perSecond, si(perSecond*float64(*size)*float64(*size)))
+	}
+	return w.Flush()
+}
+
+// verifyEngines runs the two comma-separated engines of pair side by side on a random soup with the given seed and
+// density, see life.Verify, and returns an error describing the first generation they compute differently.
+func verifyEngines(pair string, size, generations uint, seed int64, density float64, wrap bool) error {
+	names := strings.Split(pair, ",")
+	if len(names) != 2 {
+		return fmt.Errorf("-verify takes two engines separated by a comma, e.g. naive,bitpacked")
+	}
+	a, err := life.NewEngine(names[0])
+	if err != nil {
+		return err
+	}
+	b, err := life.NewEngine(names[1])
+	if err != nil {
+		return err
+	}
+	soup := life.NewField(size, size, wrap)
+	soup.Randomize(density, rand.New(rand.NewSource(seed)))
+	fmt.Printf("%dx%d soup with seed %d and density %g, %s and %s side by side for %d generations\n",
+		size, size, seed, density, names[0], names[1], generations)
+	start := time.Now()
+	if err := life.Verify(a, b, soup, generations); err != nil {
+		return fmt.Errorf("%s and %s: %w", names[0], names[1], err)
+	}
+	fmt.Printf("no differences, in %v\n", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// si formats v with an SI prefix, e.g. 1.5G for 1.5e9.
+func si(v float64) string {
+	for _, prefix := range []string{"", "k", "M", "G"} {
+		if v < 1000 {
+			return fmt.Sprintf("%.1f%s", v, prefix)
+		}
+		v /= 1000
+	}
+	return fmt.Sprintf("%.1fT", v)
+}
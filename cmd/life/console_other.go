@@ -0,0 +1,21 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// enableANSI reports whether f is a terminal that understands ANSI escape sequences.
+// Terminals outside of Windows are assumed to.
+func enableANSI(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// clearConsole clears a terminal that doesn't understand ANSI escape sequences, which don't exist outside of
+// Windows.
+func clearConsole(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,49 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	kernel32                       = windows.NewLazySystemDLL("kernel32.dll")
+	procFillConsoleOutputCharacter = kernel32.NewProc("FillConsoleOutputCharacterW")
+	procFillConsoleOutputAttribute = kernel32.NewProc("FillConsoleOutputAttribute")
+)
+
+// enableANSI reports whether f is a console that understands ANSI escape sequences, after turning on their
+// processing. Consoles older than Windows 10 don't support them.
+func enableANSI(f *os.File) bool {
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	return windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING) == nil
+}
+
+// clearConsole clears the visible part of a console that doesn't understand ANSI escape sequences through the
+// console API, and moves the cursor to its top-left corner. The scrollback is left alone.
+func clearConsole(f *os.File) error {
+	handle := windows.Handle(f.Fd())
+	var info windows.ConsoleScreenBufferInfo
+	if err := windows.GetConsoleScreenBufferInfo(handle, &info); err != nil {
+		return err
+	}
+	top := windows.Coord{X: 0, Y: info.Window.Top}
+	n := uint32(info.Size.X) * uint32(info.Window.Bottom-info.Window.Top+1)
+	// The functions take a COORD by value, which fits in a single argument.
+	coord := uintptr(uint16(top.X)) | uintptr(uint16(top.Y))<<16
+	var written uint32
+	if r, _, err := procFillConsoleOutputCharacter.Call(uintptr(handle), ' ', uintptr(n), coord, uintptr(unsafe.Pointer(&written))); r == 0 {
+		return err
+	}
+	if r, _, err := procFillConsoleOutputAttribute.Call(uintptr(handle), uintptr(info.Attributes), uintptr(n), coord, uintptr(unsafe.Pointer(&written))); r == 0 {
+		return err
+	}
+	return windows.SetConsoleCursorPosition(handle, top)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/418Coffee/life"
+)
+
+// convert runs the convert subcommand with the given arguments. It writes a pattern file in another format.
+func convert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s convert [options] in out\n"+
+			"Writes the pattern of in to out, reading RLE from standard input if in is - and writing to standard output "+
+			"if out is -.\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", "", "format of out: "+strings.Join(recordFormatNames(), ", ")+
+		" (pbm is the binary P4 format, pbm-plain the plain P1 format); by default pbm for the .pbm extension and rle otherwise")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	return convertPattern(fs.Arg(0), fs.Arg(1), *format)
+}
+
+// convertPattern reads the pattern file in and writes it to out in the named format of recordFormats, or in the
+// format told by the extension of out if format is empty. The comments of the pattern are written along.
+func convertPattern(in, out, format string) error {
+	if format == "" {
+		format = "rle"
+		if filepath.Ext(out) == ".pbm" {
+			format = "pbm"
+		}
+	}
+	rf, ok := recordFormats[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q (available: %s)", format, strings.Join(recordFormatNames(), ", "))
+	}
+	var l *life.Game
+	var err error
+	// The field doesn't wrap, so that the pattern only gets the bounded grid of a torus if it had one.
+	if in == "-" {
+		l, err = life.ReadGame(os.Stdin, false)
+	} else {
+		l, err = life.LoadGame(in, false)
+	}
+	if err != nil {
+		return err
+	}
+	printWarnings(in, l)
+	var comments []string
+	if c := l.Comment(); c != "" {
+		comments = strings.Split(strings.TrimSuffix(c, "\n"), "\n")
+	}
+	if out != "-" {
+		return rf.write(out, l.Field(), comments...)
+	}
+	if format == "rle" {
+		return l.Field().WriteRLE(os.Stdout, comments...)
+	}
+	return l.Field().WritePBM(os.Stdout, format == "pbm-plain", comments...)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestConvertPattern(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "glider.rle")
+	if err := os.WriteFile(in, []byte("#C A glider.\nx = 11, y = 3, rule = B3/S23\nbo$2bo$3o!\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		out, format, magic string
+	}{
+		{"glider.pbm", "", "P4\n"},
+		{"plain.pbm", "pbm-plain", "P1\n"},
+		{"binary.img", "pbm", "P4\n"},
+		{"copy.rle", "", "#C A glider.\nx = 11"},
+	} {
+		out := filepath.Join(dir, test.out)
+		if err := convertPattern(in, out, test.format); err != nil {
+			t.Fatalf("%s: %v", test.out, err)
+		}
+		b, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(b), test.magic) {
+			t.Errorf("%s: got %q, wanted it to start with %q", test.out, b, test.magic)
+		}
+		// LoadGame tells the format by the extension, which doesn't give away this image.
+		if test.out == "binary.img" {
+			continue
+		}
+		l, err := life.LoadGame(out, false)
+		if err != nil {
+			t.Fatalf("%s: %v", test.out, err)
+		}
+		if f := l.Field(); f.Width() != 11 || f.Population() != 5 || !f.Alive(2, 1) || l.Comment() != "A glider.\n" {
+			t.Errorf("%s: got the comment %q and:\n%s", test.out, l.Comment(), f)
+		}
+	}
+	if err := convertPattern(in, filepath.Join(dir, "out.rle"), "gif"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// statsColumns is the header row of the file written by -csv.
+var statsColumns = []string{"generation", "population", "births", "deaths", "density", "bounding_box_area"}
+
+// statsWriter writes the statistics of every generation of a run as rows of CSV, for -csv.
+// Rows are written as they come rather than collected, and flushed at least once a second, so that the file of a
+// run that is killed still holds all but the last moments of it.
+type statsWriter struct {
+	w *csv.Writer
+	// file is closed when done, unless the rows go to standard output.
+	file    *os.File
+	flushed time.Time
+}
+
+// createStatsWriter creates the named file, or uses standard output if name is -, and writes the header row.
+func createStatsWriter(name string) (*statsWriter, error) {
+	if name == "-" {
+		return newStatsWriter(os.Stdout)
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newStatsWriter(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	s.file = file
+	return s, nil
+}
+
+func newStatsWriter(w io.Writer) (*statsWriter, error) {
+	s := &statsWriter{w: csv.NewWriter(w), flushed: time.Now()}
+	if err := s.w.Write(statsColumns); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// write writes the row of the current generation of l.
+func (s *statsWriter) write(l *life.Game) error {
+	st := l.Stats()
+	err := s.w.Write([]string{
+		strconv.FormatUint(uint64(st.Generation), 10),
+		strconv.FormatUint(uint64(st.Population), 10),
+		strconv.FormatUint(uint64(st.Births), 10),
+		strconv.FormatUint(uint64(st.Deaths), 10),
+		strconv.FormatFloat(st.Density, 'g', -1, 64),
+		strconv.FormatUint(uint64(st.BoundingBoxArea), 10),
+	})
+	if err != nil {
+		return err
+	}
+	if time.Since(s.flushed) >= time.Second {
+		s.w.Flush()
+		s.flushed = time.Now()
+		return s.w.Error()
+	}
+	return nil
+}
+
+// close flushes the rows that are left and closes the file.
+func (s *statsWriter) close() error {
+	s.w.Flush()
+	err := s.w.Error()
+	if s.file != nil {
+		if cerr := s.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestStatsWriter(t *testing.T) {
+	// A glider on an empty plane gains and loses two cells every generation and keeps to a 3 by 3 box.
+	f := life.NewField(12, 12, false)
+	for _, c := range []life.Cell{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}} {
+		f.Set(c.X, c.Y, true)
+	}
+	l := life.NewGameFromField(f)
+	var b bytes.Buffer
+	s, err := newStatsWriter(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.write(l); err != nil {
+		t.Fatal(err)
+	}
+	for l.Generation() < 10 {
+		l.Tick()
+		if err := s.write(l); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.close(); err != nil {
+		t.Fatal(err)
+	}
+	want := "generation,population,births,deaths,density,bounding_box_area\n0,5,0,0,0.034722222222222224,9\n"
+	for gen := 1; gen <= 10; gen++ {
+		want += strconv.Itoa(gen) + ",5,2,2,0.034722222222222224,9\n"
+	}
+	if got := b.String(); got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+}
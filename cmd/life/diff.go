@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/418Coffee/life"
+)
+
+// errDifferent is returned by diffPatterns when the patterns turn out different, which it has already reported.
+var errDifferent = errors.New("patterns differ")
+
+// diffPatterns runs the diff subcommand with the given arguments. It runs two patterns, or one pattern twice, for
+// the same number of generations and compares the results.
+func diffPatterns(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s diff [options] a.rle [b.rle]\n"+
+			"Runs both patterns, or a.rle twice, and exits with 1 if the results differ.\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	ticks := fs.Uint("ticks", 100, "generations to run both patterns for")
+	engine := fs.String("engine", "naive", "how generations are computed: "+strings.Join(life.EngineNames(), ", "))
+	engineB := fs.String("engine-b", "", "how generations of the second pattern are computed, if not like the first")
+	nowrap := fs.Bool("nowrap", false, "don't wrap the fields toroidally")
+	nowrapB := fs.Bool("nowrap-b", false, "don't wrap the field of the second pattern toroidally")
+	ruleString := fs.String("rule", "B3/S23", "rule in B/S notation for both patterns")
+	verbose := fs.Bool("v", false, "list the first differing cells")
+	radius := fs.Uint("radius", 8, "how many cells the patterns may be shifted against each other to find how similar they are")
+	// Flags may also follow the file names.
+	var files []string
+	for fs.Parse(args); fs.NArg() > 0; fs.Parse(args) {
+		files, args = append(files, fs.Arg(0)), fs.Args()[1:]
+	}
+	if len(files) == 1 {
+		files = append(files, files[0])
+	}
+	if len(files) != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	if *engineB == "" {
+		*engineB = *engine
+	}
+	rule, err := life.ParseRule(*ruleString)
+	if err != nil {
+		return err
+	}
+
+	run := func(file, engine string, wrap bool) (*life.Field, error) {
+		g, err := life.LoadGame(file, wrap)
+		if err != nil {
+			return nil, err
+		}
+		printWarnings(file, g)
+		e, err := life.NewEngine(engine)
+		if err != nil {
+			return nil, err
+		}
+		g.SetRule(rule)
+		g.SetEngine(e)
+		for g.Generation() < *ticks {
+			g.Tick()
+		}
+		return g.Field(), nil
+	}
+	a, err := run(files[0], *engine, !*nowrap)
+	if err != nil {
+		return err
+	}
+	b, err := run(files[1], *engineB, !*nowrap && !*nowrapB)
+	if err != nil {
+		return err
+	}
+
+	// aligned tells how similar the patterns are wherever they are, if that is more than unaligned.
+	aligned := func(unaligned float64) string {
+		j, shift := a.AlignedJaccard(b, *radius)
+		if j <= unaligned {
+			return ""
+		}
+		if shift == (life.Point{}) {
+			return fmt.Sprintf("%.4f with the patterns aligned", j)
+		}
+		return fmt.Sprintf("%.4f with the patterns aligned and b shifted by %d,%d", j, shift.X, shift.Y)
+	}
+	if a.Width() != b.Width() || a.Height() != b.Height() {
+		fmt.Printf("different sizes: %dx%d and %dx%d\n", a.Width(), a.Height(), b.Width(), b.Height())
+		if s := aligned(-1); s != "" {
+			fmt.Printf("jaccard index %s\n", s)
+		}
+		return errDifferent
+	}
+	var differ []life.Cell
+	for y := uint(0); y < a.Height(); y++ {
+		for x := uint(0); x < a.Width(); x++ {
+			if a.Alive(int(x), int(y)) != b.Alive(int(x), int(y)) {
+				differ = append(differ, life.Cell{X: x, Y: y})
+			}
+		}
+	}
+	if len(differ) == 0 {
+		fmt.Printf("identical after %d generations, population %d\n", *ticks, a.Population())
+		return nil
+	}
+	fmt.Printf("%d cells differ after %d generations, population %d and %d\n", len(differ), *ticks, a.Population(), b.Population())
+	j, _ := a.Jaccard(b)
+	if s := aligned(j); s != "" {
+		fmt.Printf("jaccard index %.4f, %s\n", j, s)
+	} else {
+		fmt.Printf("jaccard index %.4f\n", j)
+	}
+	overlay(a, b, differ)
+	if *verbose {
+		for i, c := range differ {
+			if i == 10 {
+				fmt.Printf("and %d more\n", len(differ)-i)
+				break
+			}
+			alive := "b"
+			if a.Alive(int(c.X), int(c.Y)) {
+				alive = "a"
+			}
+			fmt.Printf("%d,%d alive in %s only\n", c.X, c.Y, alive)
+		}
+	}
+	return errDifferent
+}
+
+// overlay draws the part of a and b around the differing cells, marking the cells alive only in a with a and
+// those alive only in b with b. It shows at most 80 by 40 cells, starting from the first difference.
+func overlay(a, b *life.Field, differ []life.Cell) {
+	const margin, maxWidth, maxHeight = 2, 80, 40
+	min, max := differ[0], differ[0]
+	for _, c := range differ {
+		if c.X < min.X {
+			min.X = c.X
+		}
+		if c.X > max.X {
+			max.X = c.X
+		}
+		max.Y = c.Y
+	}
+	from := life.Cell{X: saturatingSub(min.X, margin), Y: saturatingSub(min.Y, margin)}
+	to := life.Cell{X: max.X + margin, Y: max.Y + margin}
+	if to.X >= a.Width() {
+		to.X = a.Width() - 1
+	}
+	if to.Y >= a.Height() {
+		to.Y = a.Height() - 1
+	}
+	if to.X-from.X >= maxWidth {
+		to.X = from.X + maxWidth - 1
+	}
+	if to.Y-from.Y >= maxHeight {
+		to.Y = from.Y + maxHeight - 1
+	}
+	fmt.Printf("cells %d,%d to %d,%d (█ alive in both, a or b alive in one only):\n", from.X, from.Y, to.X, to.Y)
+	var line strings.Builder
+	for y := from.Y; y <= to.Y; y++ {
+		line.Reset()
+		for x := from.X; x <= to.X; x++ {
+			inA, inB := a.Alive(int(x), int(y)), b.Alive(int(x), int(y))
+			switch {
+			case inA && inB:
+				line.WriteString("█")
+			case inA:
+				line.WriteByte('a')
+			case inB:
+				line.WriteByte('b')
+			default:
+				line.WriteByte('.')
+			}
+		}
+		fmt.Println(line.String())
+	}
+}
+
+func saturatingSub(a, b uint) uint {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
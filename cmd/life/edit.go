@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/418Coffee/life"
+	"golang.org/x/term"
+)
+
+// shapes are stamped onto the field by the number keys of the editor.
+var shapes = []struct {
+	name string
+	rows []string
+}{
+	{"glider", []string{".o.", "..o", "ooo"}},
+	{"lightweight spaceship", []string{".o..o", "o....", "o...o", "oooo."}},
+	{"R-pentomino", []string{".oo", "oo.", ".o."}},
+	{"blinker", []string{"ooo"}},
+	{"block", []string{"oo", "oo"}},
+	{"acorn", []string{".o.....", "...o...", "oo..ooo"}},
+}
+
+// editor lets the user draw on a field with the keyboard.
+type editor struct {
+	f        *life.Field
+	wrap     bool
+	x, y     uint
+	status   string
+	saving   bool
+	filename []byte
+}
+
+// edit runs the editor on f, which wraps around its edges if wrap is set, until the user starts the run or quits.
+// It reports whether the run should start.
+func edit(f *life.Field, wrap bool, keys <-chan key, out *bufio.Writer) (bool, error) {
+	e := &editor{f: f, wrap: wrap, status: "arrows or hjkl move, space toggles, 1-6 stamp shapes, s saves, enter runs, q quits"}
+	for {
+		if err := e.draw(out); err != nil {
+			return false, err
+		}
+		k := <-keys
+		if e.saving {
+			e.prompt(k)
+			continue
+		}
+		switch k {
+		case keyUp, 'k':
+			e.move(0, -1)
+		case keyDown, 'j':
+			e.move(0, 1)
+		case keyLeft, 'h':
+			e.move(-1, 0)
+		case keyRight, 'l':
+			e.move(1, 0)
+		case ' ':
+			e.f.Set(e.x, e.y, !e.f.Alive(int(e.x), int(e.y)))
+		case 's':
+			e.saving, e.filename = true, e.filename[:0]
+		case '\r', '\n':
+			return true, nil
+		case 'q', 3: // 3 is Ctrl-C
+			return false, nil
+		default:
+			if k >= '1' && int(k-'1') < len(shapes) {
+				e.stamp(int(k - '1'))
+			}
+		}
+	}
+}
+
+// move moves the cursor, wrapping around the edges if the field wraps and stopping at them otherwise.
+func (e *editor) move(dx, dy int) {
+	x, y := int(e.x)+dx, int(e.y)+dy
+	w, h := int(e.f.Width()), int(e.f.Height())
+	if e.wrap {
+		x, y = (x+w)%w, (y+h)%h
+	}
+	if x >= 0 && y >= 0 && x < w && y < h {
+		e.x, e.y = uint(x), uint(y)
+	}
+}
+
+// stamp brings the cells of shape i to life with its top-left corner at the cursor.
+// Cells past the edges wrap around if the field wraps and are dropped otherwise.
+func (e *editor) stamp(i int) {
+	w, h := e.f.Width(), e.f.Height()
+	for py, row := range shapes[i].rows {
+		for px, c := range row {
+			x, y := e.x+uint(px), e.y+uint(py)
+			if e.wrap {
+				x, y = x%w, y%h
+			}
+			if c == 'o' && x < w && y < h {
+				e.f.Set(x, y, true)
+			}
+		}
+	}
+	e.status = "stamped a " + shapes[i].name
+}
+
+// prompt handles the keys typed while asking for the name of the file to save to.
+func (e *editor) prompt(k key) {
+	switch {
+	case k == '\r' || k == '\n':
+		e.saving = false
+		name := string(e.filename)
+		if name == "" {
+			name = "pattern.rle"
+		}
+		if err := writeRLE(name, e.f); err != nil {
+			e.status = err.Error()
+		} else {
+			e.status = "saved to " + name
+		}
+	case k == 0x1b || k == 3:
+		e.saving = false
+		e.status = "not saved"
+	case k == 0x7f || k == '\b':
+		if len(e.filename) > 0 {
+			e.filename = e.filename[:len(e.filename)-1]
+		}
+	case k >= ' ' && k < 0x7f:
+		e.filename = append(e.filename, byte(k))
+	}
+}
+
+// draw redraws the status line and the field, and puts the terminal cursor on the cell being edited.
+func (e *editor) draw(out *bufio.Writer) error {
+	status := fmt.Sprintf("edit %d,%d  %s", e.x, e.y, e.status)
+	if e.saving {
+		status = "save as (pattern.rle): " + string(e.filename)
+	}
+	if cols, _, err := term.GetSize(int(screen.Fd())); err == nil && len(status) > cols {
+		status = status[:cols]
+	}
+	out.WriteString(life.ClearScreen)
+	out.WriteString(status)
+	out.WriteByte('\n')
+	e.f.WriteTo(out)
+	if e.saving {
+		fmt.Fprintf(out, "\x1b[1;%dH", len(status)+1)
+	} else {
+		fmt.Fprintf(out, "\x1b[%d;%dH", e.y+2, e.x+1)
+	}
+	return out.Flush()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/418Coffee/life"
+)
+
+// fitMode is the value of the -fit flag: whether the field is fitted to the terminal, and what happens when the
+// terminal is resized. Given without a value, as -fit, the view is cropped.
+type fitMode string
+
+const (
+	fitNone = fitMode("")
+	// fitCrop keeps the size of the field and only draws as much of it as fits.
+	fitCrop = fitMode("crop")
+	// fitResize resizes the field to fill the terminal again.
+	fitResize = fitMode("resize")
+)
+
+func (m *fitMode) String() string {
+	if m == nil || *m == fitNone {
+		return "false"
+	}
+	return string(*m)
+}
+
+func (m *fitMode) Set(v string) error {
+	switch v {
+	case "true", string(fitCrop):
+		*m = fitCrop
+	case "false":
+		*m = fitNone
+	case string(fitResize):
+		*m = fitResize
+	default:
+		return fmt.Errorf("unknown fit mode %q, expected crop or resize", v)
+	}
+	return nil
+}
+
+// IsBoolFlag lets -fit be given without a value.
+func (m *fitMode) IsBoolFlag() bool { return true }
+
+// The size -fit falls back to when the size of the terminal is unknown.
+const fallbackCols, fallbackRows = 80, 24
+
+// fitSize returns the size of the field that r draws in a terminal of the given size, at the scale of r, between the
+// header line and the status bar if there are those and with a line to spare for the cursor, so that the frame
+// doesn't scroll the screen.
+// The field is at least one cell wide and high, even if the terminal is too small for that.
+func fitSize(r life.Renderer, cols, rows int, header, status bool, border string, rulers bool) (width, height uint) {
+	rows--
+	if header {
+		rows--
+	}
+	if status {
+		rows--
+	}
+	if border != "none" {
+		cols -= 2
+		rows -= 2
+	}
+	if rulers {
+		// The left ruler is as wide as the largest row index and the top one takes a line, or two with the
+		// labels of every tenth column.
+		cols -= len(strconv.Itoa(rows - 1))
+		rows--
+		if cols > 10 {
+			rows--
+		}
+	}
+	perCol, perRow := life.CellsPerCharacter(r)
+	charCols, charRows := life.CharactersPerCell(r)
+	width, height = 1, 1
+	if n := uint(cols) * perCol / charCols; cols > 0 && n > 0 {
+		width = n
+	}
+	if n := uint(rows) * perRow / charRows; rows > 0 && n > 0 {
+		height = n
+	}
+	return width, height
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestFitSize(t *testing.T) {
+	for _, tt := range []struct {
+		r             life.Renderer
+		cols, rows    int
+		header        bool
+		status        bool
+		border        string
+		rulers        bool
+		width, height uint
+	}{
+		{life.BlockRenderer{}, 80, 24, true, false, "none", false, 80, 22},
+		{life.BlockRenderer{}, 80, 24, false, false, "none", false, 80, 23},
+		{life.BlockRenderer{}, 80, 24, true, true, "none", false, 80, 21},
+		{life.HalfBlockRenderer{}, 80, 24, true, false, "none", false, 80, 44},
+		{life.BrailleRenderer{}, 80, 24, true, false, "none", false, 160, 88},
+		{life.FrameRenderer{Renderer: life.HalfBlockRenderer{}}, 80, 24, true, false, "unicode", false, 78, 40},
+		// Two lines of top ruler, and a left one two digits wide for rows 0 to 17.
+		{life.FrameRenderer{Rulers: true}, 80, 24, true, false, "unicode", true, 76, 18},
+		{life.BlockRenderer{}, 1, 1, true, false, "none", false, 1, 1},
+		{life.BlockRenderer{Scale: 2}, 80, 24, true, false, "none", false, 40, 22},
+		{life.FrameRenderer{Renderer: life.AgeRenderer{Scale: 3}}, 80, 24, true, false, "unicode", false, 26, 10},
+	} {
+		w, h := fitSize(tt.r, tt.cols, tt.rows, tt.header, tt.status, tt.border, tt.rulers)
+		if w != tt.width || h != tt.height {
+			t.Errorf("%T in %dx%d: got %dx%d, wanted %dx%d", tt.r, tt.cols, tt.rows, w, h, tt.width, tt.height)
+		}
+	}
+}
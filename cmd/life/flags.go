@@ -0,0 +1,226 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+var seed int64
+var nowrap bool
+var ticks uint
+var rleFile string
+var patternName string
+var lexiconFile string
+var width, height uint
+var renderer string
+var border string
+var rulers bool
+var scale uint
+var zoom string
+var sixel bool
+var cellSize uint
+var color string
+var redraw bool
+var header bool
+var showStatus bool
+var final bool
+var at genList
+var outDir string
+var engine string
+var noInteractive bool
+var editing bool
+var ruleString string
+var fps float64
+var outFile string
+var summary bool
+var snapshotDir string
+var untilStable bool
+var maxTicks uint
+var quiet bool
+var jsonSummary bool
+var grid string
+var places placements
+var noOverlap bool
+var noRun bool
+var record recorder
+var recordFormat string
+var density float64
+var noise float64
+var loop soupLimit
+var csvFile string
+var videoFile string
+var videoFormat string
+var fit fitMode
+var heatmap bool
+var heatmapOut string
+var replayFile string
+var viewportSpec string
+var follow bool
+var timeout time.Duration
+
+// parseFlags defines the flags of a run and parses them.
+func parseFlags() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage of %[1]s [options] width height\n       %[1]s bench [options]\n       %[1]s soup [options]\n       %[1]s tournament [options]\n       %[1]s info [options] file.rle\n       %[1]s convert [options] in out\n       %[1]s diff [options] a.rle [b.rle]\n       %[1]s patterns\n       %[1]s serve [options] [width height]\n       %[1]s serve-telnet [options] [width height]\noptions:\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Int64Var(&seed, "seed", time.Now().UnixMicro(), "seed for initial state")
+	flag.BoolVar(&nowrap, "nowrap", false, "don't wrap field toroidally")
+	flag.UintVar(&ticks, "ticks", 100, "amount of generations to run, 0 to run until interrupted")
+	flag.StringVar(&rleFile, "file", "", "load initial state from .rle file or .pbm image, also inside a zip archive as archive.zip!name, or RLE from standard input if - (mutually exclusive with width height arguments)")
+	flag.StringVar(&patternName, "pattern", "", "start with a built-in pattern in the middle of the field, e.g. gosper-gun (see "+os.Args[0]+" patterns for the list)")
+	flag.StringVar(&lexiconFile, "lexicon", "", "look up -pattern in a file of the Life Lexicon instead of the built-in patterns")
+	flag.StringVar(&renderer, "renderer", "block", "how cells are drawn: "+strings.Join(life.RendererNames(), ", "))
+	flag.StringVar(&border, "border", "none", "draw a border around the field: none, unicode, ascii")
+	flag.BoolVar(&rulers, "rulers", false, "draw coordinate rulers along the border (requires the block renderer)")
+	flag.UintVar(&scale, "scale", 1, "draw every cell as N characters side by side, on N/2 lines rounded up, e.g. 2 for square cells (requires the block or age renderer)")
+	flag.StringVar(&zoom, "zoom", "", "zoom out by drawing every NxN block of cells as one character that shows how many are alive, or with -zoom auto the smallest blocks that fit the field on the screen")
+	flag.BoolVar(&sixel, "sixel", false, "draw the cells as pixels with sixel graphics, for terminals that support them like xterm -ti vt340, mlterm and foot (falls back to text when stdout is not a terminal)")
+	flag.UintVar(&cellSize, "cell-size", life.DefaultSixelCellSize, "width and height of a cell in pixels with -sixel or -video")
+	flag.StringVar(&color, "color", "none", "colour cells by age: none, 256, 8 (disabled when stdout is not a terminal)")
+	flag.BoolVar(&redraw, "redraw", false, "redraw the whole screen every frame instead of only the changed cells")
+	flag.BoolVar(&header, "header", true, "show a status line with the generation, population and rule above the field")
+	flag.BoolVar(&showStatus, "status", true, "show a status bar with the births, deaths, frame rate and speed below the field (only when stdout is a terminal)")
+	flag.StringVar(&engine, "engine", "naive", "how generations are computed: "+strings.Join(life.EngineNames(), ", "))
+	flag.BoolVar(&noInteractive, "no-interactive", false, "don't handle keys during the run (space pauses, n steps, + and - change the speed, r resets, arrows move the -viewport, f follows the pattern again, q quits)")
+	flag.BoolVar(&editing, "edit", false, "draw the initial state in the terminal before the run starts")
+	flag.StringVar(&ruleString, "rule", "B3/S23", "rule in B/S notation, e.g. B36/S23")
+	flag.Float64Var(&fps, "fps", 30, "generations shown per second, below 1 for slow motion or 0 to run as fast as possible (unless given, as fast as possible when stdout is not a terminal)")
+	flag.Var(&at, "at", "only draw the listed generations, one after the other, e.g. 0,10,100 or 0-100:20 for every 20th up to 100")
+	flag.StringVar(&outDir, "out-dir", "", "write the generations of -at to files in this directory, in -record-format, instead of drawing them")
+	flag.BoolVar(&final, "final", false, "only draw the final generation, e.g. to write it to a file")
+	flag.StringVar(&outFile, "out", "", "write the final state to an .rle file or a .pbm image, or as RLE to standard output if - (the run is then drawn on standard error)")
+	flag.BoolVar(&summary, "summary", false, "print the number of generations, final population and speed when the run ends")
+	flag.StringVar(&snapshotDir, "snapshots", ".", "directory the current generation is written to as .rle on SIGUSR1")
+	flag.BoolVar(&untilStable, "until-stable", false, "run until the pattern dies out, stops changing or repeats, and report which (exits with 2 if it doesn't within -max generations)")
+	flag.UintVar(&maxTicks, "max", 100000, "the most generations to run with -until-stable, or each soup with -loop, 0 for no limit")
+	flag.BoolVar(&quiet, "quiet", false, "don't draw the run, compute it as fast as possible and print a summary of it")
+	flag.BoolVar(&jsonSummary, "json", false, "print the summary of -quiet as JSON")
+	flag.StringVar(&grid, "grid", "", "size of the field as WIDTHxHEIGHT, in place of the width height arguments, or of the field the pattern of -file is put on, in its middle or at X,Y with WIDTHxHEIGHT@X,Y")
+	flag.Var(&places, "place", "place the pattern of an .rle file onto an empty field, as file@x,y or file@x,y:transform with r90, r180, r270, fx or fy (can be repeated)")
+	flag.BoolVar(&noOverlap, "no-overlap", false, "make overlapping -place patterns an error instead of combining them")
+	flag.BoolVar(&noRun, "no-run", false, "only write the initial state to -out, without running")
+	flag.Var(&record, "record", "write every N generations and the last one to files in a directory, as every=N dir=DIR")
+	flag.StringVar(&recordFormat, "record-format", "rle", "format of the files written by -record: "+strings.Join(recordFormatNames(), ", "))
+	flag.Float64Var(&density, "density", life.DefaultDensity, "probability of a cell being alive in a random initial state")
+	flag.Float64Var(&noise, "noise", 0, "flip every cell with this probability after every generation, e.g. 0.0005, drawn from -seed")
+	flag.StringVar(&csvFile, "csv", "", "write the generation, population, births, deaths, density and bounding box area of every generation to a CSV file, or to standard output if - (the run is then not drawn)")
+	flag.StringVar(&videoFile, "video", "", "write every generation as a frame of an uncompressed video to a file, or to standard output if -, e.g. to pipe into ffmpeg -i - out.mp4 (the run is then not drawn)")
+	flag.StringVar(&videoFormat, "video-format", "y4m", "format of -video: y4m for a YUV4MPEG2 stream of -fps frames per second, ppm for a sequence of binary PPM images")
+	flag.Var(&fit, "fit", "size the field to fill the terminal, in place of the width and height arguments, and crop the view when the terminal is resized, or resize the field with -fit=resize")
+	flag.BoolVar(&heatmap, "heatmap", false, "show how many generations every cell was alive when the run ends, as a heat map")
+	flag.StringVar(&heatmapOut, "heatmap-out", "", "write the heat map to a .png file, with one pixel per cell")
+	flag.StringVar(&replayFile, "replay", "", "repeat the run described in a file, or if the file doesn't exist, describe this run in it so that it can be repeated")
+	flag.StringVar(&viewportSpec, "viewport", "", "draw only the part X,Y,WxH of the field, which the arrow keys move during the run")
+	flag.BoolVar(&follow, "follow", false, "move the -viewport, or a view the size of the terminal, along with the pattern as it wanders across the field (the arrow keys take over until f is pressed)")
+	flag.DurationVar(&timeout, "timeout", 0, "stop the run after this much time, e.g. 30s, or at -ticks or when settled with -until-stable if that comes first")
+	flag.Var(&loop, "loop", "start a new random soup with the next seed whenever one settles or reaches -max generations, optionally only N times, as -loop=N")
+	flag.Parse()
+	// flag only takes the value of -loop as -loop=N, and stops at -loop N, so the number is taken here and the flags
+	// after it are parsed again. A single number followed by another is the width and height, as in -loop 80 40.
+	if _, err := strconv.ParseUint(flag.Arg(0), 10, strconv.IntSize); loop.on && loop.n == 0 && err == nil && flag.NArg() != 2 {
+		if err := loop.Set(flag.Arg(0)); err != nil {
+			printUsageAndExit(fmt.Errorf("-loop: %w", err))
+		}
+		flag.CommandLine.Parse(flag.Args()[1:])
+	}
+	// The same goes for -fit resize.
+	if fit == fitCrop && (flag.Arg(0) == string(fitCrop) || flag.Arg(0) == string(fitResize)) {
+		fit.Set(flag.Arg(0))
+		flag.CommandLine.Parse(flag.Args()[1:])
+	}
+}
+
+// checkFlags checks that the flags of a run go together, and exits with the usage if they don't. It returns the rule
+// and the video format they give. Flags that write to standard output move the screen to standard error.
+func checkFlags() (life.Rule, life.VideoFormat) {
+	if outFile == "-" {
+		if csvFile == "-" {
+			printUsageAndExit(fmt.Errorf("-out and -csv can't both write to standard output"))
+		}
+		screen = os.Stderr
+	}
+	// The rows on standard output would be mixed up with the frames, so the run isn't drawn and its summary goes
+	// to standard error.
+	if csvFile == "-" {
+		quiet = true
+		screen = os.Stderr
+	}
+	// A video replaces the frames drawn on the screen.
+	if videoFile != "" {
+		if videoFile == "-" && (outFile == "-" || csvFile == "-") {
+			printUsageAndExit(fmt.Errorf("-video can't write to standard output along with -out or -csv"))
+		}
+		if fit != fitNone {
+			printUsageAndExit(fmt.Errorf("-video needs frames of the same size and can't be combined with -fit"))
+		}
+		if videoFile == "-" {
+			screen = os.Stderr
+		}
+		quiet = true
+	}
+	vformat, ok := videoFormats[videoFormat]
+	if !ok {
+		printUsageAndExit(fmt.Errorf("unknown video format %q (available: %s)", videoFormat, strings.Join(videoFormatNames(), ", ")))
+	}
+	rule, err := life.ParseRule(ruleString)
+	if err != nil {
+		printUsageAndExit(err)
+	}
+	if fps < 0 || math.IsNaN(fps) {
+		printUsageAndExit(fmt.Errorf("-fps must not be negative, use 0 to run as fast as possible"))
+	}
+	if noRun && outFile == "" {
+		printUsageAndExit(fmt.Errorf("-no-run requires -out"))
+	}
+	if jsonSummary && !quiet {
+		printUsageAndExit(fmt.Errorf("-json requires -quiet"))
+	}
+	if density < 0 || density > 1 {
+		printUsageAndExit(fmt.Errorf("-density must be between 0 and 1"))
+	}
+	if !(noise >= 0 && noise <= 1) {
+		printUsageAndExit(fmt.Errorf("-noise must be between 0 and 1"))
+	}
+	// A noisy run never really settles, even if a generation happens to repeat.
+	if noise > 0 && (untilStable || loop.on) {
+		printUsageAndExit(fmt.Errorf("-noise can't be combined with -until-stable or -loop"))
+	}
+	if untilStable && loop.on {
+		printUsageAndExit(fmt.Errorf("-until-stable and -loop can't be combined"))
+	}
+	if untilStable || loop.on {
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "ticks" {
+				printUsageAndExit(fmt.Errorf("-until-stable and -loop run until the pattern settles, use -max to limit it instead of -ticks"))
+			}
+		})
+		ticks = maxTicks
+	}
+	if outDir != "" && len(at) == 0 {
+		printUsageAndExit(fmt.Errorf("-out-dir requires -at"))
+	}
+	if len(at) != 0 {
+		if _, ok := recordFormats[recordFormat]; !ok {
+			printUsageAndExit(fmt.Errorf("unknown record format %q (available: %s)", recordFormat, strings.Join(recordFormatNames(), ", ")))
+		}
+		if beyond := at.beyond(ticks); ticks != 0 && len(beyond) != 0 {
+			plural := ""
+			if len(beyond) > 1 {
+				plural = "s"
+			}
+			fmt.Fprintf(os.Stderr, "-at: the run ends at generation %d, before generation%s %v\n", ticks, plural, &beyond)
+		}
+	}
+	if _, err := life.NewEngine(engine); err != nil {
+		printUsageAndExit(err)
+	}
+	return rule, vformat
+}
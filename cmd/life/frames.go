@@ -0,0 +1,112 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// termFrame is a generation of a run as it is to be drawn, taken from the game so that the run can go on meanwhile.
+type termFrame struct {
+	// f holds the cells to draw, a copy of the field or the part of it that is on screen.
+	f *life.Field
+	// gen is the generation of the cells.
+	gen uint
+	// status is the header line.
+	status string
+	// stats, rule, delay and paused are shown in the status bar.
+	stats  life.Stats
+	rule   life.Rule
+	delay  time.Duration
+	paused bool
+	// cols and rows are the size of the terminal.
+	cols, rows int
+	// resized is set if the terminal was resized since the frame before.
+	resized bool
+	// repaint is set if the whole screen has to be drawn again, e.g. for a new soup.
+	repaint bool
+}
+
+// frameLoop draws frames in a goroutine of its own, so that a slow terminal doesn't hold back the run and drawing
+// doesn't take time from computing generations. It draws one frame at a time, and if dropping is enabled, a frame
+// that is posted while the one before still waits to be drawn replaces it.
+type frameLoop struct {
+	frames chan termFrame
+	// errs holds the first error of drawing, after which frames are taken but not drawn anymore.
+	errs chan error
+	// pending counts the frames that were posted but not drawn yet.
+	pending sync.WaitGroup
+	done    chan struct{}
+	drop    bool
+	// dropped counts the frames that were replaced before they were drawn.
+	dropped uint
+	stopped bool
+}
+
+// startFrameLoop starts drawing the frames posted to the returned loop with draw. Frames are only dropped if drop is
+// set, otherwise posting waits for the frame before to be drawn.
+func startFrameLoop(draw func(termFrame) error, drop bool) *frameLoop {
+	fl := &frameLoop{frames: make(chan termFrame, 1), errs: make(chan error, 1), done: make(chan struct{}), drop: drop}
+	go func() {
+		defer close(fl.done)
+		failed := false
+		for fr := range fl.frames {
+			if !failed {
+				if err := draw(fr); err != nil {
+					fl.errs <- err
+					failed = true
+				}
+			}
+			fl.pending.Done()
+		}
+	}()
+	return fl
+}
+
+// post hands fr over to be drawn. It returns the error of drawing a frame before, if there was one.
+func (fl *frameLoop) post(fr termFrame) error {
+	select {
+	case err := <-fl.errs:
+		return err
+	default:
+	}
+	fl.pending.Add(1)
+	if !fl.drop {
+		fl.frames <- fr
+		return nil
+	}
+	// Only one frame fits in the channel, so either there is room for fr or a frame waits that fr replaces.
+	select {
+	case fl.frames <- fr:
+	case old := <-fl.frames:
+		fl.pending.Done()
+		fl.dropped++
+		fr.resized = fr.resized || old.resized
+		fr.repaint = fr.repaint || old.repaint
+		fl.frames <- fr
+	}
+	return nil
+}
+
+// wait waits until every frame posted is drawn, so that something else can be written to the screen, and returns
+// the error of drawing one, if there was one.
+func (fl *frameLoop) wait() error {
+	fl.pending.Wait()
+	select {
+	case err := <-fl.errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+// stop draws the frames that were posted and ends the loop. It can be called more than once.
+func (fl *frameLoop) stop() error {
+	if !fl.stopped {
+		fl.stopped = true
+		close(fl.frames)
+		<-fl.done
+	}
+	return fl.wait()
+}
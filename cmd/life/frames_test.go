@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFrameLoop(t *testing.T) {
+	for _, drop := range []bool{false, true} {
+		// The first frame holds up drawing until all frames are posted.
+		var drawn []uint
+		started, release := make(chan struct{}), make(chan struct{})
+		fl := startFrameLoop(func(fr termFrame) error {
+			if fr.gen == 1 {
+				close(started)
+				<-release
+			}
+			if fr.gen == 4 && !fr.repaint {
+				t.Errorf("drop %t: the repaint of a dropped frame got lost", drop)
+			}
+			drawn = append(drawn, fr.gen)
+			return nil
+		}, drop)
+		fl.post(termFrame{gen: 1})
+		<-started
+		if drop {
+			for gen := uint(2); gen <= 4; gen++ {
+				fl.post(termFrame{gen: gen, repaint: gen == 3})
+			}
+			close(release)
+		} else {
+			close(release)
+			for gen := uint(2); gen <= 4; gen++ {
+				fl.post(termFrame{gen: gen, repaint: gen == 4})
+			}
+		}
+		if err := fl.stop(); err != nil {
+			t.Fatal(err)
+		}
+		want := []uint{1, 2, 3, 4}
+		if drop {
+			// The frame being drawn can't be dropped, the others are replaced by the last one.
+			want = []uint{1, 4}
+		}
+		if len(drawn) != len(want) || drawn[len(drawn)-1] != 4 || uint(len(want))+fl.dropped != 4 {
+			t.Errorf("drop %t: drew %v and dropped %d, wanted %v", drop, drawn, fl.dropped, want)
+		}
+	}
+
+	failed := errors.New("broken pipe")
+	fl := startFrameLoop(func(fr termFrame) error { return failed }, true)
+	fl.post(termFrame{gen: 1})
+	if err := fl.wait(); err != failed {
+		t.Errorf("got the error %v, wanted %v", err, failed)
+	}
+	fl.post(termFrame{gen: 2})
+	if err := fl.stop(); err != nil {
+		t.Errorf("got the error %v again", err)
+	}
+}
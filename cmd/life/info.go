@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/418Coffee/life"
+)
+
+// Thumbnails larger than this many characters are downsampled.
+const thumbnailCols, thumbnailRows = 64, 32
+
+// patternInfo is what the info subcommand tells about a pattern file.
+type patternInfo struct {
+	File     string   `json:"file"`
+	Name     string   `json:"name,omitempty"`
+	Author   string   `json:"author,omitempty"`
+	Comments []string `json:"comments,omitempty"`
+	// Width and Height are the size declared by the header line.
+	Width  uint   `json:"width"`
+	Height uint   `json:"height"`
+	Rule   string `json:"rule"`
+	// Grid is the bounded grid of Golly at the end of the rule, e.g. "T50,20", empty if there is none.
+	Grid       string `json:"grid,omitempty"`
+	Population uint   `json:"population"`
+	// BoundingBox holds the live cells, nil if there are none.
+	BoundingBox *infoBox      `json:"bounding_box,omitempty"`
+	Warnings    []infoWarning `json:"warnings,omitempty"`
+
+	field *life.Field
+}
+
+// infoBox is a rectangle with its top-left corner at X,Y.
+type infoBox struct {
+	X      uint `json:"x"`
+	Y      uint `json:"y"`
+	Width  uint `json:"width"`
+	Height uint `json:"height"`
+}
+
+// infoWarning is a problem with a pattern file that doesn't keep it from being read.
+type infoWarning struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (w infoWarning) String() string {
+	if w.Line == 0 {
+		return w.Message
+	}
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// info runs the info subcommand with the given arguments. It describes a pattern file without running it.
+func info(args []string) error {
+	fs := flag.NewFlagSet("info", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s info [options] file.rle\n"+
+			"Describes a pattern file, or RLE from standard input if the file is -.\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	asJSON := fs.Bool("json", false, "print the description as JSON")
+	noThumbnail := fs.Bool("no-thumbnail", false, "don't draw the pattern")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	name := fs.Arg(0)
+	var b []byte
+	var err error
+	if name == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return err
+	}
+	pi, err := describePattern(name, b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if *asJSON {
+		return json.NewEncoder(os.Stdout).Encode(pi)
+	}
+	return pi.print(!*noThumbnail)
+}
+
+// describePattern reads the RLE pattern in b. It reads more than life.ReadGame accepts, so that problems can be
+// listed as warnings instead: lines of more than 70 characters, a missing '!' at the end of the pattern and rules
+// that can't be parsed.
+func describePattern(name string, b []byte) (patternInfo, error) {
+	pi := patternInfo{File: name, Rule: life.Conway.String()}
+	warn := func(line int, format string, a ...interface{}) {
+		pi.Warnings = append(pi.Warnings, infoWarning{line, fmt.Sprintf(format, a...)})
+	}
+	// The pattern is passed on with a header of only its size and lines short enough for life.ReadGame.
+	var pattern bytes.Buffer
+	header, terminated := false, false
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimRight(s.Text(), "\r")
+		if len(line) > 70 {
+			warn(n, "longer than 70 characters (%d)", len(line))
+		}
+		switch {
+		case line == "" || terminated:
+		case line[0] == '#':
+			tag, text := line, ""
+			if len(line) > 2 {
+				tag, text = line[:2], strings.TrimSpace(line[2:])
+			}
+			switch tag {
+			case "#N":
+				pi.Name = text
+			case "#O":
+				pi.Author = text
+			case "#C", "#c":
+				pi.Comments = append(pi.Comments, text)
+			}
+		case !header:
+			header = true
+			if err := pi.parseHeader(line); err != nil {
+				return pi, fmt.Errorf("line %d: %w", n, err)
+			}
+			fmt.Fprintf(&pattern, "x = %d, y = %d", pi.Width, pi.Height)
+			if pi.Grid != "" {
+				fmt.Fprintf(&pattern, ", rule = %s:%s", life.Conway, pi.Grid)
+			}
+			pattern.WriteByte('\n')
+		default:
+			if i := strings.IndexByte(line, '!'); i >= 0 {
+				line, terminated = line[:i+1], true
+			}
+			// Pattern lines are joined anyway, so they can be split anywhere.
+			for len(line) > 70 {
+				pattern.WriteString(line[:70])
+				pattern.WriteByte('\n')
+				line = line[70:]
+			}
+			pattern.WriteString(line)
+			pattern.WriteByte('\n')
+		}
+	}
+	if err := s.Err(); err != nil {
+		return pi, err
+	}
+	if !header {
+		return pi, errors.New("missing the header line")
+	}
+	if !terminated {
+		warn(0, "the pattern doesn't end with '!'")
+		pattern.WriteString("!\n")
+	}
+	if rule, err := life.ParseRule(pi.Rule); err != nil {
+		warn(0, "%v", err)
+	} else {
+		pi.Rule = rule.String()
+	}
+	l, err := life.ReadGame(&pattern, false)
+	if err != nil {
+		return pi, err
+	}
+	pi.field = l.Field()
+	pi.Population = pi.field.Population()
+	if min, max, ok := pi.field.BoundingBox(); ok {
+		pi.BoundingBox = &infoBox{min.X, min.Y, max.X - min.X + 1, max.Y - min.Y + 1}
+	}
+	return pi, nil
+}
+
+// parseHeader takes the size and rule of the pattern from its header line, e.g. "x = 3, y = 3, rule = B3/S23".
+func (pi *patternInfo) parseHeader(line string) error {
+	// The rule comes last, and holds a comma if it ends in a bounded grid.
+	if i := strings.Index(line, "rule"); i >= 0 {
+		if eq := strings.IndexByte(line[i:], '='); eq >= 0 {
+			pi.Rule = strings.TrimSpace(line[i+eq+1:])
+			if colon := strings.IndexByte(pi.Rule, ':'); colon >= 0 {
+				pi.Rule, pi.Grid = pi.Rule[:colon], pi.Rule[colon+1:]
+			}
+		}
+		line = line[:i]
+	}
+	var x, y bool
+	for _, field := range strings.Split(line, ",") {
+		eq := strings.IndexByte(field, '=')
+		if eq < 0 {
+			continue
+		}
+		key, value := strings.TrimSpace(field[:eq]), strings.TrimSpace(field[eq+1:])
+		switch key {
+		case "x", "y":
+			n, err := strconv.ParseUint(value, 10, strconv.IntSize)
+			if err != nil || n == 0 {
+				return fmt.Errorf("invalid %s = %s in the header line", key, value)
+			}
+			if key == "x" {
+				pi.Width, x = uint(n), true
+			} else {
+				pi.Height, y = uint(n), true
+			}
+		}
+	}
+	if !x || !y {
+		return fmt.Errorf("the header line %q doesn't declare x and y", line)
+	}
+	return nil
+}
+
+// print writes pi as a table, followed by a thumbnail of the pattern if thumbnail is set.
+func (pi patternInfo) print(thumbnail bool) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	if pi.Name != "" {
+		fmt.Fprintf(w, "name\t%s\n", pi.Name)
+	}
+	if pi.Author != "" {
+		fmt.Fprintf(w, "author\t%s\n", pi.Author)
+	}
+	for i, c := range pi.Comments {
+		label := ""
+		if i == 0 {
+			label = "comments"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", label, c)
+	}
+	fmt.Fprintf(w, "size\t%dx%d\n", pi.Width, pi.Height)
+	if b := pi.BoundingBox; b != nil {
+		fmt.Fprintf(w, "bounding box\t%dx%d at %d,%d\n", b.Width, b.Height, b.X, b.Y)
+	} else {
+		fmt.Fprintf(w, "bounding box\tnone\n")
+	}
+	fmt.Fprintf(w, "population\t%d\n", pi.Population)
+	fmt.Fprintf(w, "rule\t%s\n", pi.Rule)
+	if pi.Grid != "" {
+		fmt.Fprintf(w, "grid\t%s\n", pi.Grid)
+	}
+	for i, warning := range pi.Warnings {
+		label := ""
+		if i == 0 {
+			label = "warnings"
+		}
+		fmt.Fprintf(w, "%s\t%v\n", label, warning)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !thumbnail || pi.BoundingBox == nil {
+		return nil
+	}
+	fmt.Println()
+	b := pi.BoundingBox
+	return drawThumbnail(os.Stdout, pi.field.View(life.Viewport{X: b.X, Y: b.Y, Width: b.Width, Height: b.Height}))
+}
+
+// drawThumbnail draws f with a character per cell if it fits in thumbnailCols by thumbnailRows characters, and
+// otherwise with braille characters, downsampled until it fits. A cell of the thumbnail is then alive if any cell
+// of the block of f it stands for is.
+func drawThumbnail(w io.Writer, f *life.Field) error {
+	if f.Width() <= thumbnailCols && f.Height() <= thumbnailRows {
+		return life.BlockRenderer{}.Render(w, f)
+	}
+	// Braille characters hold 2x4 cells each.
+	k := (f.Width() + 2*thumbnailCols - 1) / (2 * thumbnailCols)
+	if kh := (f.Height() + 4*thumbnailRows - 1) / (4 * thumbnailRows); kh > k {
+		k = kh
+	}
+	small := life.NewField((f.Width()+k-1)/k, (f.Height()+k-1)/k, false)
+	f.EachLive(func(x, y uint) {
+		small.Set(x/k, y/k, true)
+	})
+	if k > 1 {
+		fmt.Fprintf(w, "(downsampled %d:1)\n", k)
+	}
+	return life.BrailleRenderer{}.Render(w, small)
+}
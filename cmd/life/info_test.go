@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestDescribePattern(t *testing.T) {
+	rle := "#N Glider\n#O Richard K. Guy\n#C The smallest spaceship.\nx = 5, y = 4, rule = B3/S23\n" +
+		"$bo$2bo$3o" + strings.Repeat("$", 70) + "\n"
+	pi, err := describePattern("glider.rle", []byte(rle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := patternInfo{
+		File: "glider.rle", Name: "Glider", Author: "Richard K. Guy", Comments: []string{"The smallest spaceship."},
+		Width: 5, Height: 4, Rule: "B3/S23", Population: 5, BoundingBox: &infoBox{0, 1, 3, 3},
+		Warnings: []infoWarning{
+			{5, "longer than 70 characters (80)"},
+			{0, "the pattern doesn't end with '!'"},
+		},
+	}
+	pi.field = nil
+	if !reflect.DeepEqual(pi, want) {
+		t.Errorf("got %+v, wanted %+v", pi, want)
+	}
+
+	pi, err = describePattern("blinker.rle", []byte("x = 3, y = 1, rule = B3/S23:T6,5\n3o!\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pi.Rule != "B3/S23" || pi.Grid != "T6,5" || *pi.BoundingBox != (infoBox{2, 2, 3, 1}) {
+		t.Errorf("got the rule %s, grid %s and bounding box %+v, wanted the blinker in the middle of a 6x5 torus",
+			pi.Rule, pi.Grid, *pi.BoundingBox)
+	}
+
+	for _, rle := range []string{"#C only a comment\n", "bo$2bo$3o!\n", "x = 0, y = 3\n!\n"} {
+		if _, err := describePattern("bad.rle", []byte(rle)); err == nil {
+			t.Errorf("%q: expected an error", rle)
+		}
+	}
+}
+
+func TestDrawThumbnail(t *testing.T) {
+	// A diagonal line too long for a thumbnail is downsampled 2:1 into braille.
+	f := life.NewField(256, 8, false)
+	for x := uint(0); x < 8; x++ {
+		f.Set(x, x, true)
+	}
+	b := new(strings.Builder)
+	if err := drawThumbnail(b, f); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if lines[0] != "(downsampled 2:1)" || len(lines) != 2 || !strings.HasPrefix(lines[1], "⠑⢄") {
+		t.Errorf("got %q", b.String())
+	}
+}
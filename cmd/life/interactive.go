@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"io"
+)
+
+// key is a key pressed on the terminal: either the byte it sends or one of the constants below.
+type key rune
+
+const (
+	keyUp key = -1 - iota
+	keyDown
+	keyRight
+	keyLeft
+)
+
+// readKeys sends every key read from r to keys until reading fails.
+// The escape sequences of the arrow keys are translated into keyUp, keyDown, keyRight and keyLeft.
+func readKeys(r io.Reader, keys chan<- key) {
+	buf := make([]byte, 64)
+	for {
+		n, err := r.Read(buf)
+		for i := 0; i < n; i++ {
+			// Arrow keys send ESC [ A through ESC [ D, which arrive in a single read.
+			if buf[i] == 0x1b && i+2 < n && buf[i+1] == '[' && buf[i+2] >= 'A' && buf[i+2] <= 'D' {
+				keys <- keyUp - key(buf[i+2]-'A')
+				i += 2
+				continue
+			}
+			keys <- key(buf[i])
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// crlfWriter turns every "\n" into "\r\n", because a terminal in raw mode only moves the cursor down on "\n".
+type crlfWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	c.buf = c.buf[:0]
+	for {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			break
+		}
+		c.buf = append(c.buf, p[:i]...)
+		c.buf = append(c.buf, '\r', '\n')
+		p = p[i+1:]
+	}
+	c.buf = append(c.buf, p...)
+	if _, err := c.w.Write(c.buf); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
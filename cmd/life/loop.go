@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// soupLimit is the value of the -loop flag: whether to keep starting new soups, and after how many to stop, if
+// ever. Given without a value, as -loop, it sets no limit.
+type soupLimit struct {
+	on bool
+	n  uint
+}
+
+func (s *soupLimit) String() string {
+	switch {
+	case s == nil || !s.on:
+		return "false"
+	case s.n == 0:
+		return "true"
+	}
+	return strconv.FormatUint(uint64(s.n), 10)
+}
+
+func (s *soupLimit) Set(v string) error {
+	switch v {
+	case "true":
+		s.on, s.n = true, 0
+		return nil
+	case "false":
+		s.on, s.n = false, 0
+		return nil
+	}
+	n, err := strconv.ParseUint(v, 10, strconv.IntSize)
+	if err != nil || n == 0 {
+		return fmt.Errorf("the number of soups must be positive, not %q", v)
+	}
+	s.on, s.n = true, uint(n)
+	return nil
+}
+
+// IsBoolFlag lets -loop be given without a value.
+func (s *soupLimit) IsBoolFlag() bool { return true }
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestSoupLimitSet(t *testing.T) {
+	for _, tt := range []struct {
+		v  string
+		on bool
+		n  uint
+	}{
+		{"true", true, 0},
+		{"10", true, 10},
+		{"false", false, 0},
+	} {
+		var s soupLimit
+		if err := s.Set(tt.v); err != nil || s.on != tt.on || s.n != tt.n {
+			t.Errorf("%q: got %+v and error %v", tt.v, s, err)
+		}
+	}
+	for _, v := range []string{"0", "-1", "x"} {
+		if err := new(soupLimit).Set(v); err == nil {
+			t.Errorf("%q: got no error", v)
+		}
+	}
+}
@@ -0,0 +1,756 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/418Coffee/life"
+	"golang.org/x/term"
+)
+
+// screen is where the run is drawn.
+var screen = os.Stdout
+
+func printUsageAndExit(err error) {
+	if err != nil {
+		fmt.Fprint(flag.CommandLine.Output(), err, "\n")
+	}
+	flag.Usage()
+	os.Exit(1)
+}
+
+// errNotStable is returned by run when -until-stable gives up, so that scripts can tell it apart from failures.
+var errNotStable = errors.New("did not stabilize")
+
+func main() {
+	var err error
+	// Failures exit with 1, except for diff, which like diff(1) exits with 1 for differences and 2 for failures.
+	failed := 1
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "bench":
+		err = bench(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "soup":
+		err = soup(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "info":
+		err = info(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "convert":
+		err = convert(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "tournament":
+		err = tournament(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "serve":
+		err = serve(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "serve-telnet":
+		err = serveTelnet(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "patterns":
+		err = listPatterns(os.Args[2:])
+	case len(os.Args) > 1 && os.Args[1] == "diff":
+		err, failed = diffPatterns(os.Args[2:]), 2
+	default:
+		err = run()
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, errDifferent):
+		os.Exit(1)
+	case errors.Is(err, errNotStable):
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(failed)
+	}
+}
+
+func run() (err error) {
+	parseFlags()
+	// replaying is set if the run is a repeat of the one in replayFile, rather than to be described in it.
+	replaying := false
+	if replayFile != "" {
+		m, err := readManifest(replayFile)
+		switch {
+		case err == nil:
+			if err := m.apply(); err != nil {
+				printUsageAndExit(fmt.Errorf("-replay: %w", err))
+			}
+			replaying = true
+		case !errors.Is(err, os.ErrNotExist):
+			printUsageAndExit(fmt.Errorf("-replay: %w", err))
+		}
+	}
+	rule, vformat := checkFlags()
+	tty := term.IsTerminal(int(screen.Fd()))
+	// Colours, moving the cursor and clearing the screen with escape sequences need a terminal that understands them.
+	ansi := enableANSI(screen)
+	// bar is the status bar below the field, which is only drawn over itself on a terminal.
+	var bar *statusBar
+	if showStatus && tty && ansi && !quiet && len(at) == 0 {
+		bar = &statusBar{}
+	}
+	r, zoomer, ages := newRenderer(tty, ansi, bar != nil)
+	// fitWidth and fitHeight are the size of the field that fills the terminal, with -fit.
+	var fitWidth, fitHeight uint
+	if fit != fitNone {
+		if len(flag.Args()) != 0 || grid != "" {
+			printUsageAndExit(fmt.Errorf("-fit takes the size of the field from the terminal and can't be combined with width and height arguments or -grid"))
+		}
+		cols, rows := fallbackCols, fallbackRows
+		if c, rw, err := term.GetSize(int(screen.Fd())); tty && err == nil {
+			cols, rows = c, rw
+		} else {
+			fmt.Fprintf(os.Stderr, "-fit: the screen isn't a terminal, assuming %dx%d characters\n", cols, rows)
+		}
+		fitWidth, fitHeight = fitSize(r, cols, rows, header, bar != nil, border, rulers)
+	}
+	// view is the part of the field that is drawn, with -viewport.
+	var view *life.Viewport
+	if viewportSpec != "" {
+		if fit != fitNone {
+			printUsageAndExit(fmt.Errorf("-viewport can't be combined with -fit"))
+		}
+		v, err := life.ParseViewport(viewportSpec)
+		if err != nil {
+			printUsageAndExit(err)
+		}
+		view = &v
+	}
+	if follow && view == nil {
+		if fit != fitNone {
+			printUsageAndExit(fmt.Errorf("-follow can't be combined with -fit"))
+		}
+		// Without -viewport, the view is as large as the terminal, like the field with -fit.
+		cols, rows := fallbackCols, fallbackRows
+		if c, rw, err := term.GetSize(int(screen.Fd())); tty && err == nil {
+			cols, rows = c, rw
+		}
+		w, h := fitSize(r, cols, rows, header, bar != nil, border, rulers)
+		view = &life.Viewport{Width: w, Height: h}
+	}
+	s, err := newGameSetup(rule, ages, fitWidth, fitHeight)
+	if err != nil {
+		return err
+	}
+	if loop.on && (rleFile != "" || s.composed != nil || editing) {
+		printUsageAndExit(fmt.Errorf("-loop requires a random initial state and can't be combined with -file, -place, -pattern or -edit"))
+	}
+	if replayFile != "" && !replaying {
+		if editing || rleFile == "-" {
+			printUsageAndExit(fmt.Errorf("-replay can't describe runs of patterns drawn with -edit or read from standard input"))
+		}
+		if err := newManifest().write(replayFile); err != nil {
+			return fmt.Errorf("-replay: %w", err)
+		}
+	}
+	// random is set if the initial state or the noise is drawn from the seed, which is then shown, so that the run
+	// can be repeated with -seed.
+	random := rleFile == "" && s.composed == nil && !editing || noise > 0
+	if random && !quiet {
+		fmt.Fprintf(os.Stderr, "seed %d\n", seed)
+	}
+	interactive := !noInteractive && !quiet && len(at) == 0 && tty && term.IsTerminal(int(os.Stdin.Fd()))
+	if editing && (!interactive || !ansi) {
+		printUsageAndExit(fmt.Errorf("-edit requires stdin and stdout to be terminals that understand ANSI escape sequences and can't be combined with -no-interactive or -quiet"))
+	}
+	l, err := s.newGame()
+	if err != nil {
+		printUsageAndExit(err)
+	}
+	// Resets read the pattern again, but its warnings are only printed once, before the run is drawn.
+	switch rleFile {
+	case "":
+	case "-":
+		printWarnings("standard input", l)
+	default:
+		printWarnings(rleFile, l)
+	}
+
+	diff := newDiffRenderer(ansi, ages)
+	if diff != nil {
+		r = diff
+	}
+
+	// The summary is deferred first so that it is printed last, once the terminal is restored.
+	var simulated uint
+	// dropped counts the frames that were skipped because the terminal didn't keep up with the run.
+	var dropped uint
+	var started time.Time
+	var settled settling
+	interrupted := false
+	// stopped is why the run ended, one of the stopped constants, if it ended by itself or was interrupted.
+	var stopped string
+	defer func() {
+		if started.IsZero() || (err != nil && !errors.Is(err, errNotStable)) {
+			return
+		}
+		if heatmap {
+			// Without ANSI escape sequences there are no colours, so the counts are told apart by shades.
+			if err := (life.HeatRenderer{Basic: color == "8", Plain: !ansi}).Render(screen, l.HeatMap()); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+		// With -loop, every soup was reported when it ended.
+		if quiet && !loop.on {
+			r := newReport(l, settled)
+			r.StoppedBy = stopped
+			if err := printReport(r); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return
+		}
+		if settled.s != life.Unsettled && !loop.on {
+			fmt.Fprintln(screen, settled)
+		}
+		if (err == nil || errors.Is(err, errNotStable)) && (interrupted || summary || stopped == stoppedTimeout) {
+			elapsed := time.Since(started)
+			fmt.Fprintf(screen, "%d generations in %v (%.1f generations/s), final population %d",
+				simulated, elapsed.Round(time.Millisecond), float64(simulated)/elapsed.Seconds(), l.Population())
+			if dropped > 0 {
+				fmt.Fprintf(screen, ", %d frames dropped", dropped)
+			}
+			if random {
+				fmt.Fprintf(screen, ", seed %d", seed)
+			}
+			if stopped != "" {
+				fmt.Fprintf(screen, ", %s", describeStop(stopped))
+			}
+			fmt.Fprintln(screen)
+		}
+	}()
+
+	// Buffer whole frames so they reach the terminal in as few writes as possible.
+	var stdout io.Writer = screen
+	var keys chan key
+	if interactive {
+		state, err := term.MakeRaw(int(os.Stdin.Fd()))
+		if err != nil {
+			return err
+		}
+		// Deferred calls also run when panicking, so the terminal is restored whenever run returns.
+		defer term.Restore(int(os.Stdin.Fd()), state)
+		stdout = &crlfWriter{w: screen}
+		keys = make(chan key)
+		go readKeys(os.Stdin, keys)
+	}
+	out := bufio.NewWriterSize(stdout, 1<<16)
+
+	if editing {
+		// Edit the loaded or placed patterns, or an empty field of the given size, with the rule of the run.
+		f := l.Field()
+		if rleFile == "" && s.composed == nil {
+			f = life.NewField(width, height, !nowrap)
+			life.NewGameFromField(f).SetRule(rule)
+		}
+		start, err := edit(f, !nowrap, keys, out)
+		if err != nil || !start {
+			return err
+		}
+		s.edited = f
+		if l, err = s.newGame(); err != nil {
+			return err
+		}
+	}
+	if noRun {
+		if err := writeResult(l); err != nil {
+			return fmt.Errorf("writing the initial state to %s: %w", outFile, err)
+		}
+		return nil
+	}
+	if diff != nil {
+		defer func() {
+			diff.Close(out)
+			out.Flush()
+		}()
+	}
+	// In raw mode Ctrl-C arrives as a key, otherwise as a signal.
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	snapshot := snapshotSignals()
+	resized := resizeSignals()
+	var writing sync.WaitGroup
+	defer writing.Wait()
+	// Lines logged while the terminal is in raw mode need a carriage return.
+	eol := "\n"
+	if interactive {
+		eol = "\r\n"
+	}
+
+	// The delay is the time between the starts of two frames, so the time spent computing and drawing a frame
+	// is part of it.
+	var delay time.Duration
+	if fps > 0 && !quiet && !final && len(at) == 0 {
+		delay = time.Duration(float64(time.Second) / fps)
+	}
+	// Nobody watches frames written to a file or pipe, so they aren't held back unless -fps asks for it.
+	if !tty {
+		fpsGiven := false
+		flag.Visit(func(f *flag.Flag) {
+			fpsGiven = fpsGiven || f.Name == "fps"
+		})
+		if !fpsGiven {
+			delay = 0
+		}
+	}
+	paused := false
+	var cols, rows int
+	// repaint is set when the whole screen has to be drawn again with the next frame.
+	repaint := false
+	// stability is declared here already, because resizing the field with -fit=resize starts its history over.
+	var stability *life.StabilityDetector
+	// following is set while the view follows the pattern. Moving the view by hand stops it.
+	following := follow
+	// finished is set once the run is over, when the final generation is drawn with -final.
+	finished := false
+	// frames draws the frames that draw takes from the run, once the run starts.
+	var frames *frameLoop
+	draw := func() error {
+		if quiet || (final && !finished) || len(at) != 0 {
+			return nil
+		}
+		fr := termFrame{repaint: repaint}
+		repaint = false
+		if tty {
+			if c, rw, err := term.GetSize(int(screen.Fd())); err == nil && (c != cols || rw != rows) {
+				cols, rows = c, rw
+				fr.resized = true
+				if fit != fitNone {
+					s.fitWidth, s.fitHeight = fitSize(r, cols, rows, header, bar != nil, border, rulers)
+				}
+				if fit == fitResize && (l.Field().Width() != s.fitWidth || l.Field().Height() != s.fitHeight) {
+					l.Resize(s.fitWidth, s.fitHeight)
+					width, height = s.fitWidth, s.fitHeight
+					if stability != nil {
+						stability = life.NewStabilityDetector()
+						stability.Observe(l)
+					}
+				}
+			}
+		}
+		f := l.Field()
+		// With -fit, only the part of the field that fits in the terminal is drawn.
+		if fit == fitCrop && tty && (f.Width() > s.fitWidth || f.Height() > s.fitHeight) {
+			w, h := f.Width(), f.Height()
+			if w > s.fitWidth {
+				w = s.fitWidth
+			}
+			if h > s.fitHeight {
+				h = s.fitHeight
+			}
+			f = f.Resized(w, h)
+		}
+		// The viewport is fitted to the field every frame, because a reset may bring a field of another size.
+		if view != nil {
+			if following {
+				*view = view.Follow(f)
+			}
+			*view = view.Within(f)
+			f = f.View(*view)
+		}
+		// The frame is drawn while the game goes on, so it needs cells of its own.
+		if f == l.Field() {
+			f = f.Clone()
+		}
+		fr.f, fr.gen, fr.cols, fr.rows = f, l.Generation(), cols, rows
+		fr.status = l.Header(cols)
+		if view != nil {
+			fr.status = appendViewport(fr.status, *view, following, cols)
+		}
+		if bar != nil {
+			fr.stats, fr.rule, fr.delay, fr.paused = l.Stats(), l.Rule(), delay, paused
+		}
+		return frames.post(fr)
+	}
+	// cleared is set once the screen was cleared, after which frames of the same size are drawn over each other.
+	cleared := false
+	// drawn is the generation of the last frame drawn, and barPaused whether the status bar showed the run as paused.
+	var drawn uint
+	barPaused := false
+	// drawFrame draws a frame in the goroutine of frames, which owns the renderers, the status bar and out meanwhile.
+	drawFrame := func(fr termFrame) error {
+		if fr.resized || fr.repaint {
+			cleared = false
+			if diff != nil {
+				diff.Invalidate()
+			}
+		}
+		if fr.resized && zoomer != nil {
+			zoomer.Columns, zoomer.Rows = fitSize(life.BlockRenderer{}, fr.cols, fr.rows, header, bar != nil, border, false)
+		}
+		if bar != nil {
+			// A pause doesn't drag down the frame rate after it, and only frames of new generations count.
+			if fr.paused != barPaused {
+				bar.reset()
+				barPaused = fr.paused
+			}
+			if fr.gen != drawn {
+				bar.tick(time.Now())
+			}
+		}
+		drawn = fr.gen
+		f := fr.f
+		if diff != nil {
+			if err := r.Render(out, f); err != nil {
+				return err
+			}
+			if header {
+				_, scaleRows := life.CharactersPerCell(diff)
+				// Overwrite the header line in place and return the cursor below the field.
+				fmt.Fprintf(out, "%s%s%s\x1b[%d;1H", life.CursorHome, fr.status, life.ClearLine, diff.Top+f.Height()*scaleRows+1)
+			}
+			if bar != nil {
+				fmt.Fprintf(out, "%s%s\n", bar.line(fr.stats, fr.rule, fr.delay, fr.paused, fr.cols), life.ClearLine)
+			}
+		} else {
+			// Frames written to anything but a terminal simply follow each other.
+			switch {
+			case ansi && cleared:
+				out.WriteString(life.CursorHome)
+			case ansi:
+				out.WriteString(life.ClearScreen)
+			case tty:
+				if err := clearConsole(screen); err != nil {
+					return err
+				}
+			}
+			cleared = true
+			if !tty {
+				// Frames that follow each other in a file or pipe start with a line of their own, which holds the
+				// generation.
+				if header {
+					out.WriteString("--- " + fr.status)
+				} else {
+					out.WriteString("--- gen " + strconv.FormatUint(uint64(fr.gen), 10))
+				}
+				out.WriteByte('\n')
+			} else if header {
+				out.WriteString(fr.status)
+				if ansi {
+					out.WriteString(life.ClearLine)
+				}
+				out.WriteByte('\n')
+			}
+			if err := r.Render(out, f); err != nil {
+				return err
+			}
+			if bar != nil {
+				fmt.Fprintf(out, "%s%s\n", bar.line(fr.stats, fr.rule, fr.delay, fr.paused, fr.cols), life.ClearLine)
+			}
+			if ansi {
+				out.WriteString(life.ClearBelow)
+			}
+		}
+		return out.Flush()
+	}
+
+	advance := true
+	var next time.Time
+	// Quiet runs also report whether the pattern settled, unless they go on forever and there would be no end to
+	// the generations to remember, or are noisy.
+	if untilStable || loop.on || (quiet && ticks != 0 && noise == 0) {
+		stability = life.NewStabilityDetector()
+		stability.Observe(l)
+	}
+	unstable := false
+	// Runs with a video aren't drawn, so the view follows the pattern here instead, and only the part of the field
+	// in the -viewport goes into the video.
+	outs, err := openOutputs(l, r, out, vformat, func(f *life.Field) *life.Field {
+		if view != nil {
+			if following {
+				*view = view.Follow(f)
+			}
+			*view = view.Within(f)
+			f = f.View(*view)
+		}
+		return f
+	})
+	if err != nil {
+		return err
+	}
+	// hold shows the final frame of a pattern that settled for a moment, and reports whether the run was
+	// interrupted meanwhile.
+	hold := func() bool {
+		if !tty || quiet || len(at) != 0 {
+			return false
+		}
+		select {
+		case <-time.After(time.Second):
+		case k := <-keys:
+			if k == 'q' || k == 3 {
+				interrupted = k == 3
+				return true
+			}
+		case <-interrupt:
+			interrupted = true
+			return true
+		}
+		return false
+	}
+	// soups counts the soups that ended with -loop.
+	var soups uint
+	// nextSoup reports the soup that just ended and starts the one with the next seed, unless the run is over.
+	nextSoup := func() (bool, error) {
+		soups++
+		if quiet {
+			if err := printReport(newReport(l, settled)); err != nil {
+				return false, err
+			}
+		} else {
+			// The line goes to the screen after the last frame of the soup.
+			if err := frames.wait(); err != nil {
+				return false, err
+			}
+			fmt.Fprintf(out, "soup %d: %v\n", soups, newReport(l, settled))
+			if err := out.Flush(); err != nil {
+				return false, err
+			}
+		}
+		if hold() || soups == loop.n {
+			return false, nil
+		}
+		seed++
+		g, err := s.newGame()
+		if err != nil {
+			return false, err
+		}
+		l, settled = g, settling{}
+		stability = life.NewStabilityDetector()
+		stability.Observe(l)
+		if err := outs.write(l); err != nil {
+			return false, err
+		}
+		repaint = true
+		return true, draw()
+	}
+	// Frames are only dropped on a terminal, files and pipes get every one.
+	frames = startFrameLoop(drawFrame, tty)
+	defer func() {
+		frames.stop()
+		dropped = frames.dropped
+	}()
+	started = time.Now()
+	// expired fires when the time of -timeout is up. It is checked between generations, so the last frame is
+	// complete.
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+loop:
+	for {
+		if advance {
+			if ticks != 0 && l.Generation() >= ticks {
+				if !loop.on {
+					unstable, stopped = untilStable, stoppedTicks
+					break
+				}
+				more, err := nextSoup()
+				if err != nil {
+					return err
+				}
+				if !more {
+					break
+				}
+				continue
+			}
+			start := time.Now()
+			l.Tick()
+			simulated++
+			if err := outs.write(l); err != nil {
+				return err
+			}
+			if err := draw(); err != nil {
+				return err
+			}
+			advance = false
+			next = start.Add(delay)
+			if stability != nil {
+				if s, since, period := stability.Observe(l); s != life.Unsettled {
+					settled = settling{s, since, period}
+					// Nothing has to be remembered anymore once the pattern repeats.
+					stability = nil
+					if untilStable {
+						stopped = stoppedSettled
+						hold()
+						break loop
+					}
+					if loop.on {
+						more, err := nextSoup()
+						if err != nil {
+							return err
+						}
+						if !more {
+							break loop
+						}
+					}
+				}
+			}
+		}
+		var tick <-chan time.Time
+		if !paused {
+			tick = time.After(time.Until(next))
+		}
+		select {
+		case <-tick:
+			advance = true
+		case k := <-keys:
+			switch k {
+			case ' ':
+				paused = !paused
+				if bar != nil {
+					if err := draw(); err != nil {
+						return err
+					}
+				}
+			case 'n', '.':
+				advance = paused
+			case '+':
+				if delay > time.Millisecond {
+					delay /= 2
+				}
+			case '-':
+				if delay == 0 {
+					delay = time.Millisecond
+				} else if delay < time.Minute {
+					delay *= 2
+				}
+			case 'r':
+				g, err := s.newGame()
+				if err != nil {
+					return err
+				}
+				l = g
+				if err := outs.write(l); err != nil {
+					return err
+				}
+				if untilStable || loop.on {
+					settled = settling{}
+					stability = life.NewStabilityDetector()
+					stability.Observe(l)
+				}
+				repaint = true
+				if err := draw(); err != nil {
+					return err
+				}
+			case keyUp, keyDown, keyRight, keyLeft:
+				if view == nil {
+					break
+				}
+				dx, dy := 0, 0
+				switch k {
+				case keyUp:
+					dy = -1
+				case keyDown:
+					dy = 1
+				case keyRight:
+					dx = 1
+				case keyLeft:
+					dx = -1
+				}
+				*view = view.Panned(l.Field(), dx, dy)
+				following = false
+				if err := draw(); err != nil {
+					return err
+				}
+			case 'f':
+				if view == nil {
+					break
+				}
+				following = true
+				if err := draw(); err != nil {
+					return err
+				}
+			case 'q':
+				stopped = stoppedQuit
+				break loop
+			case 3: // Ctrl-C
+				interrupted = true
+				break loop
+			}
+		case <-interrupt:
+			interrupted = true
+			break loop
+		case <-expired:
+			unstable, stopped = untilStable, stoppedTimeout
+			break loop
+		case <-resized:
+			// Redraw right away, also while paused, to fit the new size of the terminal.
+			if err := draw(); err != nil {
+				return err
+			}
+		case <-snapshot:
+			// The copy is taken between ticks, so it is consistent, and written in the background so the run
+			// goes on.
+			f, gen := l.Field().Clone(), l.Generation()
+			writing.Add(1)
+			go func() {
+				defer writing.Done()
+				name := filepath.Join(snapshotDir, fmt.Sprintf("life-%s-gen%d.rle", time.Now().Format("20060102-150405.000"), gen))
+				if err := writeRLE(name, f, fmt.Sprintf("Snapshot of generation %d.", gen)); err != nil {
+					fmt.Fprintf(os.Stderr, "snapshot: %v%s", err, eol)
+					return
+				}
+				fmt.Fprintf(os.Stderr, "snapshot: wrote %s%s", name, eol)
+			}()
+		}
+	}
+	if interrupted {
+		stopped = stoppedInterrupted
+		// Don't keep anyone waiting who insists.
+		go func() {
+			<-interrupt
+			os.Exit(1)
+		}()
+	}
+	if final {
+		finished = true
+		if err := draw(); err != nil {
+			return err
+		}
+	}
+	if err := frames.stop(); err != nil {
+		return err
+	}
+
+	if err := outs.close(l); err != nil {
+		return err
+	}
+	if err := writeFinal(l); err != nil {
+		return err
+	}
+	if unstable && stopped == stoppedTimeout {
+		return fmt.Errorf("%w within %v", errNotStable, timeout)
+	}
+	if unstable {
+		return fmt.Errorf("%w within %d generations", errNotStable, ticks)
+	}
+	return nil
+}
+
+// appendViewport appends the position of v, and whether it follows the pattern, to the header line h, unless the
+// line would be longer than max characters.
+func appendViewport(h string, v life.Viewport, following bool, max int) string {
+	part := fmt.Sprintf("view %d,%d", v.X, v.Y)
+	if following {
+		part += " following"
+	}
+	if max != 0 && len(h)+2+len(part) > max {
+		return h
+	}
+	return h + "  " + part
+}
+
+// printWarnings prints the warnings of the pattern read from name to standard error.
+func printWarnings(name string, l *life.Game) {
+	for _, w := range l.Warnings() {
+		fmt.Fprintf(os.Stderr, "%s: warning: %v\n", name, w)
+	}
+}
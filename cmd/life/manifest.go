@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// manifest is what -replay writes and reads: the flags that decide the generations of a run, by name, so that the
+// run can be repeated exactly. Flags that only change how the run is shown are left out.
+type manifest struct {
+	Flags map[string]string `json:"flags"`
+	// Place holds the -place flags, which can be given several times.
+	Place []string `json:"place,omitempty"`
+}
+
+// readManifest reads the manifest in the named file.
+func readManifest(name string) (manifest, error) {
+	var m manifest
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return m, fmt.Errorf("%s: %w", name, err)
+	}
+	return m, nil
+}
+
+// apply sets the flags of m, except those given on the command line, which take precedence. The size is only
+// taken from m if no width and height arguments were given.
+func (m manifest) apply() error {
+	given := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		given[f.Name] = true
+	})
+	for name, value := range m.Flags {
+		if given[name] || name == "grid" && flag.NArg() != 0 {
+			continue
+		}
+		if flag.Lookup(name) == nil {
+			return fmt.Errorf("unknown flag -%s", name)
+		}
+		if err := flag.Set(name, value); err != nil {
+			return fmt.Errorf("-%s: %w", name, err)
+		}
+	}
+	if !given["place"] {
+		for _, spec := range m.Place {
+			if err := places.Set(spec); err != nil {
+				return fmt.Errorf("-place: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// newManifest returns the manifest of the run that is about to start, with the size of its field, however it was
+// given.
+func newManifest() manifest {
+	m := manifest{Flags: map[string]string{
+		"seed":    fmt.Sprint(seed),
+		"nowrap":  fmt.Sprint(nowrap),
+		"density": fmt.Sprint(density),
+		"rule":    ruleString,
+		"engine":  engine,
+	}}
+	if rleFile != "" {
+		m.Flags["file"] = rleFile
+	} else {
+		m.Flags["grid"] = fmt.Sprintf("%dx%d", width, height)
+	}
+	if patternName != "" {
+		m.Flags["pattern"] = patternName
+	}
+	if lexiconFile != "" {
+		m.Flags["lexicon"] = lexiconFile
+	}
+	if noise > 0 {
+		m.Flags["noise"] = fmt.Sprint(noise)
+	}
+	for _, p := range places {
+		m.Place = append(m.Place, p.spec)
+	}
+	// -until-stable and -loop are limited by -max and can't be combined with -ticks.
+	switch {
+	case untilStable:
+		m.Flags["until-stable"], m.Flags["max"] = "true", fmt.Sprint(maxTicks)
+	case loop.on:
+		m.Flags["loop"], m.Flags["max"] = loop.String(), fmt.Sprint(maxTicks)
+	default:
+		m.Flags["ticks"] = fmt.Sprint(ticks)
+	}
+	return m
+}
+
+// write writes m to the named file.
+func (m manifest) write(name string) error {
+	b, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	seed, width, height, ruleString, engine, ticks = 42, 30, 20, "B36/S23", "naive", 500
+	defer func() { seed, width, height, ruleString, engine, ticks = 0, 0, 0, "", "", 0 }()
+	if err := places.Set("glider.rle@2,3:r90"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { places = nil }()
+	m := newManifest()
+	want := map[string]string{"seed": "42", "nowrap": "false", "density": "0", "rule": "B36/S23", "engine": "naive", "grid": "30x20", "ticks": "500"}
+	if !reflect.DeepEqual(m.Flags, want) || !reflect.DeepEqual(m.Place, []string{"glider.rle@2,3:r90"}) {
+		t.Errorf("got %v and %v", m.Flags, m.Place)
+	}
+	name := t.TempDir() + "/run.json"
+	if err := m.write(name); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readManifest(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("read %+v, wrote %+v", got, m)
+	}
+}
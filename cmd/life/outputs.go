@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/418Coffee/life"
+)
+
+// outputs are where a run writes its generations besides the screen: the recording, the generations of -at, the
+// statistics of -csv and the video.
+type outputs struct {
+	rec   *recorder
+	atw   *atWriter
+	stats *statsWriter
+	video *videoWriter
+	// view returns the part of a field that goes into the video.
+	view func(*life.Field) *life.Field
+}
+
+// openOutputs opens the outputs the flags ask for and writes the initial state of l to them. The generations of -at
+// are drawn with r to w, unless they are written to files, and the video is written in vformat.
+func openOutputs(l *life.Game, r life.Renderer, w *bufio.Writer, vformat life.VideoFormat,
+	view func(*life.Field) *life.Field) (*outputs, error) {
+	o := &outputs{view: view}
+	if record.every != 0 {
+		record.format = recordFormat
+		if err := record.start(); err != nil {
+			return nil, fmt.Errorf("recording: %w", err)
+		}
+		o.rec = &record
+	}
+	if len(at) != 0 {
+		if outDir != "" {
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return nil, fmt.Errorf("-at: %w", err)
+			}
+		}
+		o.atw = &atWriter{gens: at, dir: outDir, format: recordFormat, r: r, header: header, w: w}
+	}
+	var err error
+	if csvFile != "" {
+		if o.stats, err = createStatsWriter(csvFile); err != nil {
+			return nil, fmt.Errorf("statistics: %w", err)
+		}
+	}
+	if videoFile != "" {
+		vr := &life.VideoRenderer{Format: vformat, CellSize: int(cellSize), FrameRate: fps}
+		if o.video, err = createVideoWriter(videoFile, vr); err != nil {
+			return nil, fmt.Errorf("video: %w", err)
+		}
+	}
+	return o, o.write(l)
+}
+
+// write writes the current generation of l to the outputs.
+func (o *outputs) write(l *life.Game) error {
+	if o.rec != nil {
+		o.rec.record(l)
+	}
+	if o.atw != nil {
+		if err := o.atw.write(l); err != nil {
+			return fmt.Errorf("-at: %w", err)
+		}
+	}
+	if o.stats != nil {
+		if err := o.stats.write(l); err != nil {
+			return fmt.Errorf("statistics: %w", err)
+		}
+	}
+	if o.video != nil {
+		if err := o.video.write(o.view(l.Field())); err != nil {
+			return fmt.Errorf("video: %w", err)
+		}
+	}
+	return nil
+}
+
+// close records the final generation of l, unless it was recorded already, and closes the outputs.
+func (o *outputs) close(l *life.Game) error {
+	if o.rec != nil {
+		if err := o.rec.finish(l); err != nil {
+			return fmt.Errorf("recording: %w", err)
+		}
+	}
+	if o.stats != nil {
+		if err := o.stats.close(); err != nil {
+			return fmt.Errorf("statistics: %w", err)
+		}
+	}
+	if o.video != nil {
+		if err := o.video.close(); err != nil {
+			return fmt.Errorf("video: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeFinal writes the final state of l to -out and its heat map to -heatmap-out, if they are given.
+func writeFinal(l *life.Game) error {
+	if outFile != "" {
+		if err := writeResult(l); err != nil {
+			return fmt.Errorf("writing the final state to %s: %w", outFile, err)
+		}
+	}
+	if heatmapOut != "" {
+		if err := writePNG(heatmapOut, l.HeatMap()); err != nil {
+			return fmt.Errorf("writing the heat map to %s: %w", heatmapOut, err)
+		}
+	}
+	return nil
+}
+
+// writeResult writes the current state of l to outFile, with comments describing how it came about.
+func writeResult(l *life.Game) error {
+	var origin string
+	switch {
+	case editing:
+		origin = "of a pattern drawn in the editor"
+	case rleFile == "-":
+		origin = "of a pattern read from standard input"
+	case rleFile != "":
+		origin = "of " + rleFile
+	case len(places) > 0:
+		origin = "of " + places.String()
+	case patternName != "":
+		origin = "of the pattern " + patternName
+	default:
+		origin = fmt.Sprintf("of a random start with seed %d", seed)
+	}
+	comments := []string{fmt.Sprintf("Generation %d %s.", l.Generation(), origin)}
+	switch {
+	case outFile == "-":
+		return l.Field().WriteRLE(os.Stdout, comments...)
+	case filepath.Ext(outFile) == ".pbm":
+		return pbmWriter(false)(outFile, l.Field(), comments...)
+	}
+	return writeRLE(outFile, l.Field(), comments...)
+}
+
+// writeRLE writes f with the given comments to the file with the given name.
+func writeRLE(name string, f *life.Field, comments ...string) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := f.WriteRLE(file, comments...); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// pbmWriter returns a function that writes a field with the given comments to the file with the given name as a
+// PBM image, in the plain format if plain is set.
+func pbmWriter(plain bool) func(name string, f *life.Field, comments ...string) error {
+	return func(name string, f *life.Field, comments ...string) error {
+		file, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		if err := f.WritePBM(file, plain, comments...); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+}
+
+// writePNG writes img to the file with the given name as PNG.
+func writePNG(name string, img image.Image) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/418Coffee/life"
+	"github.com/418Coffee/life/patterns"
+)
+
+// listPatterns runs the patterns subcommand with the given arguments. It lists the built-in patterns of -pattern,
+// or those of a Life Lexicon file.
+func listPatterns(args []string) error {
+	fs := flag.NewFlagSet("patterns", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s patterns [options]\nLists the patterns that -pattern places on the field.\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	lexicon := fs.String("lexicon", "", "list the patterns of a file of the Life Lexicon instead of the built-in ones")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	names, get := patterns.Names(), patterns.Get
+	if *lexicon != "" {
+		l, err := openLexicon(*lexicon)
+		if err != nil {
+			return err
+		}
+		names, get = l.Names(), l.Get
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "pattern\tsize\tpopulation")
+	for _, name := range names {
+		p, _ := get(name)
+		fmt.Fprintf(w, "%s\t%dx%d\t%d\n", name, p.Width(), p.Height(), p.Population())
+	}
+	return w.Flush()
+}
+
+// openLexicon reads the named file of the Life Lexicon, and prints the warnings about the entries it skipped to
+// standard error.
+func openLexicon(name string) (*life.Lexicon, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	l, err := life.OpenLexicon(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	for _, w := range l.Warnings() {
+		fmt.Fprintf(os.Stderr, "%s: warning: %v\n", name, w)
+	}
+	return l, nil
+}
+
+// findPattern returns the named built-in pattern, or the one of the Life Lexicon file lexicon if it isn't empty.
+func findPattern(name, lexicon string) (*life.Field, error) {
+	if lexicon == "" {
+		return patterns.Get(name)
+	}
+	l, err := openLexicon(lexicon)
+	if err != nil {
+		return nil, err
+	}
+	return l.Get(name)
+}
+
+// centred returns an empty field of the given size with the pattern p of the given name in its middle.
+func centred(p *life.Field, name string, width, height uint, wrap bool) (*life.Field, error) {
+	if p.Width() > width || p.Height() > height {
+		return nil, fmt.Errorf("the %dx%d pattern %s doesn't fit on a %dx%d field", p.Width(), p.Height(), name, width, height)
+	}
+	f := life.NewField(width, height, wrap)
+	if err := f.Place(p, (width-p.Width())/2, (height-p.Height())/2); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestCentred(t *testing.T) {
+	glider, err := findPattern("glider", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := centred(glider, "glider", 7, 6, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "       \n" +
+		"   █   \n" +
+		"    █  \n" +
+		"  ███  \n" +
+		"       \n" +
+		"       \n"
+	if got := f.String(); got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+	pulsar, _ := findPattern("pulsar", "")
+	if _, err := centred(pulsar, "pulsar", 12, 20, true); err == nil {
+		t.Error("got no error for a pattern wider than the field")
+	}
+	if _, err := findPattern("spaceship", ""); err == nil {
+		t.Error("got no error for an unknown pattern")
+	}
+}
+
+func TestFindPatternInLexicon(t *testing.T) {
+	name := t.TempDir() + "/lexicon.txt"
+	lexicon := ":Block: (p1) A still life.\n\tOO\n\tOO\n\n:glider: (c/4) A spaceship.\n\t.O.\n\t..O\n\tOOO\n"
+	if err := os.WriteFile(name, []byte(lexicon), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := findPattern("block", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Width() != 2 || f.Height() != 2 || f.Population() != 4 {
+		t.Errorf("got\n%s, wanted the block", f)
+	}
+	if _, err := findPattern("pulsar", name); err == nil {
+		t.Error("got a built-in pattern that isn't in the lexicon")
+	}
+}
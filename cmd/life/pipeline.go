@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/418Coffee/life"
+	"golang.org/x/term"
+)
+
+// newRenderer returns the renderer that draws the frames of a run as the flags ask for, and exits with the usage if
+// they don't go together. tty and ansi tell whether the screen is a terminal and understands ANSI escape sequences,
+// and withBar whether a status bar is drawn below the field. zoomer is the renderer of -zoom auto, whose blocks are
+// fitted to the size of the screen, and ages is set if the renderer colours the cells by their age.
+func newRenderer(tty, ansi, withBar bool) (r life.Renderer, zoomer *life.ZoomRenderer, ages bool) {
+	r, err := life.LookupRenderer(renderer)
+	if err != nil {
+		printUsageAndExit(err)
+	}
+	switch color {
+	case "none":
+	case "256", "8":
+		if renderer != "block" {
+			printUsageAndExit(fmt.Errorf("-color requires the block renderer"))
+		}
+		if ansi {
+			r = life.AgeRenderer{Basic: color == "8"}
+			ages = true
+		}
+	default:
+		printUsageAndExit(fmt.Errorf("unknown color mode %q", color))
+	}
+	if scale == 0 {
+		printUsageAndExit(fmt.Errorf("-scale must be positive"))
+	}
+	if zoom != "" {
+		if renderer != "block" || color != "none" || scale > 1 || rulers || sixel {
+			printUsageAndExit(fmt.Errorf("-zoom draws blocks of cells and can't be combined with -renderer, -color, -scale, -rulers or -sixel"))
+		}
+		if zoom == "auto" {
+			cols, rows := fallbackCols, fallbackRows
+			if c, rw, err := term.GetSize(int(screen.Fd())); tty && err == nil {
+				cols, rows = c, rw
+			}
+			zoomer = &life.ZoomRenderer{}
+			zoomer.Columns, zoomer.Rows = fitSize(life.BlockRenderer{}, cols, rows, header, withBar, border, false)
+			r = zoomer
+		} else {
+			n, err := strconv.ParseUint(zoom, 10, strconv.IntSize)
+			if err != nil || n == 0 {
+				printUsageAndExit(fmt.Errorf("-zoom must be a positive number of cells or auto"))
+			}
+			r = life.ZoomRenderer{Block: uint(n)}
+		}
+	}
+	if scale > 1 {
+		switch sr := r.(type) {
+		case life.BlockRenderer:
+			sr.Scale = scale
+			r = sr
+		case life.AgeRenderer:
+			sr.Scale = scale
+			r = sr
+		default:
+			printUsageAndExit(fmt.Errorf("-scale requires the block or age renderer"))
+		}
+		if rulers {
+			printUsageAndExit(fmt.Errorf("-rulers can't be combined with -scale"))
+		}
+	}
+	switch border {
+	case "none":
+		if rulers {
+			r = life.FrameRenderer{Renderer: r, Rulers: true}
+		}
+	case "unicode", "ascii":
+		r = life.FrameRenderer{Renderer: r, ASCII: border == "ascii", Rulers: rulers}
+	default:
+		printUsageAndExit(fmt.Errorf("unknown border %q", border))
+	}
+	if rulers && renderer != "block" {
+		printUsageAndExit(fmt.Errorf("-rulers requires the block renderer"))
+	}
+	if cellSize == 0 {
+		printUsageAndExit(fmt.Errorf("-cell-size must be positive"))
+	}
+	if sixel {
+		if renderer != "block" || color != "none" || scale > 1 || border != "none" || rulers || fit != fitNone {
+			printUsageAndExit(fmt.Errorf("-sixel draws the cells as pixels and can't be combined with -renderer, -color, -scale, -border, -rulers or -fit"))
+		}
+		// Whether the terminal supports sixels can't be told reliably, which is why they have to be asked for, but
+		// anything that isn't a terminal certainly doesn't.
+		if ansi {
+			r = &life.SixelRenderer{CellSize: int(cellSize)}
+		} else {
+			fmt.Fprintln(os.Stderr, "-sixel: the screen isn't a terminal, drawing the field as text")
+		}
+	}
+	return r, zoomer, ages
+}
+
+// newDiffRenderer returns a renderer that only redraws the changed cells, if nothing but the plain cells end up on
+// screen, and nil otherwise. ansi and ages are like for newRenderer.
+func newDiffRenderer(ansi, ages bool) *life.DiffRenderer {
+	if !ansi || quiet || redraw || renderer != "block" || zoom != "" || sixel || len(at) != 0 || border != "none" || rulers || ages {
+		return nil
+	}
+	diff := life.NewDiffRenderer()
+	diff.Scale = scale
+	if header {
+		diff.Top = 1
+	}
+	return diff
+}
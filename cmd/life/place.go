@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/418Coffee/life"
+)
+
+// placement is a pattern file to be placed onto the initial board, given as file@x,y[:transform].
+type placement struct {
+	spec      string
+	file      string
+	x, y      uint
+	transform life.Transform
+}
+
+// placements is the value of the -place flag, which can be given several times.
+type placements []placement
+
+func (p *placements) String() string {
+	specs := make([]string, len(*p))
+	for i, pl := range *p {
+		specs[i] = pl.spec
+	}
+	return strings.Join(specs, " ")
+}
+
+func (p *placements) Set(spec string) error {
+	// The file name may contain @ and, on Windows, a colon, so the position is looked for after the last @.
+	at := strings.LastIndexByte(spec, '@')
+	if at <= 0 {
+		return fmt.Errorf("%q isn't of the form file@x,y or file@x,y:transform", spec)
+	}
+	pl := placement{spec: spec, file: spec[:at]}
+	pos := spec[at+1:]
+	if colon := strings.IndexByte(pos, ':'); colon >= 0 {
+		t, err := life.ParseTransform(pos[colon+1:])
+		if err == nil && t == life.Identity {
+			err = fmt.Errorf("missing transform after the colon")
+		}
+		if err != nil {
+			return fmt.Errorf("%q: %w", spec, err)
+		}
+		pl.transform, pos = t, pos[:colon]
+	}
+	comma := strings.IndexByte(pos, ',')
+	if comma < 0 {
+		return fmt.Errorf("%q: the position %q isn't of the form x,y", spec, pos)
+	}
+	x, errX := strconv.ParseUint(pos[:comma], 10, strconv.IntSize)
+	y, errY := strconv.ParseUint(pos[comma+1:], 10, strconv.IntSize)
+	if errX != nil || errY != nil {
+		return fmt.Errorf("%q: the position %q isn't of the form x,y", spec, pos)
+	}
+	pl.x, pl.y = uint(x), uint(y)
+	*p = append(*p, pl)
+	return nil
+}
+
+// compose places the patterns onto an empty board of the given size. Overlapping patterns are combined, unless
+// overlap is false, which makes them an error.
+func compose(p placements, width, height uint, wrap, overlap bool) (*life.Field, error) {
+	f := life.NewField(width, height, wrap)
+	for _, pl := range p {
+		g, err := life.LoadGame(pl.file, false)
+		if err != nil {
+			return nil, fmt.Errorf("-place %s: %w", pl.spec, err)
+		}
+		printWarnings(pl.file, g)
+		pattern := g.Field().Transformed(pl.transform)
+		if !overlap {
+			var clash error
+			pattern.EachLive(func(x, y uint) {
+				if clash == nil && pl.x+x < width && pl.y+y < height && f.Alive(int(pl.x+x), int(pl.y+y)) {
+					clash = fmt.Errorf("-place %s: overlaps an earlier pattern at %d,%d", pl.spec, pl.x+x, pl.y+y)
+				}
+			})
+			if clash != nil {
+				return nil, clash
+			}
+		}
+		if err := f.Place(pattern, pl.x, pl.y); err != nil {
+			return nil, fmt.Errorf("-place %s: %w", pl.spec, err)
+		}
+	}
+	return f, nil
+}
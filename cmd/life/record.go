@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/418Coffee/life"
+)
+
+// recordFormats are the formats the recorder can write, by name.
+var recordFormats = map[string]struct {
+	ext   string
+	write func(name string, f *life.Field, comments ...string) error
+}{
+	"rle":       {".rle", writeRLE},
+	"pbm":       {".pbm", pbmWriter(false)},
+	"pbm-plain": {".pbm", pbmWriter(true)},
+}
+
+func recordFormatNames() []string {
+	names := make([]string, 0, len(recordFormats))
+	for name := range recordFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// recorder writes every so many generations of a run, and the last one, to a directory.
+// The states are copied right after the tick that computed them and written in the background, so that recording
+// slows the run down only when the writes fall behind. Then the run waits for them, which may delay frames, but no
+// generation is skipped.
+type recorder struct {
+	every  uint
+	dir    string
+	format string
+
+	// last is the generation recorded last, so the final state isn't written twice.
+	last     uint
+	recorded bool
+	queue    chan recording
+	done     chan error
+}
+
+type recording struct {
+	f   *life.Field
+	gen uint
+}
+
+// String and Set make a recorder the value of the -record flag, given as every=N dir=DIR.
+func (r *recorder) String() string {
+	if r == nil || r.every == 0 {
+		return ""
+	}
+	return fmt.Sprintf("every=%d dir=%s", r.every, r.dir)
+}
+
+func (r *recorder) Set(s string) error {
+	r.dir = "."
+	for _, option := range strings.FieldsFunc(s, func(c rune) bool { return c == ' ' || c == ',' }) {
+		eq := strings.IndexByte(option, '=')
+		if eq < 0 {
+			return fmt.Errorf("%q isn't of the form key=value", option)
+		}
+		switch key, value := option[:eq], option[eq+1:]; key {
+		case "every":
+			n, err := strconv.ParseUint(value, 10, strconv.IntSize)
+			if err != nil || n == 0 {
+				return fmt.Errorf("every must be a positive number of generations, not %q", value)
+			}
+			r.every = uint(n)
+		case "dir":
+			r.dir = value
+		default:
+			return fmt.Errorf("unknown option %q, expected every or dir", key)
+		}
+	}
+	if r.every == 0 {
+		return fmt.Errorf("missing every=N")
+	}
+	return nil
+}
+
+// start creates the directory and starts writing in the background.
+func (r *recorder) start() error {
+	if _, ok := recordFormats[r.format]; !ok {
+		return fmt.Errorf("unknown record format %q (available: %s)", r.format, strings.Join(recordFormatNames(), ", "))
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+	r.queue, r.done = make(chan recording, 4), make(chan error, 1)
+	go func() {
+		var first error
+		for rec := range r.queue {
+			if err := r.write(rec); err != nil && first == nil {
+				first = err
+			}
+		}
+		r.done <- first
+	}()
+	return nil
+}
+
+// record records the current generation of l if it is one of every so many.
+func (r *recorder) record(l *life.Game) {
+	if gen := l.Generation(); gen%r.every == 0 {
+		r.enqueue(l, gen)
+	}
+}
+
+// finish records the current generation of l, unless it already was, waits for the writes to be done and returns
+// the first error that occurred.
+func (r *recorder) finish(l *life.Game) error {
+	if gen := l.Generation(); !r.recorded || gen != r.last {
+		r.enqueue(l, gen)
+	}
+	close(r.queue)
+	return <-r.done
+}
+
+func (r *recorder) enqueue(l *life.Game, gen uint) {
+	r.last, r.recorded = gen, true
+	r.queue <- recording{l.Field().Clone(), gen}
+}
+
+func (r *recorder) write(rec recording) error {
+	format := recordFormats[r.format]
+	return format.write(recordingName(r.dir, rec.gen, r.format), rec.f, fmt.Sprintf("Generation %d.", rec.gen))
+}
+
+// recordingName returns the name of the file in dir that generation gen is written to in the given format.
+func recordingName(dir string, gen uint, format string) string {
+	return filepath.Join(dir, fmt.Sprintf("gen%08d%s", gen, recordFormats[format].ext))
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestRecorder(t *testing.T) {
+	for _, tt := range []struct {
+		every, ticks uint
+		want         []string
+	}{
+		{1, 3, []string{"gen00000000.rle", "gen00000001.rle", "gen00000002.rle", "gen00000003.rle"}},
+		{2, 5, []string{"gen00000000.rle", "gen00000002.rle", "gen00000004.rle", "gen00000005.rle"}},
+		{2, 4, []string{"gen00000000.rle", "gen00000002.rle", "gen00000004.rle"}},
+	} {
+		r := new(recorder)
+		dir := t.TempDir() + "/snapshots"
+		if err := r.Set("every=" + strconv.FormatUint(uint64(tt.every), 10) + " dir=" + dir); err != nil {
+			t.Fatal(err)
+		}
+		r.format = "rle"
+		if err := r.start(); err != nil {
+			t.Fatal(err)
+		}
+		f := life.NewField(3, 3, true)
+		for x := uint(0); x < 3; x++ {
+			f.Set(x, 1, true)
+		}
+		l := life.NewGameFromField(f)
+		r.record(l)
+		for l.Generation() < tt.ticks {
+			l.Tick()
+			r.record(l)
+		}
+		if err := r.finish(l); err != nil {
+			t.Fatal(err)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, e := range entries {
+			got = append(got, e.Name())
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("every %d for %d ticks: got %v, wanted %v", tt.every, tt.ticks, got, tt.want)
+		}
+	}
+}
+
+func TestRecorderSet(t *testing.T) {
+	r := new(recorder)
+	if err := r.Set("every=500,dir=out"); err != nil || r.every != 500 || r.dir != "out" {
+		t.Errorf("got every %d dir %q and error %v", r.every, r.dir, err)
+	}
+	for _, s := range []string{"", "dir=out", "every=0", "every=x", "often=1", "every"} {
+		if err := new(recorder).Set(s); err == nil {
+			t.Errorf("%q: got no error", s)
+		}
+	}
+}
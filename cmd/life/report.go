@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/418Coffee/life"
+)
+
+// settling is what a run settled into, as found by a life.StabilityDetector.
+type settling struct {
+	s             life.Stability
+	since, period uint
+}
+
+func (s settling) String() string {
+	if s.s == life.Unsettled {
+		return "not stabilized"
+	}
+	return fmt.Sprintf("%v at generation %d, period %d", s.s, s.since, s.period)
+}
+
+// settle runs l without drawing it until it settles or reaches generation max, and returns what it settled into.
+// If each isn't nil, it is called with every generation, starting with the current one.
+func settle(l *life.Game, max uint, each func(*life.Game)) settling {
+	d := life.NewStabilityDetector()
+	for {
+		if each != nil {
+			each(l)
+		}
+		if s, since, period := d.Observe(l); s != life.Unsettled {
+			return settling{s, since, period}
+		}
+		if l.Generation() >= max {
+			return settling{}
+		}
+		l.Tick()
+	}
+}
+
+// report is the summary of a run printed by -quiet.
+type report struct {
+	Seed        *int64 `json:"seed,omitempty"`
+	File        string `json:"file,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	Width       uint   `json:"width"`
+	Height      uint   `json:"height"`
+	Rule        string `json:"rule"`
+	Generations uint   `json:"generations"`
+	Population  uint   `json:"population"`
+	Stabilized  bool   `json:"stabilized"`
+	Stability   string `json:"stability"`
+	Since       uint   `json:"stabilized_at"`
+	Period      uint   `json:"period"`
+	// StoppedBy is why the run ended, see describeStop.
+	StoppedBy string `json:"stopped_by,omitempty"`
+
+	settled settling
+}
+
+// newReport returns the report of a run that ended with l, having settled as described by s.
+func newReport(l *life.Game, s settling) report {
+	r := report{
+		File:        rleFile,
+		Pattern:     patternName,
+		Width:       l.Field().Width(),
+		Height:      l.Field().Height(),
+		Rule:        l.Rule().String(),
+		Generations: l.Generation(),
+		Population:  l.Population(),
+		Stabilized:  s.s != life.Unsettled,
+		Stability:   s.s.String(),
+		Since:       s.since,
+		Period:      s.period,
+		settled:     s,
+	}
+	// With -noise, the seed matters for any initial state.
+	if rleFile == "" && patternName == "" || noise > 0 {
+		r.Seed = &seed
+	}
+	return r
+}
+
+func (r report) String() string {
+	origin := r.File
+	if r.Pattern != "" {
+		origin = "pattern " + r.Pattern
+	}
+	if r.Seed != nil && origin != "" {
+		origin += fmt.Sprintf(" with seed %d", *r.Seed)
+	} else if r.Seed != nil {
+		origin = fmt.Sprintf("seed %d", *r.Seed)
+	}
+	s := fmt.Sprintf("%s, %dx%d, rule %s: %d generations, final population %d, %v",
+		origin, r.Width, r.Height, r.Rule, r.Generations, r.Population, r.settled)
+	// Settling is already told by the line itself.
+	if r.StoppedBy != "" && r.StoppedBy != stoppedSettled {
+		s += ", " + describeStop(r.StoppedBy)
+	}
+	return s
+}
+
+// Why a run ended.
+const (
+	stoppedTicks       = "ticks"
+	stoppedSettled     = "settled"
+	stoppedTimeout     = "timeout"
+	stoppedQuit        = "quit"
+	stoppedInterrupted = "interrupted"
+)
+
+// describeStop returns why a run ended, for one of the stopped constants.
+func describeStop(stopped string) string {
+	switch stopped {
+	case stoppedTicks:
+		return "stopped at -ticks"
+	case stoppedSettled:
+		return "stopped when settled"
+	case stoppedTimeout:
+		return fmt.Sprintf("stopped after -timeout %v", timeout)
+	case stoppedQuit:
+		return "quit"
+	}
+	return stopped
+}
+
+// printReport prints r on the screen, as JSON if -json is set.
+func printReport(r report) error {
+	if !jsonSummary {
+		_, err := fmt.Fprintln(screen, r)
+		return err
+	}
+	return json.NewEncoder(screen).Encode(r)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+func TestReportStoppedBy(t *testing.T) {
+	defer func(d time.Duration) { timeout = d }(timeout)
+	timeout = 30 * time.Second
+	r := report{File: "gun.rle", Width: 40, Height: 20, Rule: "B3/S23", Generations: 1234, Population: 56}
+	for _, tt := range []struct {
+		stopped string
+		settled settling
+		want    string
+	}{
+		{"", settling{}, "gun.rle, 40x20, rule B3/S23: 1234 generations, final population 56, not stabilized"},
+		{stoppedTicks, settling{}, "gun.rle, 40x20, rule B3/S23: 1234 generations, final population 56, not stabilized, stopped at -ticks"},
+		{stoppedTimeout, settling{}, "gun.rle, 40x20, rule B3/S23: 1234 generations, final population 56, not stabilized, stopped after -timeout 30s"},
+		{stoppedSettled, settling{life.StillLife, 1200, 1}, "gun.rle, 40x20, rule B3/S23: 1234 generations, final population 56, still life at generation 1200, period 1"},
+	} {
+		r.StoppedBy, r.settled = tt.stopped, tt.settled
+		if got := r.String(); got != tt.want {
+			t.Errorf("got %q, wanted %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows && !plan9 && !js
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// resizeSignals returns a channel that receives SIGWINCH, which is sent when the terminal is resized.
+func resizeSignals() <-chan os.Signal {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGWINCH)
+	return c
+}
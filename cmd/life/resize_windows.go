@@ -0,0 +1,11 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// resizeSignals returns nil, Windows has no signal for resizing the console. Its size is still checked before
+// every frame.
+func resizeSignals() <-chan os.Signal {
+	return nil
+}
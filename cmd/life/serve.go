@@ -0,0 +1,454 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+//go:embed serve.html
+var servePage []byte
+
+// serve runs the serve subcommand with the given arguments. It runs a game and serves its state over HTTP until
+// interrupted.
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s serve [options] [width height]\n"+
+			"Serves the page at /, the state as JSON at /state, a stream of it at /stream (?diff=1 for only the\n"+
+			"changed cells) and takes pause, resume, step and reset at /control.\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	opts := addGameOptions(fs, 10)
+	fs.Parse(args)
+	newGame, err := opts.newGame(fs)
+	if err != nil {
+		return err
+	}
+	s, err := newServer(newGame)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	srv := &http.Server{Addr: *addr, Handler: s}
+	failed := make(chan error, 1)
+	go func() {
+		failed <- srv.ListenAndServe()
+	}()
+	simulated := make(chan struct{})
+	go func() {
+		s.run(ctx, opts.delay())
+		close(simulated)
+	}()
+	opts.printSeed()
+	fmt.Fprintf(os.Stderr, "serving on http://%s\n", *addr)
+	select {
+	case err := <-failed:
+		stop()
+		<-simulated
+		return err
+	case <-ctx.Done():
+	}
+	// Streams never end by themselves, so they are told to before waiting for the requests to be done.
+	s.close()
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = srv.Shutdown(shutdown)
+	<-simulated
+	return err
+}
+
+// gameOptions are the flags that set up the game of the serve subcommands.
+type gameOptions struct {
+	file       *string
+	seed       *int64
+	density    *float64
+	nowrap     *bool
+	ruleString *string
+	engine     *string
+	fps        *float64
+}
+
+// addGameOptions defines the flags of the game on fs, with fps generations per second by default.
+func addGameOptions(fs *flag.FlagSet, fps float64) *gameOptions {
+	return &gameOptions{
+		file:       fs.String("file", "", "load initial state from .rle file (mutually exclusive with width height arguments)"),
+		seed:       fs.Int64("seed", time.Now().UnixMicro(), "seed for the random initial state"),
+		density:    fs.Float64("density", life.DefaultDensity, "probability of a cell being alive in the random initial state"),
+		nowrap:     fs.Bool("nowrap", false, "don't wrap field toroidally"),
+		ruleString: fs.String("rule", "B3/S23", "rule in B/S notation, e.g. B36/S23"),
+		engine:     fs.String("engine", "naive", "how generations are computed: "+strings.Join(life.EngineNames(), ", ")),
+		fps:        fs.Float64("fps", fps, "generations computed per second"),
+	}
+}
+
+// newGame checks the options and the width and height arguments of the parsed fs, and returns the function that
+// creates the game from them.
+func (o *gameOptions) newGame(fs *flag.FlagSet) (func() (*life.Game, error), error) {
+	if *o.fps <= 0 {
+		return nil, fmt.Errorf("-fps must be positive")
+	}
+	if *o.density < 0 || *o.density > 1 {
+		return nil, fmt.Errorf("-density must be between 0 and 1")
+	}
+	rule, err := life.ParseRule(*o.ruleString)
+	if err != nil {
+		return nil, err
+	}
+	var width, height uint64
+	switch {
+	case *o.file != "" && fs.NArg() == 0:
+	case *o.file == "" && fs.NArg() == 2:
+		width, err = strconv.ParseUint(fs.Arg(0), 0, strconv.IntSize)
+		if err == nil {
+			height, err = strconv.ParseUint(fs.Arg(1), 0, strconv.IntSize)
+		}
+		if err != nil || width == 0 || height == 0 {
+			return nil, fmt.Errorf("width and height must be positive numbers")
+		}
+		if err := life.CheckSize(uint(width), uint(height)); err != nil {
+			return nil, err
+		}
+	default:
+		fs.Usage()
+		os.Exit(1)
+	}
+	warned := false
+	return func() (*life.Game, error) {
+		var l *life.Game
+		var err error
+		if *o.file != "" {
+			if l, err = life.LoadGame(*o.file, !*o.nowrap); err != nil {
+				return nil, err
+			}
+			// The file is read again for every new game, but its warnings only need to be told once.
+			if !warned {
+				printWarnings(*o.file, l)
+				warned = true
+			}
+		} else {
+			r := rand.New(rand.NewSource(*o.seed))
+			if l, err = life.New(uint(width), uint(height), life.WithWrap(!*o.nowrap), life.WithDensity(*o.density), life.WithRand(r)); err != nil {
+				return nil, err
+			}
+		}
+		e, err := life.NewEngine(*o.engine)
+		if err != nil {
+			return nil, err
+		}
+		l.SetRule(rule)
+		l.SetEngine(e)
+		return l, nil
+	}, nil
+}
+
+// delay returns the time between two generations.
+func (o *gameOptions) delay() time.Duration {
+	return time.Duration(float64(time.Second) / *o.fps)
+}
+
+// printSeed prints the seed of a random game, so that it can be watched again.
+func (o *gameOptions) printSeed() {
+	if *o.file == "" {
+		fmt.Fprintf(os.Stderr, "seed %d\n", *o.seed)
+	}
+}
+
+// frame is the state of a generation as served. It isn't changed once published, so handlers can read it without
+// holding a lock.
+type frame struct {
+	Generation uint      `json:"generation"`
+	Width      uint      `json:"width"`
+	Height     uint      `json:"height"`
+	Population uint      `json:"population"`
+	Paused     bool      `json:"paused"`
+	Cells      [][2]uint `json:"cells"`
+}
+
+func newFrame(l *life.Game, paused bool) *frame {
+	f := &frame{
+		Generation: l.Generation(),
+		Width:      l.Field().Width(),
+		Height:     l.Field().Height(),
+		Population: l.Population(),
+		Paused:     paused,
+		Cells:      make([][2]uint, 0, l.Population()),
+	}
+	l.Field().EachLive(func(x, y uint) {
+		f.Cells = append(f.Cells, [2]uint{x, y})
+	})
+	return f
+}
+
+// server runs a game and serves its frames. Only the goroutine running run touches the game, everything else goes
+// through the frames it publishes and the commands it takes.
+type server struct {
+	newGame  func() (*life.Game, error)
+	commands chan command
+	mux      *http.ServeMux
+	// game is only used by run once the server is created.
+	game *life.Game
+
+	mu      sync.Mutex
+	current *frame
+	streams map[chan *frame]struct{}
+	// done is closed when the server shuts down, which ends the streams.
+	done   chan struct{}
+	closed bool
+}
+
+// command is a request to the simulation from /control, answered with an error on reply.
+type command struct {
+	name  string
+	reply chan error
+}
+
+// newServer returns a server of the game created by newGame, which is called again on every reset.
+func newServer(newGame func() (*life.Game, error)) (*server, error) {
+	l, err := newGame()
+	if err != nil {
+		return nil, err
+	}
+	s := &server{
+		newGame:  newGame,
+		game:     l,
+		commands: make(chan command),
+		mux:      http.NewServeMux(),
+		streams:  make(map[chan *frame]struct{}),
+		done:     make(chan struct{}),
+	}
+	s.mux.HandleFunc("/", s.handlePage)
+	s.mux.HandleFunc("/state", s.handleState)
+	s.mux.HandleFunc("/stream", s.handleStream)
+	s.mux.HandleFunc("/control", s.handleControl)
+	s.current = newFrame(l, false)
+	return s, nil
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// run computes a generation every delay and carries out the commands until ctx is done.
+func (s *server) run(ctx context.Context, delay time.Duration) {
+	l, paused := s.game, false
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		var tick <-chan time.Time
+		if !paused {
+			tick = ticker.C
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			l.Tick()
+		case c := <-s.commands:
+			var err error
+			switch c.name {
+			case "pause":
+				paused = true
+			case "resume":
+				paused = false
+			case "step":
+				l.Tick()
+			case "reset":
+				var g *life.Game
+				if g, err = s.newGame(); err == nil {
+					l = g
+				}
+			default:
+				err = fmt.Errorf("unknown command %q, expected pause, resume, step or reset", c.name)
+			}
+			c.reply <- err
+		}
+		s.publish(newFrame(l, paused))
+	}
+}
+
+// publish makes f the current frame and passes it on to the streams. A stream that hasn't taken the previous frame
+// yet gets f in its place, so a slow client skips frames rather than holding up the simulation.
+func (s *server) publish(f *frame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = f
+	for c := range s.streams {
+		select {
+		case <-c:
+		default:
+		}
+		c <- f
+	}
+}
+
+// subscribe returns a channel that receives the frames from now on, starting with the current one.
+func (s *server) subscribe() chan *frame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := make(chan *frame, 1)
+	c <- s.current
+	s.streams[c] = struct{}{}
+	return c
+}
+
+func (s *server) unsubscribe(c chan *frame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.streams, c)
+}
+
+// close ends the streams.
+func (s *server) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
+
+func (s *server) frame() *frame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+func (s *server) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(servePage)
+}
+
+func (s *server) handleState(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.frame())
+}
+
+// diffFrame is the part of a frame that changed since the previous one sent on a stream, for streams with ?diff=1.
+type diffFrame struct {
+	Generation uint      `json:"generation"`
+	Population uint      `json:"population"`
+	Paused     bool      `json:"paused"`
+	Born       [][2]uint `json:"born"`
+	Died       [][2]uint `json:"died"`
+}
+
+// handleStream sends a frame per generation as server-sent events, until the client goes away or the server shuts
+// down. Frames are "frame" events; with ?diff=1, the first one is followed by "diff" events with only the cells
+// that changed.
+func (s *server) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming isn't supported", http.StatusInternalServerError)
+		return
+	}
+	diff := r.URL.Query().Get("diff") == "1"
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	frames := s.subscribe()
+	defer s.unsubscribe(frames)
+	// alive holds the cells sent so far, to tell what changed, for diff streams.
+	var alive []bool
+	var prev *frame
+	for {
+		var f *frame
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case f = <-frames:
+		}
+		event, data := "frame", interface{}(f)
+		if diff && prev != nil && (prev.Width != f.Width || prev.Height != f.Height) {
+			prev = nil
+		}
+		if diff && prev != nil {
+			event, data = "diff", changes(alive, f)
+		}
+		if diff {
+			if prev == nil {
+				alive = make([]bool, f.Width*f.Height)
+				for _, c := range f.Cells {
+					alive[c[1]*f.Width+c[0]] = true
+				}
+			}
+			prev = f
+		}
+		b, err := json.Marshal(data)
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// changes returns the cells of f that were born or died since alive, and updates alive to f.
+func changes(alive []bool, f *frame) diffFrame {
+	d := diffFrame{Generation: f.Generation, Population: f.Population, Paused: f.Paused, Born: [][2]uint{}, Died: [][2]uint{}}
+	now := make([]bool, len(alive))
+	for _, c := range f.Cells {
+		i := c[1]*f.Width + c[0]
+		now[i] = true
+		if !alive[i] {
+			d.Born = append(d.Born, c)
+		}
+	}
+	for i, was := range alive {
+		if was && !now[i] {
+			d.Died = append(d.Died, [2]uint{uint(i) % f.Width, uint(i) / f.Width})
+		}
+	}
+	copy(alive, now)
+	return d
+}
+
+// handleControl carries out the command given as the command form value, e.g. curl -d command=pause.
+func (s *server) handleControl(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	c := command{name: r.FormValue("command"), reply: make(chan error, 1)}
+	select {
+	case s.commands <- c:
+	case <-s.done:
+		http.Error(w, "shutting down", http.StatusServiceUnavailable)
+		return
+	case <-r.Context().Done():
+		return
+	}
+	if err := <-c.reply; err != nil {
+		status := http.StatusBadRequest
+		if c.name == "reset" {
+			status = http.StatusInternalServerError
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	s.handleState(w, r)
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// newBlinkerServer returns a running server of a blinker, which stops when the test ends.
+func newBlinkerServer(t *testing.T) *httptest.Server {
+	s, err := newServer(func() (*life.Game, error) {
+		f := life.NewField(5, 5, false)
+		f.Set(1, 2, true)
+		f.Set(2, 2, true)
+		f.Set(3, 2, true)
+		return life.NewGameFromField(f), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.run(ctx, time.Hour)
+		close(done)
+	}()
+	ts := httptest.NewServer(s)
+	t.Cleanup(func() {
+		s.close()
+		ts.Close()
+		cancel()
+		<-done
+	})
+	return ts
+}
+
+func control(t *testing.T, ts *httptest.Server, command string) frame {
+	t.Helper()
+	resp, err := http.PostForm(ts.URL+"/control", url.Values{"command": {command}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("%s: %s", command, resp.Status)
+	}
+	var f frame
+	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestServeState(t *testing.T) {
+	ts := newBlinkerServer(t)
+	resp, err := http.Get(ts.URL + "/state")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var f frame
+	if err := json.NewDecoder(resp.Body).Decode(&f); err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]uint{{1, 2}, {2, 2}, {3, 2}}
+	if f.Generation != 0 || f.Width != 5 || f.Height != 5 || f.Population != 3 || len(f.Cells) != len(want) {
+		t.Fatalf("got %+v", f)
+	}
+	for i, c := range want {
+		if f.Cells[i] != c {
+			t.Errorf("cell %d: got %v, wanted %v", i, f.Cells[i], c)
+		}
+	}
+
+	if f := control(t, ts, "pause"); !f.Paused {
+		t.Errorf("pause: not paused")
+	}
+	if f := control(t, ts, "step"); f.Generation != 1 || f.Cells[0] != [2]uint{2, 1} {
+		t.Errorf("step: got %+v", f)
+	}
+	if f := control(t, ts, "reset"); f.Generation != 0 || !f.Paused {
+		t.Errorf("reset: got %+v", f)
+	}
+	resp, err = http.PostForm(ts.URL+"/control", url.Values{"command": {"rewind"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("rewind: got %s", resp.Status)
+	}
+}
+
+func TestServeStream(t *testing.T) {
+	ts := newBlinkerServer(t)
+	resp, err := http.Get(ts.URL + "/stream?diff=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	events := bufio.NewScanner(resp.Body)
+	// next returns the event and data of the next event on the stream.
+	next := func() (string, string) {
+		t.Helper()
+		var event, data string
+		for events.Scan() {
+			line := events.Text()
+			switch {
+			case line == "":
+				return event, data
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		t.Fatalf("stream ended: %v", events.Err())
+		return "", ""
+	}
+	if event, _ := next(); event != "frame" {
+		t.Fatalf("got %s event first, wanted frame", event)
+	}
+	control(t, ts, "step")
+	event, data := next()
+	if event != "diff" {
+		t.Fatalf("got %s event after step, wanted diff", event)
+	}
+	var d diffFrame
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Generation != 1 || len(d.Born) != 2 || len(d.Died) != 2 {
+		t.Errorf("got %+v, wanted 2 cells born and 2 dead in generation 1", d)
+	}
+}
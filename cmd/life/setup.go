@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/418Coffee/life"
+	"golang.org/x/term"
+)
+
+// gameSetup creates the initial state of a run as the flags ask for, again on every reset.
+type gameSetup struct {
+	rule life.Rule
+	// ages is set if the ages of the cells are tracked for the renderer.
+	ages bool
+	// stdin holds the pattern read from standard input, which can only be read once, for resets.
+	stdin []byte
+	// loadOpts put the pattern of -file on the field of -grid.
+	loadOpts []life.Option
+	// composed holds the patterns placed with -place or -pattern, which replace the random initial state.
+	composed *life.Field
+	// edited holds the pattern drawn in the editor, which replaces the random or loaded initial state.
+	edited *life.Field
+	// fitWidth and fitHeight are the size of the field that fills the terminal, with -fit.
+	fitWidth, fitHeight uint
+}
+
+// newGameSetup reads the pattern and works out the size of the field that the flags give, and exits with the usage
+// if they don't go together. The width and height are set, to fitWidth and fitHeight with -fit.
+func newGameSetup(rule life.Rule, ages bool, fitWidth, fitHeight uint) (*gameSetup, error) {
+	s := &gameSetup{rule: rule, ages: ages, fitWidth: fitWidth, fitHeight: fitHeight}
+	// Without a file or dimensions, a pattern piped into the command is read.
+	if rleFile == "" && patternName == "" && len(flag.Args()) == 0 && grid == "" && len(places) == 0 && fit == fitNone && !term.IsTerminal(int(os.Stdin.Fd())) {
+		rleFile = "-"
+	}
+	if rleFile == "-" {
+		var err error
+		if s.stdin, err = io.ReadAll(os.Stdin); err != nil {
+			printUsageAndExit(err)
+		}
+	}
+	if rleFile != "" && len(places) > 0 {
+		printUsageAndExit(fmt.Errorf("-place can't be combined with -file"))
+	}
+	if patternName != "" && (rleFile != "" || len(places) > 0) {
+		printUsageAndExit(fmt.Errorf("-pattern can't be combined with -file or -place"))
+	}
+	if lexiconFile != "" && patternName == "" {
+		printUsageAndExit(fmt.Errorf("-lexicon requires -pattern"))
+	}
+	// gridAt is the position of the pattern of -file on the field of -grid, nil for the middle.
+	var gridAt *life.Cell
+	if rleFile == "" && fit != fitNone {
+		width, height = s.fitWidth, s.fitHeight
+	} else if rleFile == "" || grid != "" {
+		args := flag.Args()
+		if grid != "" {
+			size := grid
+			if at := strings.IndexByte(grid, '@'); at >= 0 {
+				if rleFile == "" {
+					printUsageAndExit(fmt.Errorf("-grid WIDTHxHEIGHT@X,Y gives the position of the pattern of -file, which it requires"))
+				}
+				pos := grid[at+1:]
+				comma := strings.IndexByte(pos, ',')
+				if comma < 0 {
+					printUsageAndExit(fmt.Errorf("-grid: the position %q isn't of the form x,y", pos))
+				}
+				x, errX := strconv.ParseUint(pos[:comma], 10, strconv.IntSize)
+				y, errY := strconv.ParseUint(pos[comma+1:], 10, strconv.IntSize)
+				if errX != nil || errY != nil {
+					printUsageAndExit(fmt.Errorf("-grid: the position %q isn't of the form x,y", pos))
+				}
+				size, gridAt = grid[:at], &life.Cell{X: uint(x), Y: uint(y)}
+			}
+			x := strings.IndexByte(size, 'x')
+			if x < 0 || len(args) != 0 {
+				printUsageAndExit(fmt.Errorf("-grid must be of the form WIDTHxHEIGHT and replaces the width and height arguments"))
+			}
+			args = []string{size[:x], size[x+1:]}
+		}
+		if len(args) != 2 {
+			printUsageAndExit(nil)
+		}
+		w, err := strconv.ParseUint(args[0], 0, strconv.IntSize)
+		if err != nil {
+			printUsageAndExit(err)
+		}
+		h, err := strconv.ParseUint(args[1], 0, strconv.IntSize)
+		if err != nil {
+			printUsageAndExit(err)
+		}
+		width, height = uint(w), uint(h)
+		if err := life.CheckSize(width, height); err != nil {
+			printUsageAndExit(err)
+		}
+	}
+	if rleFile != "" && grid != "" {
+		s.loadOpts = append(s.loadOpts, life.WithGrid(width, height))
+		if gridAt != nil {
+			s.loadOpts = append(s.loadOpts, life.WithOffset(gridAt.X, gridAt.Y))
+		}
+	}
+	if len(places) > 0 {
+		var err error
+		if s.composed, err = compose(places, width, height, !nowrap, !noOverlap); err != nil {
+			return nil, err
+		}
+	}
+	if patternName != "" {
+		p, err := findPattern(patternName, lexiconFile)
+		if err == nil {
+			s.composed, err = centred(p, patternName, width, height, !nowrap)
+		}
+		if err != nil {
+			printUsageAndExit(fmt.Errorf("-pattern: %w", err))
+		}
+	}
+	return s, nil
+}
+
+// newGame creates the initial state.
+func (s *gameSetup) newGame() (*life.Game, error) {
+	var l *life.Game
+	switch {
+	case s.edited != nil:
+		l = life.NewGameFromField(s.edited.Clone())
+	case s.composed != nil:
+		l = life.NewGameFromField(s.composed.Clone())
+	case rleFile == "-":
+		// Standard input has no extension to tell the format by, RLE is assumed.
+		var err error
+		if l, err = life.ReadGame(bytes.NewReader(s.stdin), !nowrap, s.loadOpts...); err != nil {
+			return nil, err
+		}
+	case rleFile != "":
+		var err error
+		if l, err = life.LoadGame(rleFile, !nowrap, s.loadOpts...); err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		r := rand.New(rand.NewSource(seed))
+		if l, err = life.New(width, height, life.WithWrap(!nowrap), life.WithDensity(density), life.WithRand(r)); err != nil {
+			return nil, err
+		}
+	}
+	l.SetRule(s.rule)
+	e, err := life.NewEngine(engine)
+	if err != nil {
+		return nil, err
+	}
+	l.SetEngine(e)
+	if noise > 0 {
+		// The noise is drawn from another source than the initial state, so that they don't repeat each other.
+		if err := l.SetPerturbation(noise, rand.New(rand.NewSource(^seed))); err != nil {
+			return nil, err
+		}
+	}
+	l.TrackAges(s.ages)
+	l.TrackHeat(heatmap || heatmapOut != "")
+	// With -fit=resize, loaded and placed patterns are put on a field that fills the terminal as well.
+	if fit == fitResize && (l.Field().Width() != s.fitWidth || l.Field().Height() != s.fitHeight) {
+		l.Resize(s.fitWidth, s.fitHeight)
+	}
+	return l, nil
+}
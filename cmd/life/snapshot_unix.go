@@ -0,0 +1,16 @@
+//go:build !windows && !plan9 && !js
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// snapshotSignals returns a channel that receives SIGUSR1, which requests a snapshot of the current generation.
+func snapshotSignals() <-chan os.Signal {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGUSR1)
+	return c
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// snapshotSignals returns nil, Windows has no signal to request a snapshot with.
+func snapshotSignals() <-chan os.Signal {
+	return nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// soupResult is how a single soup ended.
+type soupResult struct {
+	Seed int64 `json:"seed"`
+	// Generations is the generation the soup settled at, or gave up at if it didn't.
+	Generations  uint           `json:"generations"`
+	Stabilized   bool           `json:"stabilized"`
+	Unidentified map[string]int `json:"unidentified,omitempty"`
+
+	objects map[string]int
+}
+
+// soupSummary is the aggregated census of the soups printed by the soup subcommand.
+type soupSummary struct {
+	Soups        int            `json:"soups"`
+	Size         uint           `json:"size"`
+	Seed         int64          `json:"seed"`
+	Density      float64        `json:"density"`
+	Objects      map[string]int `json:"objects"`
+	Unidentified map[string]int `json:"unidentified"`
+	// Longest are the soups that took longest to settle, Unstable the ones that didn't and Strange the ones that
+	// left unidentified objects.
+	Longest  []soupResult `json:"longest"`
+	Unstable []soupResult `json:"unstable"`
+	Strange  []soupResult `json:"strange"`
+}
+
+// soup runs the soup subcommand with the given arguments. It runs random soups until they settle and counts the
+// objects they leave behind.
+func soup(args []string) error {
+	fs := flag.NewFlagSet("soup", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s soup [options]\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	soups := fs.Int("count", 100, "number of soups to run")
+	size := fs.Uint("size", 64, "width and height of the toroidal field of every soup")
+	seed := fs.Int64("seed", 1, "seed of the first soup, the following soups have the seeds after it")
+	density := fs.Float64("density", life.DefaultDensity, "probability of a cell being alive in a soup")
+	maxTicks := fs.Uint("max", 10000, "the most generations to run a soup for before giving up on it settling")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of soups run at the same time")
+	engine := fs.String("engine", "naive", "how generations are computed: "+strings.Join(life.EngineNames(), ", "))
+	outliers := fs.Int("longest", 5, "number of the longest-lived soups to list")
+	save := fs.String("save", "", "directory to write the longest-lived, unsettled and strange soups to as .rle")
+	asJSON := fs.Bool("json", false, "print the summary as JSON")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *soups < 1 || *size == 0 || *workers < 1 {
+		return fmt.Errorf("-count, -size and -workers must be positive")
+	}
+	if err := life.CheckSize(*size, *size); err != nil {
+		return fmt.Errorf("-size: %w", err)
+	}
+	if *density < 0 || *density > 1 {
+		return fmt.Errorf("-density must be between 0 and 1")
+	}
+	if _, err := life.NewEngine(*engine); err != nil {
+		return err
+	}
+
+	// Every soup has a seed of its own, rather than every worker a random source of its own, so that the results
+	// don't depend on which worker ran which soup, and any soup can be looked at again with life -seed.
+	newSoup := func(i int) (int64, *life.Field) {
+		s := *seed + int64(i)
+		f := life.NewField(*size, *size, true)
+		f.Randomize(*density, rand.New(rand.NewSource(s)))
+		return s, f
+	}
+	jobs := make(chan int)
+	results := make(chan soupResult)
+	for w := 0; w < *workers; w++ {
+		go func() {
+			for i := range jobs {
+				s, f := newSoup(i)
+				e, _ := life.NewEngine(*engine)
+				g := life.NewGameFromField(f).WithEngine(e)
+				r := soupResult{Seed: s, Generations: *maxTicks}
+				if st := settle(g, *maxTicks, nil); st.s != life.Unsettled {
+					r.Stabilized, r.Generations = true, st.since
+				}
+				// A soup that is still changing has no objects to count yet.
+				if r.Stabilized {
+					c := life.TakeCensus(g.Field())
+					r.objects = c.Objects
+					if len(c.Unidentified) > 0 {
+						r.Unidentified = c.Unidentified
+					}
+				}
+				results <- r
+			}
+		}()
+	}
+	go func() {
+		for i := 0; i < *soups; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	sum := soupSummary{Soups: *soups, Size: *size, Seed: *seed, Density: *density,
+		Objects: make(map[string]int), Unidentified: make(map[string]int)}
+	var all []soupResult
+	progress := time.NewTicker(time.Second)
+	defer progress.Stop()
+	for done := 0; done < *soups; {
+		select {
+		case r := <-results:
+			done++
+			all = append(all, r)
+			for name, n := range r.objects {
+				sum.Objects[name] += n
+			}
+			for code, n := range r.Unidentified {
+				sum.Unidentified[code] += n
+			}
+		case <-progress.C:
+			fmt.Fprintf(os.Stderr, "%d of %d soups done\n", done, *soups)
+		}
+	}
+
+	// The soups finish in any order, so they are sorted to make the summary the same every time.
+	sort.Slice(all, func(i, j int) bool { return all[i].Seed < all[j].Seed })
+	for _, r := range all {
+		if !r.Stabilized {
+			sum.Unstable = append(sum.Unstable, r)
+		}
+		if r.Unidentified != nil {
+			sum.Strange = append(sum.Strange, r)
+		}
+	}
+	var settled []soupResult
+	for _, r := range all {
+		if r.Stabilized {
+			settled = append(settled, r)
+		}
+	}
+	sort.SliceStable(settled, func(i, j int) bool { return settled[i].Generations > settled[j].Generations })
+	if len(settled) > *outliers {
+		settled = settled[:*outliers]
+	}
+	sum.Longest = settled
+
+	if *save != "" {
+		for _, list := range [][]soupResult{sum.Longest, sum.Unstable, sum.Strange} {
+			for _, r := range list {
+				s, f := newSoup(int(r.Seed - *seed))
+				name := filepath.Join(*save, fmt.Sprintf("soup-%d.rle", s))
+				if err := writeRLE(name, f, fmt.Sprintf("Soup with seed %d and density %g.", s, *density)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if *asJSON {
+		return json.NewEncoder(os.Stdout).Encode(sum)
+	}
+	return sum.print()
+}
+
+// print writes s as tables.
+func (s soupSummary) print() error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	total := 0
+	for _, n := range s.Objects {
+		total += n
+	}
+	for _, n := range s.Unidentified {
+		total += n
+	}
+	fmt.Fprintf(w, "%d soups of %dx%d with seeds %d to %d and density %g, %d objects\n\n",
+		s.Soups, s.Size, s.Size, s.Seed, s.Seed+int64(s.Soups)-1, s.Density, total)
+	fmt.Fprintln(w, "object\tcount\tshare")
+	for _, o := range sortedCounts(s.Objects) {
+		fmt.Fprintf(w, "%s\t%d\t%.2f%%\n", o.name, o.n, 100*float64(o.n)/float64(total))
+	}
+	for _, o := range sortedCounts(s.Unidentified) {
+		fmt.Fprintf(w, "unidentified %s\t%d\t%.2f%%\n", o.name, o.n, 100*float64(o.n)/float64(total))
+	}
+	if len(s.Longest) > 0 {
+		fmt.Fprintln(w, "\nlongest-lived soup\tsettled at")
+		for _, r := range s.Longest {
+			fmt.Fprintf(w, "seed %d\t%d\n", r.Seed, r.Generations)
+		}
+	}
+	if len(s.Unstable) > 0 {
+		fmt.Fprintln(w, "\nunsettled soup\tgave up at")
+		for _, r := range s.Unstable {
+			fmt.Fprintf(w, "seed %d\t%d\n", r.Seed, r.Generations)
+		}
+	}
+	if len(s.Strange) > 0 {
+		fmt.Fprintln(w, "\nstrange soup\tunidentified objects")
+		for _, r := range s.Strange {
+			var codes []string
+			for _, o := range sortedCounts(r.Unidentified) {
+				codes = append(codes, o.name)
+			}
+			fmt.Fprintf(w, "seed %d\t%s\n", r.Seed, strings.Join(codes, " "))
+		}
+	}
+	return w.Flush()
+}
+
+type tally struct {
+	name string
+	n    int
+}
+
+// sortedCounts returns the entries of m from the largest count to the smallest, and by name for equal counts.
+func sortedCounts(m map[string]int) []tally {
+	counts := make([]tally, 0, len(m))
+	for name, n := range m {
+		counts = append(counts, tally{name, n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].n > counts[j].n || counts[i].n == counts[j].n && counts[i].name < counts[j].name
+	})
+	return counts
+}
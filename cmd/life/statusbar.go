@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// statusFrames is the number of generations the frame rate of the status bar is averaged over.
+const statusFrames = 16
+
+// statusBar is the line below the field that shows how a run is going: the generation, the population, the cells
+// born and died in the last tick, the measured frame rate, the rule and the speed setting.
+type statusBar struct {
+	// shown holds the times the last statusFrames generations were drawn, in a ring that continues at next.
+	shown   [statusFrames]time.Time
+	n, next int
+}
+
+// tick records that a generation was drawn at t.
+func (s *statusBar) tick(t time.Time) {
+	s.shown[s.next] = t
+	s.next = (s.next + 1) % statusFrames
+	if s.n < statusFrames {
+		s.n++
+	}
+}
+
+// reset forgets the generations drawn so far, so that a pause doesn't drag down the frame rate after it.
+func (s *statusBar) reset() {
+	s.n = 0
+}
+
+// fps returns the number of generations drawn per second, on average over the last ones, or 0 if fewer than two
+// were drawn.
+func (s *statusBar) fps() float64 {
+	if s.n < 2 {
+		return 0
+	}
+	first := s.shown[(s.next+statusFrames-s.n)%statusFrames]
+	last := s.shown[(s.next+statusFrames-1)%statusFrames]
+	elapsed := last.Sub(first).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(s.n-1) / elapsed
+}
+
+// line returns the status bar of a generation with the statistics st and the given rule, with delay the time between
+// two generations that was set, 0 for as fast as possible. Like Game.Header, it leaves out the parts that don't fit
+// in max characters, unless max is 0.
+func (s *statusBar) line(st life.Stats, rule life.Rule, delay time.Duration, paused bool, max int) string {
+	parts := make([]string, 0, 6)
+	parts = append(parts,
+		"gen "+strconv.FormatUint(uint64(st.Generation), 10),
+		"pop "+strconv.FormatUint(uint64(st.Population), 10),
+		"+"+strconv.FormatUint(uint64(st.Births), 10)+" -"+strconv.FormatUint(uint64(st.Deaths), 10))
+	if fps := s.fps(); fps > 0 {
+		parts = append(parts, strconv.FormatFloat(fps, 'f', 1, 64)+" fps")
+	}
+	parts = append(parts, "rule "+rule.String())
+	switch {
+	case paused:
+		parts = append(parts, "paused")
+	case delay == 0:
+		parts = append(parts, "speed max")
+	default:
+		parts = append(parts, "speed "+strconv.FormatFloat(float64(time.Second)/float64(delay), 'g', 3, 64)+"/s")
+	}
+	line := parts[0]
+	for _, part := range parts[1:] {
+		if max != 0 && len(line)+2+len(part) > max {
+			break
+		}
+		line += "  " + part
+	}
+	if max != 0 && len(line) > max {
+		line = line[:max]
+	}
+	return line
+}
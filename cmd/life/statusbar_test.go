@@ -0,0 +1,69 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+func TestStatusBarFPS(t *testing.T) {
+	var s statusBar
+	start := time.Unix(0, 0)
+	if s.fps() != 0 {
+		t.Error("got a frame rate without generations")
+	}
+	// 10 generations per second, then 20 for the last statusFrames, which are all that count.
+	for i := 0; i < 10; i++ {
+		s.tick(start.Add(time.Duration(i) * 100 * time.Millisecond))
+	}
+	if got := s.fps(); math.Abs(got-10) > 1e-9 {
+		t.Errorf("got %v fps, wanted 10", got)
+	}
+	start = start.Add(time.Second)
+	for i := 0; i < statusFrames; i++ {
+		s.tick(start.Add(time.Duration(i) * 50 * time.Millisecond))
+	}
+	if got := s.fps(); math.Abs(got-20) > 1e-9 {
+		t.Errorf("got %v fps, wanted 20", got)
+	}
+	s.reset()
+	if s.fps() != 0 {
+		t.Error("got a frame rate after a reset")
+	}
+}
+
+func TestStatusBarLine(t *testing.T) {
+	// A blinker turns over with 2 births and 2 deaths.
+	f := life.NewField(10, 10, true)
+	f.Set(1, 0, true)
+	f.Set(1, 1, true)
+	f.Set(1, 2, true)
+	l := life.NewGameFromField(f)
+	l.Tick()
+	var s statusBar
+	for _, tt := range []struct {
+		delay  time.Duration
+		paused bool
+		max    int
+		want   string
+	}{
+		{time.Second / 30, false, 0, "gen 1  pop 3  +2 -2  rule B3/S23  speed 30/s"},
+		{0, false, 0, "gen 1  pop 3  +2 -2  rule B3/S23  speed max"},
+		{time.Second, true, 0, "gen 1  pop 3  +2 -2  rule B3/S23  paused"},
+		// Parts that don't fit are left out, and the first is cut.
+		{time.Second, false, 25, "gen 1  pop 3  +2 -2"},
+		{time.Second, false, 3, "gen"},
+	} {
+		if got := s.line(l.Stats(), l.Rule(), tt.delay, tt.paused, tt.max); got != tt.want {
+			t.Errorf("got %q, wanted %q", got, tt.want)
+		}
+	}
+	s.tick(time.Unix(0, 0))
+	s.tick(time.Unix(0, int64(time.Second/4)))
+	if got := s.line(l.Stats(), l.Rule(), time.Second/4, false, 0); !strings.Contains(got, "  4.0 fps  ") {
+		t.Errorf("%q doesn't hold the frame rate", got)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// telnetWriteTimeout is how long a client may take to receive a frame before it is dropped.
+const telnetWriteTimeout = 5 * time.Second
+
+// serveTelnet runs the serve-telnet subcommand with the given arguments. It runs a game and streams it, drawn with
+// ANSI escape sequences, to everyone who connects over TCP, e.g. with nc or telnet, until interrupted.
+func serveTelnet(args []string) error {
+	fs := flag.NewFlagSet("serve-telnet", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s serve-telnet [options] [width height]\n"+
+			"Streams the run to everyone who connects, e.g. with nc localhost 2323. q and Enter leave.\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "localhost:2323", "address to listen on")
+	maxClients := fs.Int("max-clients", 64, "the most clients watching at the same time, others are turned away")
+	opts := addGameOptions(fs, 10)
+	fs.Parse(args)
+	if *maxClients < 1 {
+		return fmt.Errorf("-max-clients must be positive")
+	}
+	newGame, err := opts.newGame(fs)
+	if err != nil {
+		return err
+	}
+	s, err := newServer(newGame)
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	simulated := make(chan struct{})
+	go func() {
+		s.run(ctx, opts.delay())
+		close(simulated)
+	}()
+	t := &telnetServer{server: s, max: *maxClients}
+	failed := make(chan error, 1)
+	go func() {
+		failed <- t.serve(ln)
+	}()
+	opts.printSeed()
+	fmt.Fprintf(os.Stderr, "serving on %s\n", ln.Addr())
+	select {
+	case err = <-failed:
+		stop()
+	case <-ctx.Done():
+		ln.Close()
+		err = <-failed
+	}
+	// The clients are told that the server shuts down and waited for, so that they are left with a usable terminal.
+	s.close()
+	t.clients.Wait()
+	<-simulated
+	return err
+}
+
+// telnetServer streams the frames of a server to TCP clients, drawn with a DiffRenderer for each of them so that only
+// the changed cells are sent.
+type telnetServer struct {
+	*server
+	max int
+
+	mu      sync.Mutex
+	watched int
+	clients sync.WaitGroup
+}
+
+// serve accepts clients on ln until it is closed, and turns away those beyond the limit.
+func (t *telnetServer) serve(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if !t.join() {
+			conn.SetWriteDeadline(time.Now().Add(telnetWriteTimeout))
+			fmt.Fprintf(conn, "Sorry, all %d places are taken, try again later.\r\n", t.max)
+			conn.Close()
+			continue
+		}
+		t.clients.Add(1)
+		go func() {
+			defer t.clients.Done()
+			defer t.leave()
+			t.stream(conn)
+		}()
+	}
+}
+
+// join counts a new client in, unless there are as many as allowed already.
+func (t *telnetServer) join() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.watched >= t.max {
+		return false
+	}
+	t.watched++
+	return true
+}
+
+func (t *telnetServer) leave() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.watched--
+}
+
+// stream draws the frames on conn until the client leaves with q, Ctrl-C or Ctrl-D, falls too far behind or the
+// server shuts down. A client that doesn't take the frames as fast as they come skips some, like the streams of the
+// HTTP server, and one that doesn't take a frame within telnetWriteTimeout is dropped.
+func (t *telnetServer) stream(conn net.Conn) {
+	defer conn.Close()
+	frames := t.subscribe()
+	defer t.unsubscribe(frames)
+	quit := make(chan struct{})
+	go func() {
+		defer close(quit)
+		buf := make([]byte, 64)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			for _, b := range buf[:n] {
+				if b == 'q' || b == 'Q' || b == 3 || b == 4 {
+					return
+				}
+			}
+		}
+	}()
+	// Terminals expect "\r\n" from the network, as they do in raw mode.
+	w := bufio.NewWriterSize(&crlfWriter{w: conn}, 1<<16)
+	d := life.NewDiffRenderer()
+	d.Top = 1
+	bye := ""
+	defer func() {
+		conn.SetWriteDeadline(time.Now().Add(time.Second))
+		d.Close(w)
+		w.WriteString(bye)
+		w.Flush()
+	}()
+	var f *life.Field
+	var prev *frame
+	for {
+		var fr *frame
+		select {
+		case <-quit:
+			return
+		case <-t.done:
+			bye = "The server is shutting down, bye.\n"
+			return
+		case fr = <-frames:
+		}
+		// The field is updated from the cells of the previous frame rather than built anew for every frame.
+		if f == nil || f.Width() != fr.Width || f.Height() != fr.Height {
+			f = life.NewField(fr.Width, fr.Height, false)
+		} else {
+			for _, c := range prev.Cells {
+				f.Set(c[0], c[1], false)
+			}
+		}
+		for _, c := range fr.Cells {
+			f.Set(c[0], c[1], true)
+		}
+		prev = fr
+		conn.SetWriteDeadline(time.Now().Add(telnetWriteTimeout))
+		d.Render(w, f)
+		fmt.Fprintf(w, "%sgen %d  pop %d  q and Enter to leave%s\x1b[%d;1H", life.CursorHome, fr.Generation,
+			fr.Population, life.ClearLine, d.Top+fr.Height+1)
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
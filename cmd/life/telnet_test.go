@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// newTelnetServer returns the address of a telnet server of a blinker for at most max clients, which stops when
+// the test ends. Its game isn't run, so the clients only get its first frame.
+func newTelnetServer(t *testing.T, max int) string {
+	s, err := newServer(func() (*life.Game, error) {
+		f := life.NewField(5, 5, false)
+		f.Set(1, 2, true)
+		f.Set(2, 2, true)
+		f.Set(3, 2, true)
+		return life.NewGameFromField(f), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := &telnetServer{server: s, max: max}
+	done := make(chan error, 1)
+	go func() {
+		done <- ts.serve(ln)
+	}()
+	t.Cleanup(func() {
+		ln.Close()
+		if err := <-done; err != nil {
+			t.Error(err)
+		}
+		s.close()
+		ts.clients.Wait()
+	})
+	return ln.Addr().String()
+}
+
+// readUntil reads from conn until it has read s, and returns what it read.
+func readUntil(t *testing.T, conn net.Conn, s string) string {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var b strings.Builder
+	r := bufio.NewReader(conn)
+	for !strings.Contains(b.String(), s) {
+		c, err := r.ReadByte()
+		if err != nil {
+			t.Fatalf("%v after %q", err, b.String())
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func TestTelnetServer(t *testing.T) {
+	addr := newTelnetServer(t, 1)
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	got := readUntil(t, conn, "q and Enter to leave")
+	if !strings.Contains(got, "gen 0  pop 3") || !strings.Contains(got, "███") {
+		t.Errorf("the first frame %q doesn't show the blinker", got)
+	}
+
+	// The second client is one too many.
+	other, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	readUntil(t, other, "Sorry, all 1 places are taken")
+
+	conn.Write([]byte("q\r\n"))
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	rest, err := io.ReadAll(conn)
+	if err != nil {
+		t.Fatalf("the server didn't disconnect the client: %v", err)
+	}
+	// The cursor is shown again.
+	if !strings.HasSuffix(string(rest), "\x1b[?25h") {
+		t.Errorf("the client was left with %q", rest)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/418Coffee/life"
+)
+
+// contestant is how the random field of a single seed did in a tournament.
+type contestant struct {
+	Seed int64 `json:"seed"`
+	// Lifespan is the generation the field settled at, or the number of generations it ran for if it didn't.
+	Lifespan   uint   `json:"lifespan"`
+	Stabilized bool   `json:"stabilized"`
+	Stability  string `json:"stability"`
+	Peak       uint   `json:"peak_population"`
+	Final      uint   `json:"final_population"`
+}
+
+// tournamentScores are the scores contestants can be ranked by, with what they rank them by.
+var tournamentScores = map[string]func(c contestant) uint{
+	"lifespan": func(c contestant) uint { return c.Lifespan },
+	"peak":     func(c contestant) uint { return c.Peak },
+	"final":    func(c contestant) uint { return c.Final },
+}
+
+// tournamentSummary is the leaderboard printed by the tournament subcommand.
+type tournamentSummary struct {
+	Seeds   int     `json:"seeds"`
+	Width   uint    `json:"width"`
+	Height  uint    `json:"height"`
+	Seed    int64   `json:"seed"`
+	Density float64 `json:"density"`
+	Rule    string  `json:"rule"`
+	Ticks   uint    `json:"ticks"`
+	Score   string  `json:"score"`
+	// Leaders are the best contestants, best first.
+	Leaders []contestant `json:"leaders"`
+}
+
+// tournament runs the tournament subcommand with the given arguments. It runs the random fields of many seeds and
+// ranks the seeds, to find the fields that are most fun to watch.
+func tournament(args []string) error {
+	fs := flag.NewFlagSet("tournament", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s tournament [options]\noptions:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	seeds := fs.Int("n", 64, "number of seeds to run")
+	size := fs.String("size", "80x60", "size of the field of every seed as WIDTHxHEIGHT")
+	seed := fs.Int64("seed", 1, "the first seed, the following ones come after it")
+	density := fs.Float64("density", life.DefaultDensity, "probability of a cell being alive in a random field")
+	ticks := fs.Uint("ticks", 2000, "the most generations to run every seed for")
+	nowrap := fs.Bool("nowrap", false, "don't wrap the fields toroidally")
+	ruleString := fs.String("rule", "B3/S23", "rule in B/S notation, e.g. B36/S23")
+	engine := fs.String("engine", "naive", "how generations are computed: "+strings.Join(life.EngineNames(), ", "))
+	workers := fs.Int("workers", runtime.NumCPU(), "number of seeds run at the same time")
+	top := fs.Int("top", 10, "number of seeds on the leaderboard")
+	score := fs.String("score", "lifespan", "what seeds are ranked by: lifespan (generations before settling), peak or final population")
+	asJSON := fs.Bool("json", false, "print the leaderboard as JSON")
+	fs.Parse(args)
+	if fs.NArg() != 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if *seeds < 1 || *workers < 1 || *top < 1 {
+		return fmt.Errorf("-n, -workers and -top must be positive")
+	}
+	if *density < 0 || *density > 1 {
+		return fmt.Errorf("-density must be between 0 and 1")
+	}
+	width, height, err := parseSize(*size)
+	if err != nil {
+		return fmt.Errorf("-size: %w", err)
+	}
+	rule, err := life.ParseRule(*ruleString)
+	if err != nil {
+		return err
+	}
+	if _, err := life.NewEngine(*engine); err != nil {
+		return err
+	}
+	if _, ok := tournamentScores[*score]; !ok {
+		return fmt.Errorf("unknown score %q, expected lifespan, peak or final", *score)
+	}
+
+	// Every seed gives the same field as life -seed with the same size, so that the winners can be watched.
+	play := func(s int64) contestant {
+		// The size and density were checked above, so there is no error.
+		l, _ := life.New(width, height, life.WithWrap(!*nowrap), life.WithDensity(*density), life.WithRand(rand.New(rand.NewSource(s))))
+		e, _ := life.NewEngine(*engine)
+		l.SetRule(rule)
+		l.SetEngine(e)
+		return newContestant(s, l, *ticks)
+	}
+	jobs := make(chan int64)
+	results := make(chan contestant)
+	for w := 0; w < *workers; w++ {
+		go func() {
+			for s := range jobs {
+				results <- play(s)
+			}
+		}()
+	}
+	go func() {
+		for i := 0; i < *seeds; i++ {
+			jobs <- *seed + int64(i)
+		}
+		close(jobs)
+	}()
+
+	sum := tournamentSummary{Seeds: *seeds, Width: width, Height: height, Seed: *seed, Density: *density,
+		Rule: rule.String(), Ticks: *ticks, Score: *score}
+	progress := time.NewTicker(time.Second)
+	defer progress.Stop()
+	for done := 0; done < *seeds; {
+		select {
+		case c := <-results:
+			done++
+			// Only the leaders are kept, whichever order the seeds finish in.
+			sum.Leaders = rank(append(sum.Leaders, c), *score)
+			if len(sum.Leaders) > *top {
+				sum.Leaders = sum.Leaders[:*top]
+			}
+		case <-progress.C:
+			fmt.Fprintf(os.Stderr, "%d of %d seeds done\n", done, *seeds)
+		}
+	}
+
+	if *asJSON {
+		return json.NewEncoder(os.Stdout).Encode(sum)
+	}
+	return sum.print(*nowrap)
+}
+
+// newContestant runs l for up to ticks generations, or until it settles, and returns how the seed s did.
+func newContestant(s int64, l *life.Game, ticks uint) contestant {
+	c := contestant{Seed: s}
+	st := settle(l, ticks, func(l *life.Game) {
+		if p := l.Population(); p > c.Peak {
+			c.Peak = p
+		}
+	})
+	c.Stabilized, c.Stability, c.Final = st.s != life.Unsettled, st.s.String(), l.Population()
+	c.Lifespan = l.Generation()
+	if c.Stabilized {
+		c.Lifespan = st.since
+	}
+	return c
+}
+
+// rank sorts cs from the best score to the worst and returns it. Contestants with the same score are ranked by
+// their lifespan, then their peak population and then their seed, so that the ranking doesn't depend on the order
+// of cs.
+func rank(cs []contestant, score string) []contestant {
+	by := tournamentScores[score]
+	sort.Slice(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		switch {
+		case by(a) != by(b):
+			return by(a) > by(b)
+		case a.Lifespan != b.Lifespan:
+			return a.Lifespan > b.Lifespan
+		case a.Peak != b.Peak:
+			return a.Peak > b.Peak
+		}
+		return a.Seed < b.Seed
+	})
+	return cs
+}
+
+// print writes s as a table, followed by the command that shows the winner.
+func (s tournamentSummary) print(nowrap bool) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "%d fields of %dx%d with seeds %d to %d, density %g and rule %s, ranked by %s\n\n",
+		s.Seeds, s.Width, s.Height, s.Seed, s.Seed+int64(s.Seeds)-1, s.Density, s.Rule, s.Score)
+	fmt.Fprintln(w, "rank\tseed\tlifespan\tpeak\tfinal\tsettled into")
+	for i, c := range s.Leaders {
+		into := c.Stability
+		if !c.Stabilized {
+			into = "-"
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%s\n", i+1, c.Seed, c.Lifespan, c.Peak, c.Final, into)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	flags := fmt.Sprintf("-seed %d -ticks %d", s.Leaders[0].Seed, s.Ticks)
+	if s.Density != life.DefaultDensity {
+		flags += fmt.Sprintf(" -density %g", s.Density)
+	}
+	if s.Rule != "B3/S23" {
+		flags += " -rule " + s.Rule
+	}
+	if nowrap {
+		flags += " -nowrap"
+	}
+	_, err := fmt.Printf("\nwatch the winner with: %s %s %d %d\n", os.Args[0], flags, s.Width, s.Height)
+	return err
+}
+
+// parseSize parses a size of the form WIDTHxHEIGHT.
+func parseSize(s string) (width, height uint, err error) {
+	x := strings.IndexByte(s, 'x')
+	if x < 0 {
+		return 0, 0, fmt.Errorf("%q isn't of the form WIDTHxHEIGHT", s)
+	}
+	w, err := strconv.ParseUint(s[:x], 0, strconv.IntSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	h, err := strconv.ParseUint(s[x+1:], 0, strconv.IntSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := life.CheckSize(uint(w), uint(h)); err != nil {
+		return 0, 0, err
+	}
+	return uint(w), uint(h), nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestNewContestant(t *testing.T) {
+	// A glider on an empty plane doesn't settle, it only moves.
+	f := life.NewField(20, 20, false)
+	for _, c := range []life.Cell{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}} {
+		f.Set(c.X, c.Y, true)
+	}
+	got := newContestant(7, life.NewGameFromField(f), 8)
+	want := contestant{Seed: 7, Lifespan: 8, Stability: "unsettled", Peak: 5, Final: 5}
+	if got != want {
+		t.Errorf("glider: got %+v, wanted %+v", got, want)
+	}
+
+	// A blinker next to a block settles right away, as a cycle of period 2.
+	f = life.NewField(10, 10, false)
+	for _, c := range []life.Cell{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 6, Y: 6}, {X: 7, Y: 6}, {X: 6, Y: 7}, {X: 7, Y: 7}} {
+		f.Set(c.X, c.Y, true)
+	}
+	got = newContestant(8, life.NewGameFromField(f), 100)
+	want = contestant{Seed: 8, Lifespan: 0, Stabilized: true, Stability: "cycle", Peak: 7, Final: 7}
+	if got != want {
+		t.Errorf("blinker and block: got %+v, wanted %+v", got, want)
+	}
+}
+
+func TestRank(t *testing.T) {
+	cs := []contestant{
+		{Seed: 1, Lifespan: 100, Peak: 50, Final: 10},
+		{Seed: 2, Lifespan: 300, Peak: 40, Final: 10},
+		{Seed: 3, Lifespan: 100, Peak: 60, Final: 30},
+		{Seed: 4, Lifespan: 100, Peak: 50, Final: 10},
+	}
+	for score, want := range map[string][]int64{
+		"lifespan": {2, 3, 1, 4},
+		"peak":     {3, 1, 4, 2},
+		"final":    {3, 2, 1, 4},
+	} {
+		// The ranking must not depend on the order the contestants finished in.
+		shuffled := append([]contestant(nil), cs...)
+		rand.New(rand.NewSource(1)).Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+		var got []int64
+		for _, c := range rank(shuffled, score) {
+			got = append(got, c.Seed)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("by %s: got seeds %v, wanted %v", score, got, want)
+		}
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	if w, h, err := parseSize("80x60"); err != nil || w != 80 || h != 60 {
+		t.Errorf("80x60: got %dx%d, %v", w, h, err)
+	}
+	for _, s := range []string{"80", "0x60", "80x", "ax60"} {
+		if _, _, err := parseSize(s); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+}
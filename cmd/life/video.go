@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/418Coffee/life"
+)
+
+// videoFormats are the formats of -video-format.
+var videoFormats = map[string]life.VideoFormat{
+	life.Y4M.String(): life.Y4M,
+	life.PPM.String(): life.PPM,
+}
+
+// videoFormatNames returns the names of the video formats in alphabetical order.
+func videoFormatNames() []string {
+	names := make([]string, 0, len(videoFormats))
+	for name := range videoFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// videoWriter writes every generation of a run as a frame of a video, for -video.
+type videoWriter struct {
+	r   *life.VideoRenderer
+	out *bufio.Writer
+	// file is closed when done, unless the frames go to standard output.
+	file *os.File
+}
+
+// createVideoWriter creates the named file, or uses standard output if name is -, to write the frames of r to.
+func createVideoWriter(name string, r *life.VideoRenderer) (*videoWriter, error) {
+	if name == "-" {
+		return newVideoWriter(os.Stdout, r), nil
+	}
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	v := newVideoWriter(file, r)
+	v.file = file
+	return v, nil
+}
+
+func newVideoWriter(w io.Writer, r *life.VideoRenderer) *videoWriter {
+	return &videoWriter{r: r, out: bufio.NewWriterSize(w, 1<<16)}
+}
+
+// write writes f as the next frame.
+func (v *videoWriter) write(f *life.Field) error {
+	return v.r.Render(v.out, f)
+}
+
+// close flushes the frames that are left and closes the file.
+func (v *videoWriter) close() error {
+	err := v.out.Flush()
+	if v.file != nil {
+		if cerr := v.file.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
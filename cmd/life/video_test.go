@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestVideoWriter(t *testing.T) {
+	l := life.NewGameFromField(life.NewField(12, 8, true))
+	var b bytes.Buffer
+	v := newVideoWriter(&b, &life.VideoRenderer{Format: life.PPM, CellSize: 2})
+	for l.Generation() < 10 {
+		if err := v.write(l.Field()); err != nil {
+			t.Fatal(err)
+		}
+		l.Tick()
+	}
+	// Nothing reaches the writer before the frames are flushed.
+	if b.Len() != 0 {
+		t.Errorf("%d bytes written before closing", b.Len())
+	}
+	if err := v.close(); err != nil {
+		t.Fatal(err)
+	}
+	frame := []byte("P6\n24 16\n255\n")
+	frame = append(frame, make([]byte, 3*24*16)...)
+	if want := bytes.Repeat(frame, 10); !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("got %d bytes, wanted 10 frames of %d", b.Len(), len(frame))
+	}
+}
@@ -0,0 +1,125 @@
+package life
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CSVOption changes how LoadGameFromCSV reads a matrix.
+type CSVOption int
+
+const (
+	// CSVHeader skips the first row, which holds the names of the columns rather than cells.
+	CSVHeader CSVOption = iota + 1
+	// CSVLenient takes every number other than 0 as a live cell, e.g. 2 or 0.5, instead of only accepting 0 and 1.
+	CSVLenient
+)
+
+// WriteCSV writes the cells of f as a matrix of 0 for dead and 1 for live cells, with a line for every row and the
+// cells separated by sep, e.g. ',' for CSV or '\t' for TSV. See LoadGameFromCSV to read it back.
+func (f *Field) WriteCSV(w io.Writer, sep rune) error {
+	if err := checkCSVSeparator(sep); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for _, row := range f.s {
+		for x, alive := range row {
+			if x > 0 {
+				bw.WriteRune(sep)
+			}
+			if alive {
+				bw.WriteByte('1')
+			} else {
+				bw.WriteByte('0')
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// LoadGameFromCSV reads a Life game state from a matrix of 0 for dead and 1 for live cells in r, with a line for
+// every row and the cells separated by sep, as written by Field.WriteCSV or exported from a spreadsheet. All rows
+// must have the same number of cells. Lines may end in "\r\n", empty lines are skipped, and values may be quoted.
+func LoadGameFromCSV(r io.Reader, sep rune, wrap bool, options ...CSVOption) (*Game, error) {
+	if err := checkCSVSeparator(sep); err != nil {
+		return nil, err
+	}
+	var header, lenient bool
+	for _, o := range options {
+		switch o {
+		case CSVHeader:
+			header = true
+		case CSVLenient:
+			lenient = true
+		default:
+			return nil, fmt.Errorf("unknown CSV option %d", o)
+		}
+	}
+	cr := csv.NewReader(r)
+	cr.Comma = sep
+	// The number of cells is checked below, with a clearer error.
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = sep != ' ' && sep != '\t'
+	cr.ReuseRecord = true
+	var rows [][]bool
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		if header {
+			header = false
+			continue
+		}
+		if len(rows) > 0 && len(record) != len(rows[0]) {
+			return nil, fmt.Errorf("line %d: got %d cells, wanted %d as in the first row", line, len(record), len(rows[0]))
+		}
+		row := make([]bool, len(record))
+		for x, value := range record {
+			value = strings.TrimSpace(value)
+			switch {
+			case value == "0" || value == "1":
+				row[x] = value == "1"
+			case lenient:
+				v, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d, column %d: %q isn't a number", line, x+1, value)
+				}
+				row[x] = v != 0
+			default:
+				return nil, fmt.Errorf("line %d, column %d: %q isn't 0 or 1", line, x+1, value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("the matrix has no rows")
+	}
+	f := NewField(uint(len(rows[0])), uint(len(rows)), wrap)
+	for y, row := range rows {
+		copy(f.s[y], row)
+	}
+	f.recount()
+	return NewGameFromField(f), nil
+}
+
+// checkCSVSeparator returns an error if sep can't separate the cells of a matrix.
+func checkCSVSeparator(sep rune) error {
+	switch {
+	case sep == '0' || sep == '1' || sep == '"' || sep == '\r' || sep == '\n':
+	case sep == utf8.RuneError || !utf8.ValidRune(sep):
+	default:
+		return nil
+	}
+	return fmt.Errorf("%q can't separate cells", sep)
+}
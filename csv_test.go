@@ -0,0 +1,93 @@
+package life
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	f := fieldFromRows(false,
+		".o.",
+		"o.o",
+	)
+	b := new(bytes.Buffer)
+	if err := f.WriteCSV(b, ','); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "0,1,0\n1,0,1\n"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	b.Reset()
+	if err := f.WriteCSV(b, '\t'); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "0\t1\t0\n1\t0\t1\n"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if err := f.WriteCSV(b, '1'); err == nil {
+		t.Error("expected an error for 1 as the separator")
+	}
+}
+
+func TestLoadGameFromCSV(t *testing.T) {
+	want := randomField(rand.New(rand.NewSource(5)), 17, 9, true)
+	for _, sep := range []rune{',', '\t', ';'} {
+		b := new(bytes.Buffer)
+		if err := want.WriteCSV(b, sep); err != nil {
+			t.Fatal(err)
+		}
+		written := b.String()
+		g, err := LoadGameFromCSV(b, sep, true)
+		if err != nil {
+			t.Fatalf("%q: %v", sep, err)
+		}
+		if !equalCells(g.Field(), want) || g.Population() != want.Population() {
+			t.Errorf("%q: got:\n%s\nwanted:\n%s", sep, g.Field(), want)
+		}
+		b.Reset()
+		g.Field().WriteCSV(b, sep)
+		if b.String() != written {
+			t.Errorf("%q: got %q written back, wanted %q", sep, b.String(), written)
+		}
+	}
+
+	blinker := fieldFromRows(false, "...", "ooo", "...")
+	for _, test := range []struct {
+		data    string
+		options []CSVOption
+	}{
+		{"0,0,0\r\n1,1,1\r\n0,0,0\r\n", nil},
+		{"0, 0, 0\n1, 1, \"1\"\n0, 0, 0\n\n\n", nil},
+		{"a,b,c\n0,0,0\n1,1,1\n0,0,0", []CSVOption{CSVHeader}},
+		{"0,0,0\n2,-1,0.5\n0,0.0,0\n", []CSVOption{CSVLenient}},
+		{"x,y,z\n0,0,0\n1,1,7\n0,0,0\n", []CSVOption{CSVLenient, CSVHeader}},
+	} {
+		g, err := LoadGameFromCSV(strings.NewReader(test.data), ',', false, test.options...)
+		if err != nil {
+			t.Errorf("%q: %v", test.data, err)
+			continue
+		}
+		if !equalCells(g.Field(), blinker) {
+			t.Errorf("%q: got:\n%s\nwanted:\n%s", test.data, g.Field(), blinker)
+		}
+	}
+
+	for _, test := range []struct {
+		data    string
+		options []CSVOption
+	}{
+		{"", nil},
+		{"a,b,c\n", []CSVOption{CSVHeader}},
+		{"0,1\n0,1,1\n", nil},
+		{"0,1\n0,2\n", nil},
+		{"0,1\n0,x\n", []CSVOption{CSVLenient}},
+		{"0,1\n0,\n", []CSVOption{CSVLenient}},
+		{"0,1\n", []CSVOption{0}},
+	} {
+		if _, err := LoadGameFromCSV(strings.NewReader(test.data), ',', false, test.options...); err == nil {
+			t.Errorf("%q: expected an error", test.data)
+		}
+	}
+}
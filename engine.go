@@ -0,0 +1,188 @@
+package life
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Engine computes the generations of a field.
+// Implementations may keep state between steps, so an engine must not be shared between games.
+type Engine interface {
+	// Step writes the generation following current into next.
+	// Both fields have the same dimensions, wrapping and rule.
+	Step(current, next *Field)
+}
+
+// SetEngine selects the engine that computes the generations of the game.
+// A nil engine selects the default NaiveEngine.
+func (g *Game) SetEngine(e Engine) {
+	g.engine = e
+}
+
+// WithEngine is like SetEngine but returns the game, so it can be chained onto NewGame or LoadGame.
+func (g *Game) WithEngine(e Engine) *Game {
+	g.SetEngine(e)
+	return g
+}
+
+// engines holds constructors rather than engines, because engines may keep state and must not be shared.
+var engines = map[string]func() Engine{
+	"bitpacked":   func() Engine { return new(BitPackedEngine) },
+	"hashlife":    func() Engine { return new(HashLifeEngine) },
+	"incremental": func() Engine { return new(IncrementalEngine) },
+	"lookup":      func() Engine { return new(LookupEngine) },
+	"naive":       func() Engine { return NaiveEngine{} },
+	"parallel":    func() Engine { return ParallelEngine{} },
+	"sparse":      func() Engine { return new(SparseEngine) },
+}
+
+// NewEngine returns a new engine of the kind registered under name.
+// An error is returned if no such engine exists.
+func NewEngine(name string) (Engine, error) {
+	e, ok := engines[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown engine %q (available: %s)", name, strings.Join(EngineNames(), ", "))
+	}
+	return e(), nil
+}
+
+// EngineNames returns the names of all registered engines in alphabetical order.
+func EngineNames() []string {
+	names := make([]string, 0, len(engines))
+	for name := range engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NaiveEngine computes every cell separately by counting its live neighbours.
+type NaiveEngine struct{}
+
+// Step writes the generation following current into next.
+func (NaiveEngine) Step(current, next *Field) {
+	var c changes
+	next.pop, c = stepRows(current, next, 0, current.height)
+	c.apply(current, next)
+}
+
+// stepRows writes the rows from up to to of the generation following current into next and returns their population
+// and the cells that changed in them.
+// Rather than wrapping every neighbour lookup, it sums the three cells above each other in every column into a
+// buffer with a one-cell border. The border holds the sums of the opposite edge if the field wraps, and zero
+// otherwise, so the inner loop indexes the buffer directly.
+func stepRows(current, next *Field, from, to uint) (pop uint, c changes) {
+	w := current.width
+	if w == 0 {
+		return 0, c
+	}
+	sums := make([]uint8, w+2)
+	dead := make([]bool, w)
+	for y := from; y < to; y++ {
+		above, below := neighbourRows(current, y, dead)
+		row := current.s[y]
+		for x, alive := range row {
+			var sum uint8
+			if above[x] {
+				sum++
+			}
+			if alive {
+				sum++
+			}
+			if below[x] {
+				sum++
+			}
+			sums[x+1] = sum
+		}
+		if current.wrap {
+			sums[0], sums[w+1] = sums[w], sums[1]
+		}
+		out := next.s[y]
+		for x, alive := range row {
+			n := sums[x] + sums[x+1] + sums[x+2]
+			if alive {
+				n--
+			}
+			out[x] = current.rule.Next(alive, n)
+			if out[x] {
+				pop++
+			}
+			if out[x] != alive {
+				c.flip(uint(x), y, out[x])
+			}
+		}
+	}
+	return pop, c
+}
+
+// neighbourRows returns the rows above and below row y of f. Rows past the edges wrap around if f wraps, and are
+// dead otherwise.
+func neighbourRows(f *Field, y uint, dead []bool) (above, below []bool) {
+	above, below = dead, dead
+	if y > 0 {
+		above = f.s[y-1]
+	} else if f.wrap {
+		above = f.s[f.height-1]
+	}
+	if y+1 < f.height {
+		below = f.s[y+1]
+	} else if f.wrap {
+		below = f.s[0]
+	}
+	return above, below
+}
+
+// ParallelEngine computes the rows of every generation concurrently, using the same computation as NaiveEngine. The rows of the field are divided evenly between the workers.
+type ParallelEngine struct {
+	// Workers is the number of goroutines computing rows. If zero, runtime.GOMAXPROCS(0) is used.
+	Workers int
+	// Threshold is the number of cells below which a generation is computed serially, because starting the
+	// workers would cost more than it saves. If zero, DefaultParallelThreshold is used.
+	Threshold uint
+}
+
+// DefaultParallelThreshold is the number of cells below which ParallelEngine computes generations serially by default.
+const DefaultParallelThreshold = 64 * 64
+
+// Step writes the generation following current into next.
+func (e ParallelEngine) Step(current, next *Field) {
+	workers := e.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	threshold := e.Threshold
+	if threshold == 0 {
+		threshold = DefaultParallelThreshold
+	}
+	if uint(workers) > current.height {
+		workers = int(current.height)
+	}
+	if workers <= 1 || current.width*current.height < threshold {
+		NaiveEngine{}.Step(current, next)
+		return
+	}
+	// Every worker only writes its own rows of next, its own population count and its own changes.
+	pops := make([]uint, workers)
+	changed := make([]changes, workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			from := current.height * uint(i) / uint(workers)
+			to := current.height * uint(i+1) / uint(workers)
+			pops[i], changed[i] = stepRows(current, next, from, to)
+		}(i)
+	}
+	wg.Wait()
+	next.pop = 0
+	var c changes
+	for i, pop := range pops {
+		next.pop += pop
+		c.add(changed[i])
+	}
+	c.apply(current, next)
+}
@@ -0,0 +1,370 @@
+package life
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestParallelEngine(t *testing.T) {
+	for _, workers := range []int{0, 2, 3, 7} {
+		t.Run(fmt.Sprint(workers, " workers"), func(t *testing.T) {
+			assertSameEngine(t, func() Engine { return ParallelEngine{Workers: workers, Threshold: 1} })
+		})
+	}
+}
+
+// referenceFuture is the original computation of Field.Future, which wraps every neighbour lookup with Field.Alive.
+func referenceFuture(f *Field, x, y uint) bool {
+	var n uint8
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if (dx != 0 || dy != 0) && f.Alive(int(x)+dx, int(y)+dy) {
+				n++
+			}
+		}
+	}
+	return f.rule.Next(f.Alive(int(x), int(y)), n)
+}
+
+func TestNaiveEngine(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, width := range []uint{1, 2, 3, 5, 64} {
+		for _, height := range []uint{1, 2, 3, 7} {
+			for _, wrap := range []bool{true, false} {
+				// Dense fields exercise the corners and edges the most.
+				for _, f := range []*Field{randomField(rng, width, height, wrap), fullField(width, height, wrap)} {
+					next := NewField(width, height, wrap)
+					NaiveEngine{}.Step(f, next)
+					var pop uint
+					for y := uint(0); y < height; y++ {
+						for x := uint(0); x < width; x++ {
+							want := referenceFuture(f, x, y)
+							if got := f.Future(x, y); got != want {
+								t.Fatalf("%dx%d wrap %t: Future(%d, %d) = %t, wanted %t in:\n%s", width, height, wrap, x, y, got, want, f)
+							}
+							if next.s[y][x] != want {
+								t.Fatalf("%dx%d wrap %t: cell %d,%d is %t, wanted %t in the generation after:\n%s", width, height, wrap, x, y, next.s[y][x], want, f)
+							}
+							if want {
+								pop++
+							}
+						}
+					}
+					if next.Population() != pop {
+						t.Fatalf("%dx%d wrap %t: got population %d, wanted %d", width, height, wrap, next.Population(), pop)
+					}
+				}
+			}
+		}
+	}
+}
+
+// fullField returns a field with every cell alive except for the main diagonal.
+func fullField(width, height uint, wrap bool) *Field {
+	f := NewField(width, height, wrap)
+	for y := uint(0); y < height; y++ {
+		for x := uint(0); x < width; x++ {
+			f.Set(x, y, x != y)
+		}
+	}
+	return f
+}
+
+func BenchmarkParallelEngine(b *testing.B) {
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprint(workers, " workers"), func(b *testing.B) {
+			g := &Game{current: randomField(rand.New(rand.NewSource(1)), 1024, 1024, true), next: NewField(1024, 1024, true), width: 1024, height: 1024}
+			g.SetEngine(ParallelEngine{Workers: workers})
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				g.Tick()
+			}
+		})
+	}
+}
+
+func TestIncrementalEngine(t *testing.T) {
+	assertSameEngine(t, func() Engine { return new(IncrementalEngine) })
+
+	// Modifying the field between ticks must be picked up.
+	rows := []string{
+		"........",
+		"..o.....",
+		"..o.....",
+		"..o.....",
+		"........",
+		"........",
+	}
+	want, got := gameFromRows(true, rows...), gameFromRows(true, rows...)
+	got.SetEngine(new(IncrementalEngine))
+	for gen := 1; gen <= 6; gen++ {
+		if gen == 3 {
+			for _, g := range []*Game{want, got} {
+				g.Field().Set(6, 4, true)
+				g.Field().Set(7, 4, true)
+				g.Field().Set(7, 5, true)
+			}
+		}
+		want.Tick()
+		got.Tick()
+		if !equalCells(got.Field(), want.Field()) {
+			t.Fatalf("generation %d: got:\n%s\nwanted:\n%s", gen, got.Field(), want.Field())
+		}
+	}
+}
+
+func BenchmarkIncrementalEngineGlider(b *testing.B) {
+	for _, name := range []string{"naive", "incremental"} {
+		b.Run(name, func(b *testing.B) {
+			g := &Game{current: NewField(256, 256, true), next: NewField(256, 256, true), width: 256, height: 256}
+			for _, c := range []Cell{{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}} {
+				g.current.Set(c.X, c.Y, true)
+			}
+			e := new(IncrementalEngine)
+			if name == "incremental" {
+				g.SetEngine(e)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				g.Tick()
+			}
+			evaluated := float64(b.N) * 256 * 256
+			if name == "incremental" {
+				evaluated = float64(e.Evaluated())
+			}
+			b.ReportMetric(evaluated/float64(b.N), "cells/op")
+		})
+	}
+}
+
+func BenchmarkNaiveEngine(b *testing.B) {
+	for _, size := range []uint{64, 256, 1024} {
+		for _, wrap := range []bool{true, false} {
+			b.Run(fmt.Sprintf("%dx%d wrap %t", size, size, wrap), func(b *testing.B) {
+				g := &Game{current: randomField(rand.New(rand.NewSource(1)), size, size, wrap), next: NewField(size, size, wrap), width: size, height: size}
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					g.Tick()
+				}
+			})
+		}
+	}
+}
+
+// conformance holds patterns and their expected states after a number of generations, which every registered
+// engine must reproduce.
+var conformance = []struct {
+	name        string
+	wrap        bool
+	rule        string
+	generations int
+	start, want []string
+}{
+	{
+		name: "blinker", generations: 1,
+		start: []string{
+			".....",
+			"..o..",
+			"..o..",
+			"..o..",
+			".....",
+		},
+		want: []string{
+			".....",
+			".....",
+			".ooo.",
+			".....",
+			".....",
+		},
+	},
+	{
+		name: "beacon", generations: 1,
+		start: []string{
+			"......",
+			".oo...",
+			".oo...",
+			"...oo.",
+			"...oo.",
+			"......",
+		},
+		want: []string{
+			"......",
+			".oo...",
+			".o....",
+			"....o.",
+			"...oo.",
+			"......",
+		},
+	},
+	{
+		name: "glider", generations: 4,
+		start: []string{
+			".o....",
+			"..o...",
+			"ooo...",
+			"......",
+			"......",
+			"......",
+		},
+		want: []string{
+			"......",
+			"..o...",
+			"...o..",
+			".ooo..",
+			"......",
+			"......",
+		},
+	},
+	{
+		name: "glider around a torus", wrap: true, generations: 32,
+		start: []string{
+			".o......",
+			"..o.....",
+			"ooo.....",
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+		},
+		want: []string{
+			".o......",
+			"..o.....",
+			"ooo.....",
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+		},
+	},
+	{
+		// The four corners form a block on a torus.
+		name: "block across the corners", wrap: true, generations: 10,
+		start: []string{
+			"o...o",
+			".....",
+			".....",
+			"o...o",
+		},
+		want: []string{
+			"o...o",
+			".....",
+			".....",
+			"o...o",
+		},
+	},
+	{
+		name: "isolated corners", generations: 1,
+		start: []string{
+			"o...o",
+			".....",
+			".....",
+			"o...o",
+		},
+		want: []string{
+			".....",
+			".....",
+			".....",
+			".....",
+		},
+	},
+	{
+		// A glider running into the edges of a plane turns into a block.
+		name: "glider hitting a corner", generations: 12,
+		start: []string{
+			"....",
+			".o..",
+			"..o.",
+			"ooo.",
+		},
+		want: []string{
+			"....",
+			"....",
+			".oo.",
+			".oo.",
+		},
+	},
+	{
+		// Under a B0 rule, dead cells without live neighbours come to life, but the plane around a field that
+		// doesn't wrap stays dead.
+		name: "B0 on a plane", rule: "B013/S0123", generations: 2,
+		start: []string{
+			".....",
+			"..o..",
+			"..o..",
+			"..o..",
+			".....",
+		},
+		want: []string{
+			"o.o.o",
+			".....",
+			"o...o",
+			".....",
+			"o.o.o",
+		},
+	},
+}
+
+func TestEngineConformance(t *testing.T) {
+	for _, name := range EngineNames() {
+		t.Run(name, func(t *testing.T) {
+			for _, test := range conformance {
+				e, err := NewEngine(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				start := fieldFromRows(test.wrap, test.start...)
+				if test.rule != "" {
+					if start.rule, err = ParseRule(test.rule); err != nil {
+						t.Fatal(err)
+					}
+				}
+				width, height := start.Width(), start.Height()
+				g := (&Game{current: start, next: NewField(width, height, test.wrap), width: width, height: height}).WithEngine(e)
+				for i := 0; i < test.generations; i++ {
+					g.Tick()
+				}
+				want := fieldFromRows(test.wrap, test.want...)
+				if !equalCells(g.Field(), want) || g.Population() != want.Population() {
+					t.Errorf("%s after %d generations: got:\n%s\nwanted:\n%s", test.name, test.generations, g.Field(), want)
+				}
+			}
+		})
+	}
+	if _, err := NewEngine("magic"); err == nil {
+		t.Error("expected an error for an unknown engine")
+	}
+}
+
+func TestEngineConformanceSetRule(t *testing.T) {
+	// Engines that keep state between steps must notice when the rule changes in the middle of a run.
+	rule, err := ParseRule("B36/S125")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range EngineNames() {
+		for _, wrap := range []bool{true, false} {
+			e, err := NewEngine(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			start := randomField(rand.New(rand.NewSource(3)), 40, 40, wrap)
+			g := NewGameFromField(start.Clone()).WithEngine(e)
+			naive := NewGameFromField(start)
+			for gen := 1; gen <= 80; gen++ {
+				if gen == 61 {
+					g.SetRule(rule)
+					naive.SetRule(rule)
+				}
+				g.Tick()
+				naive.Tick()
+				if !equalCells(g.Field(), naive.Field()) || g.Population() != naive.Population() {
+					t.Fatalf("%s, wrap %t: generation %d differs from the naive engine:\n%s\nwanted:\n%s",
+						name, wrap, gen, g.Field(), naive.Field())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package life
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter, so that the verbs give different views of the game:
+//
+//	%s   the field as String draws it, with the header if it is enabled
+//	%v   a line with the header, see Header, followed by the field
+//	%+v  like %v, followed by the statistics of the generation, see Stats
+//	%q   the field as a quoted run-length encoded pattern on a single line, e.g. for logs
+//	%#q  like %q, quoted with back quotes
+//
+// The width and precision crop the field to the given number of columns and rows from its top-left corner, e.g.
+// %20.10v draws at most 20x10 cells of a huge field. The header and the statistics are those of the whole game.
+// Other verbs format the game as if it didn't implement fmt.Formatter or fmt.Stringer.
+func (g *Game) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's', 'v', 'q':
+	default:
+		// The methods of the underlying type are dropped, so that fmt formats the fields.
+		type plain Game
+		fmt.Fprintf(s, formatDirective(s, verb), (*plain)(g))
+		return
+	}
+	f := g.current
+	width, cropWidth := s.Width()
+	height, cropHeight := s.Precision()
+	// Fields are at least 1x1, so a width or precision of 0 crops to a single column or row.
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	if (cropWidth && uint(width) < f.width) || (cropHeight && uint(height) < f.height) {
+		w, h := f.width, f.height
+		if cropWidth && uint(width) < w {
+			w = uint(width)
+		}
+		if cropHeight && uint(height) < h {
+			h = uint(height)
+		}
+		f = f.Resized(w, h)
+	}
+
+	var b []byte
+	switch {
+	case verb == 's':
+		if g.header {
+			b = append(b, g.Header(int(f.width))...)
+			b = append(b, '\n')
+		}
+		b = f.AppendTo(b)
+	case verb == 'v':
+		b = append(b, g.Header(0)...)
+		b = append(b, '\n')
+		b = f.AppendTo(b)
+		if s.Flag('+') {
+			st := g.Stats()
+			b = append(b, "births "...)
+			b = strconv.AppendUint(b, uint64(st.Births), 10)
+			b = append(b, "\ndeaths "...)
+			b = strconv.AppendUint(b, uint64(st.Deaths), 10)
+			b = append(b, "\ndensity "...)
+			b = strconv.AppendFloat(b, st.Density, 'f', 4, 64)
+			b = append(b, "\nbounding box area "...)
+			b = strconv.AppendUint(b, uint64(st.BoundingBoxArea), 10)
+			b = append(b, '\n')
+		}
+	case verb == 'q':
+		var sb strings.Builder
+		bw := bufio.NewWriter(&sb)
+		fmt.Fprintf(bw, "x = %d, y = %d, rule = %s ", f.width, f.height, rleRule(f))
+		e := rleEncoder{w: bw}
+		e.pattern(f)
+		bw.Flush()
+		if s.Flag('#') {
+			// RLE patterns hold neither back quotes nor control characters, so they can always be back quoted.
+			b = append(b, '`')
+			b = append(b, sb.String()...)
+			b = append(b, '`')
+		} else {
+			b = strconv.AppendQuote(b, sb.String())
+		}
+	}
+	s.Write(b)
+}
+
+// formatDirective rebuilds the directive that formats with verb from the flags, width and precision of s.
+func formatDirective(s fmt.State, verb rune) string {
+	d := []byte{'%'}
+	for _, flag := range "+-# 0" {
+		if s.Flag(int(flag)) {
+			d = append(d, byte(flag))
+		}
+	}
+	if width, ok := s.Width(); ok {
+		d = strconv.AppendInt(d, int64(width), 10)
+	}
+	if precision, ok := s.Precision(); ok {
+		d = append(d, '.')
+		d = strconv.AppendInt(d, int64(precision), 10)
+	}
+	return string(append(d, string(verb)...))
+}
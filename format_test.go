@@ -0,0 +1,86 @@
+package life
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestGameFormat(t *testing.T) {
+	g := gameFromRows(false,
+		".o...",
+		"..o..",
+		"ooo..",
+		".....",
+		".....",
+	)
+	g.Tick()
+	testCases := []struct {
+		format, want string
+	}{
+		{"%s", "     \n" +
+			"█ █  \n" +
+			" ██  \n" +
+			" █   \n" +
+			"     \n"},
+		{"%v", "gen 1  pop 5  rule B3/S23  5x5 plane\n" +
+			"     \n" +
+			"█ █  \n" +
+			" ██  \n" +
+			" █   \n" +
+			"     \n"},
+		{"%+v", "gen 1  pop 5  rule B3/S23  5x5 plane\n" +
+			"     \n" +
+			"█ █  \n" +
+			" ██  \n" +
+			" █   \n" +
+			"     \n" +
+			"births 2\n" +
+			"deaths 2\n" +
+			"density 0.2000\n" +
+			"bounding box area 9\n"},
+		{"%q", `"x = 5, y = 5, rule = B3/S23 $obo$b2o$bo!"`},
+		{"%#q", "`x = 5, y = 5, rule = B3/S23 $obo$b2o$bo!`"},
+		{"%2.3s", "  \n" +
+			"█ \n" +
+			" █\n"},
+		{"%.2v", "gen 1  pop 5  rule B3/S23  5x5 plane\n" +
+			"     \n" +
+			"█ █  \n"},
+		{"%3q", `"x = 3, y = 5, rule = B3/S23 $obo$b2o$bo!"`},
+		{"%9.9s", "     \n" +
+			"█ █  \n" +
+			" ██  \n" +
+			" █   \n" +
+			"     \n"},
+	}
+	for _, tc := range testCases {
+		if got := fmt.Sprintf(tc.format, g); got != tc.want {
+			t.Errorf("%s: got\n%s\nwanted\n%s", tc.format, got, tc.want)
+		}
+	}
+}
+
+func TestGameFormatHeader(t *testing.T) {
+	g := gameFromRows(false, "oo", "oo")
+	g.ShowHeader(true)
+	// %s keeps the header of String, shortened to the width of the field.
+	if got, want := fmt.Sprintf("%s", g), g.String(); got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+	// %v always has a single full header.
+	if got, want := fmt.Sprintf("%v", g), "gen 0  pop 4  rule B3/S23  2x2 plane\n██\n██\n"; got != want {
+		t.Errorf("got\n%s\nwanted\n%s", got, want)
+	}
+}
+
+func TestGameFormatOtherVerbs(t *testing.T) {
+	g := gameFromRows(false, "o")
+	// Other verbs format the fields of the game, as if there were no Format method.
+	if got := fmt.Sprintf("%d", g); !strings.HasPrefix(got, "&{") {
+		t.Errorf("%%d: got %q, wanted the fields of the game", got)
+	}
+	if got, want := fmt.Sprintf("%T", g), "*life.Game"; got != want {
+		t.Errorf("%%T: got %q, wanted %q", got, want)
+	}
+}
@@ -0,0 +1,147 @@
+package life
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FrameRenderer draws a box border around the output of another renderer and, optionally,
+// coordinate rulers along the top and left of the field.
+// The border adapts to the width of whatever the wrapped renderer produces, so it stays aligned for any
+// field size and any renderer.
+type FrameRenderer struct {
+	// Renderer draws the cells inside the frame. If nil, BlockRenderer is used.
+	Renderer Renderer
+	// ASCII draws the border with +, - and | instead of the Unicode box-drawing characters.
+	ASCII bool
+	// Rulers adds the last digit of every column and row index along the top and left of the frame,
+	// with the full index every 10 cells.
+	// Rulers require a renderer that draws exactly one character per cell.
+	Rulers bool
+}
+
+type frameGlyphs struct {
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical string
+}
+
+var (
+	unicodeFrame = frameGlyphs{"┌", "┐", "└", "┘", "─", "│"}
+	asciiFrame   = frameGlyphs{"+", "+", "+", "+", "-", "|"}
+)
+
+// Render writes f surrounded by a frame to w.
+// An error is returned if rulers are requested for a renderer that doesn't draw one character per cell.
+func (fr FrameRenderer) Render(w io.Writer, f *Field) error {
+	inner := fr.Renderer
+	if inner == nil {
+		inner = BlockRenderer{}
+	}
+	if fr.Rulers {
+		switch inner.(type) {
+		case BlockRenderer, AgeRenderer:
+		default:
+			return fmt.Errorf("rulers are not supported by renderers that draw more than one cell per character")
+		}
+		if cols, rows := CharactersPerCell(inner); cols != 1 || rows != 1 {
+			return fmt.Errorf("rulers are not supported by scaled renderers")
+		}
+	}
+	body := new(bytes.Buffer)
+	if err := inner.Render(body, f); err != nil {
+		return err
+	}
+	lines := strings.Split(strings.TrimSuffix(body.String(), "\n"), "\n")
+	if body.Len() == 0 {
+		lines = nil
+	}
+	var width int
+	for _, line := range lines {
+		if n := displayWidth(line); n > width {
+			width = n
+		}
+	}
+	g := unicodeFrame
+	if fr.ASCII {
+		g = asciiFrame
+	}
+
+	b := new(strings.Builder)
+	// The left ruler is as wide as the largest row index.
+	var margin string
+	if fr.Rulers {
+		margin = strings.Repeat(" ", len(strconv.Itoa(len(lines)-1)))
+		fr.writeTopRuler(b, margin, f.width)
+	}
+	b.WriteString(margin)
+	b.WriteString(g.topLeft)
+	b.WriteString(strings.Repeat(g.horizontal, width))
+	b.WriteString(g.topRight)
+	b.WriteByte('\n')
+	for y, line := range lines {
+		if fr.Rulers {
+			label := strconv.Itoa(y)
+			if y%10 != 0 {
+				label = label[len(label)-1:]
+			}
+			b.WriteString(margin[len(label):])
+			b.WriteString(label)
+		}
+		b.WriteString(g.vertical)
+		b.WriteString(line)
+		b.WriteString(strings.Repeat(" ", width-displayWidth(line)))
+		b.WriteString(g.vertical)
+		b.WriteByte('\n')
+	}
+	b.WriteString(margin)
+	b.WriteString(g.bottomLeft)
+	b.WriteString(strings.Repeat(g.horizontal, width))
+	b.WriteString(g.bottomRight)
+	b.WriteByte('\n')
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeTopRuler writes a line with the full index of every tenth column, if the field is wide enough to have any,
+// followed by a line with the last digit of every column index.
+func (fr FrameRenderer) writeTopRuler(b *strings.Builder, margin string, width uint) {
+	// Skip the margin and the left border.
+	indent := margin + " "
+	if width > 10 {
+		b.WriteString(indent)
+		var col uint
+		for x := uint(10); x < width; x += 10 {
+			label := strconv.FormatUint(uint64(x), 10)
+			b.WriteString(strings.Repeat(" ", int(x-col)))
+			b.WriteString(label)
+			col = x + uint(len(label))
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString(indent)
+	for x := uint(0); x < width; x++ {
+		b.WriteByte(byte('0' + x%10))
+	}
+	b.WriteByte('\n')
+}
+
+// displayWidth returns the number of characters in line, not counting ANSI escape sequences.
+func displayWidth(line string) int {
+	var n int
+	for i := 0; i < len(line); {
+		if line[i] == '\x1b' && i+1 < len(line) && line[i+1] == '[' {
+			// Skip the parameters up to and including the final byte of the sequence.
+			for i += 2; i < len(line) && (line[i] < 0x40 || line[i] > 0x7e); i++ {
+			}
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		n++
+	}
+	return n
+}
@@ -2,6 +2,9 @@ module github.com/418Coffee/life
 
 go 1.17
 
-require golang.org/x/sys v0.0.0-20220503163025-988cb79eb6c6
+require (
+	golang.org/x/sys v0.0.0-20220503163025-988cb79eb6c6
+	golang.org/x/term v0.0.0-20220526004731-065cf7ba2467
+)
 
 retract v0.1.0
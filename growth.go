@@ -0,0 +1,143 @@
+package life
+
+import "errors"
+
+// Growth is how the population of a pattern develops in the long run.
+type Growth int
+
+const (
+	// UnknownGrowth means the population didn't follow any of the other classes closely enough, e.g. because the
+	// pattern is still settling.
+	UnknownGrowth Growth = iota
+	// DiesOut means every cell has died.
+	DiesOut
+	// BoundedGrowth means the population repeats itself, as that of still lifes, oscillators and spaceships does.
+	BoundedGrowth
+	// LinearGrowth means the population grows by the same number of cells in every period, as that of guns and
+	// puffers does.
+	LinearGrowth
+	// SuperlinearGrowth means the growth of the population grows by the same number of cells in every period, as that
+	// of breeders does.
+	SuperlinearGrowth
+)
+
+func (g Growth) String() string {
+	switch g {
+	case DiesOut:
+		return "dies out"
+	case BoundedGrowth:
+		return "bounded"
+	case LinearGrowth:
+		return "linear"
+	case SuperlinearGrowth:
+		return "superlinear"
+	default:
+		return "unknown"
+	}
+}
+
+// GrowthReport is the result of ClassifyGrowth.
+type GrowthReport struct {
+	Growth Growth
+	// Generations is the number of generations run, fewer than asked for if the pattern died out.
+	Generations uint
+	// Population is the population after the last generation run.
+	Population uint
+	// Since is the generation from which on the population follows the class, the one at which the last cell died
+	// for DiesOut.
+	Since uint
+	// Period is the shortest period of the population curve: that of the oscillation for BoundedGrowth, and the
+	// number of generations Gain refers to for LinearGrowth and SuperlinearGrowth. For a glider gun it is the period
+	// of the gun.
+	Period uint
+	// Gain is the number of cells the population gains every Period generations for LinearGrowth, 5 for a gun that
+	// emits a glider every period, and the number of cells by which that gain increases for SuperlinearGrowth.
+	Gain int
+}
+
+// ClassifyGrowth runs the live cells of pattern on an unbounded plane, see SparseField, for the given number of
+// generations, and classifies how its population develops by the populations of the second half of the run.
+// The population is bounded if it repeats itself, grows linearly if it grows by the same number of cells in every
+// period, and superlinearly if its growth grows by the same number in every period. Each of these models has to match
+// the population of every generation exactly, so that a pattern that is still settling, like a methuselah, or that
+// grows in a less regular way is reported as UnknownGrowth rather than misclassified. Running for more generations
+// makes up for that: the generations should be enough for the pattern to settle and to repeat the period of its
+// population at least 4 times in the second half of the run.
+//
+// An error is returned for rules with B0, which fill an unbounded plane in a single generation.
+func ClassifyGrowth(pattern *Field, generations uint) (GrowthReport, error) {
+	if pattern.rule.Birth&1 != 0 {
+		return GrowthReport{}, errors.New("the growth of rules with B0 can't be classified")
+	}
+	s := Sparse(pattern)
+	pops := make([]int, 1, generations+1)
+	pops[0] = int(s.Population())
+	for gen := uint(1); gen <= generations && pops[gen-1] != 0; gen++ {
+		s.Step()
+		pops = append(pops, int(s.Population()))
+	}
+	return classifyPopulations(pops), nil
+}
+
+// classifyPopulations classifies the population curve pops, which has the population of every generation, see
+// ClassifyGrowth.
+func classifyPopulations(pops []int) GrowthReport {
+	n := len(pops) - 1
+	r := GrowthReport{Generations: uint(n), Population: uint(pops[n])}
+	if pops[n] == 0 {
+		r.Growth, r.Since = DiesOut, uint(n)
+		return r
+	}
+	start := n - n/2
+	// A bounded or linear population has the same first differences over a period, a superlinear one the same second
+	// differences.
+	for order := 1; order <= 2; order++ {
+		for period := 1; period <= n/8; period++ {
+			gain, ok := periodicDifference(pops, order, period, start)
+			if !ok {
+				continue
+			}
+			r.Period, r.Gain = uint(period), gain
+			switch {
+			case order == 1 && gain == 0:
+				r.Growth = BoundedGrowth
+			case order == 1 && gain > 0:
+				r.Growth = LinearGrowth
+			case order == 2 && gain > 0:
+				r.Growth = SuperlinearGrowth
+			default:
+				// The population can't shrink or slow down its growth forever.
+				return GrowthReport{Generations: r.Generations, Population: r.Population}
+			}
+			// Look for the generation from which on the model holds.
+			t := start
+			for t > order*period && difference(pops, order, period, t-1) == gain {
+				t--
+			}
+			r.Since = uint(t - order*period)
+			return r
+		}
+	}
+	return r
+}
+
+// periodicDifference returns the difference of the given order over period generations of pops, if it is the same
+// for every generation from start on.
+func periodicDifference(pops []int, order, period, start int) (int, bool) {
+	d := difference(pops, order, period, len(pops)-1)
+	for t := start; t < len(pops)-1; t++ {
+		if difference(pops, order, period, t) != d {
+			return 0, false
+		}
+	}
+	return d, true
+}
+
+// difference returns the first or second difference of pops over period generations at generation t, which must be
+// at least order*period.
+func difference(pops []int, order, period, t int) int {
+	if order == 1 {
+		return pops[t] - pops[t-period]
+	}
+	return pops[t] - 2*pops[t-period] + pops[t-2*period]
+}
@@ -0,0 +1,71 @@
+package life
+
+import "testing"
+
+func TestClassifyGrowth(t *testing.T) {
+	gun, err := LoadGame("./patterns/gosper-gun.rle", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rPentomino, err := LoadGame("./patterns/r-pentomino.rle", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		f    *Field
+		gens uint
+		want GrowthReport
+	}{
+		{"gun", gun.Field(), 600, GrowthReport{LinearGrowth, 600, 136, 0, 30, 5}},
+		// The R-pentomino takes 1103 generations to settle, and can't be told apart from a growing pattern before.
+		{"r-pentomino", rPentomino.Field(), 1000, GrowthReport{UnknownGrowth, 1000, 156, 0, 0, 0}},
+		{"settled r-pentomino", rPentomino.Field(), 3000, GrowthReport{BoundedGrowth, 3000, 116, 1103, 1, 0}},
+		{"block", fieldFromRows(false, "oo", "oo"), 100, GrowthReport{BoundedGrowth, 100, 4, 0, 1, 0}},
+		{"glider", fieldFromRows(false, ".o.", "..o", "ooo"), 100, GrowthReport{BoundedGrowth, 100, 5, 0, 1, 0}},
+		{"diagonal", fieldFromRows(false, "o..", ".o.", "..o"), 100, GrowthReport{DiesOut, 2, 0, 2, 0, 0}},
+		{"empty", NewField(3, 3, true), 100, GrowthReport{DiesOut, 0, 0, 0, 0, 0}},
+	} {
+		r, err := ClassifyGrowth(test.f, test.gens)
+		if err != nil || r != test.want {
+			t.Errorf("%s: got %+v (%v), wanted %+v", test.name, r, err, test.want)
+		}
+	}
+
+	b0, _ := ParseRule("B03/S23")
+	f := NewField(3, 3, false)
+	f.rule = b0
+	if _, err := ClassifyGrowth(f, 100); err == nil {
+		t.Error("expected an error for a rule with B0")
+	}
+}
+
+func TestClassifyPopulations(t *testing.T) {
+	// Settling for 10 generations, then growing quadratically with a blinking cell on top.
+	quadratic := []int{7, 30, 2, 15, 9, 40, 3, 21, 8, 11}
+	for t := 10; t <= 200; t++ {
+		quadratic = append(quadratic, 10+t%2+t*t)
+	}
+	for _, test := range []struct {
+		name string
+		pops []int
+		want GrowthReport
+	}{
+		{"quadratic", quadratic, GrowthReport{SuperlinearGrowth, 200, 40010, 10, 2, 8}},
+		{
+			"oscillating",
+			[]int{5, 9, 4, 7, 4, 7, 4, 7, 4, 7, 4, 7, 4, 7, 4, 7, 4, 7},
+			GrowthReport{BoundedGrowth, 17, 7, 2, 2, 0},
+		},
+		{
+			"shrinking",
+			[]int{40, 38, 36, 34, 32, 30, 28, 26, 24, 22, 20, 18, 16, 14, 12, 10},
+			GrowthReport{UnknownGrowth, 15, 10, 0, 0, 0},
+		},
+		{"too short", []int{1, 2, 3, 4, 5, 6, 7}, GrowthReport{UnknownGrowth, 6, 7, 0, 0, 0}},
+	} {
+		if r := classifyPopulations(test.pops); r != test.want {
+			t.Errorf("%s: got %+v, wanted %+v", test.name, r, test.want)
+		}
+	}
+}
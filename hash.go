@@ -0,0 +1,53 @@
+package life
+
+// Hash returns a 64-bit hash of the cells of the current generation.
+// Every cell position has a fixed pseudo-random key, and the hash is the XOR of the keys of all live cells. It
+// doesn't depend on the order in which the cells came to life, so equal generations always have equal hashes,
+// but different generations can collide. The hash is kept up to date by Set and by the engines, which flip the keys
+// of the cells that change as they compute them, so it costs nothing beside the changes.
+func (g *Game) Hash() uint64 {
+	return g.current.hash
+}
+
+// changes collects the cells that changed during a step: the XOR of their keys and the number of births and deaths.
+// Engines fill it as they compute the cells, and apply it to the field they computed.
+type changes struct {
+	hash           uint64
+	births, deaths uint
+}
+
+// flip records that the cell at position x,y came to life if alive is set, and died otherwise.
+func (c *changes) flip(x, y uint, alive bool) {
+	c.hash ^= cellKey(x, y)
+	if alive {
+		c.births++
+	} else {
+		c.deaths++
+	}
+}
+
+// add adds the changes of d, which were collected on other cells.
+func (c *changes) add(d changes) {
+	c.hash ^= d.hash
+	c.births += d.births
+	c.deaths += d.deaths
+}
+
+// apply sets the hash, births and deaths of next, the generation following current.
+func (c changes) apply(current, next *Field) {
+	next.hash = current.hash ^ c.hash
+	next.births, next.deaths = c.births, c.deaths
+}
+
+// cellKey returns the key of the cell at position x,y. It is derived from the coordinates with SplitMix64, so no
+// table of keys has to be stored however large the field is.
+func cellKey(x, y uint) uint64 {
+	return splitMix64(splitMix64(uint64(x)) ^ uint64(y))
+}
+
+func splitMix64(z uint64) uint64 {
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
@@ -0,0 +1,49 @@
+package life
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, name := range EngineNames() {
+		for _, wrap := range []bool{true, false} {
+			e, err := NewEngine(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := (&Game{current: randomField(rng, 37, 23, wrap), next: NewField(37, 23, wrap), width: 37, height: 23}).WithEngine(e)
+			for i := 0; i < 200; i++ {
+				if rng.Intn(4) == 0 {
+					g.Field().Set(uint(rng.Intn(37)), uint(rng.Intn(23)), rng.Intn(2) == 0)
+				} else {
+					g.Tick()
+				}
+				want := *g.Field()
+				want.recount()
+				if g.Hash() != want.hash {
+					t.Fatalf("%s wrap %t: step %d: got hash %x, wanted %x", name, wrap, i, g.Hash(), want.hash)
+				}
+			}
+		}
+	}
+
+	// A generation that recurs has the same hash.
+	g := gameFromRows(true,
+		".....",
+		"..o..",
+		"..o..",
+		"..o..",
+		".....",
+	)
+	start := g.Hash()
+	g.Tick()
+	if g.Hash() == start {
+		t.Error("blinker has the same hash in both phases")
+	}
+	g.Tick()
+	if g.Hash() != start {
+		t.Error("blinker hash differs after a full period")
+	}
+}
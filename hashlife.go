@@ -0,0 +1,412 @@
+package life
+
+import "math/bits"
+
+// node is a square of 2^level by 2^level cells of a HashLife universe.
+// Nodes are canonical: there is only one node for every combination of children, so identical squares are shared.
+type node struct {
+	nw, ne, sw, se *node
+	level          uint8
+	pop            uint64
+}
+
+// quadrants is the key nodes are looked up by.
+type quadrants struct {
+	nw, ne, sw, se *node
+}
+
+// memoKey is the key the results of HashLife.step are cached by.
+type memoKey struct {
+	n *node
+	j uint8
+}
+
+// DefaultHashLifeCacheSize is the number of cached nodes and results above which a HashLife universe clears its cache.
+const DefaultHashLifeCacheSize = 1 << 22
+
+var (
+	deadLeaf  = &node{}
+	aliveLeaf = &node{pop: 1}
+)
+
+// HashLife is an unbounded plane that is advanced with Gosper's HashLife algorithm.
+// The plane is stored as a quadtree in which identical squares share a single node, and the future of every node
+// is cached. Because of this, patterns with a lot of repetition in space and time, like guns or spaceships, can be
+// advanced by billions of generations in a matter of milliseconds.
+// Rules that give birth to cells without any live neighbours (B0) are not supported.
+type HashLife struct {
+	// CacheSize is the number of cached nodes and results above which the cache is cleared, see ResetCache.
+	// If zero, DefaultHashLifeCacheSize is used.
+	CacheSize int
+
+	rule       Rule
+	nodes      map[quadrants]*node
+	memo       map[memoKey]*node
+	empty      []*node
+	root       *node
+	origin     Point
+	generation uint64
+}
+
+// NewHashLife returns an empty HashLife plane.
+func NewHashLife() *HashLife {
+	h := &HashLife{rule: Conway}
+	h.ResetCache()
+	h.root = h.emptyNode(3)
+	h.origin = Point{-4, -4}
+	return h
+}
+
+// HashLifeFromField returns a HashLife plane holding the live cells of f, with the top-left cell of f at the origin.
+// The plane does not wrap, whatever the wrapping of f is.
+func HashLifeFromField(f *Field) *HashLife {
+	h := NewHashLife()
+	h.rule = f.rule
+	h.Load(f)
+	return h
+}
+
+// Load replaces the contents of the plane with the live cells of f, with the top-left cell of f at the origin.
+// The cache is kept, so loading similar fields over and over again stays fast.
+func (h *HashLife) Load(f *Field) {
+	level := uint8(3)
+	for uint64(1)<<level < uint64(f.width) || uint64(1)<<level < uint64(f.height) {
+		level++
+	}
+	h.root = h.build(f, level, 0, 0)
+	h.origin = Point{}
+	h.generation = 0
+}
+
+func (h *HashLife) build(f *Field, level uint8, x, y uint) *node {
+	if x >= f.width || y >= f.height {
+		return h.emptyNode(level)
+	}
+	if level == 0 {
+		if f.s[y][x] {
+			return aliveLeaf
+		}
+		return deadLeaf
+	}
+	half := uint(1) << (level - 1)
+	return h.join(
+		h.build(f, level-1, x, y),
+		h.build(f, level-1, x+half, y),
+		h.build(f, level-1, x, y+half),
+		h.build(f, level-1, x+half, y+half),
+	)
+}
+
+// ResetCache clears the cache of nodes and results, keeping only the nodes of the current pattern.
+// It is called automatically when the cache grows beyond CacheSize.
+func (h *HashLife) ResetCache() {
+	h.nodes = make(map[quadrants]*node)
+	h.memo = make(map[memoKey]*node)
+	h.empty = []*node{deadLeaf}
+	if h.root != nil {
+		h.root = h.intern(h.root)
+	}
+}
+
+// setRule makes r the rule of the plane. The cached results of the old rule are forgotten, the nodes are kept as
+// they don't depend on the rule.
+func (h *HashLife) setRule(r Rule) {
+	if r != h.rule {
+		h.rule = r
+		h.memo = make(map[memoKey]*node)
+	}
+}
+
+// intern re-adds n and its descendants to the node table.
+func (h *HashLife) intern(n *node) *node {
+	if n.level == 0 {
+		return n
+	}
+	return h.join(h.intern(n.nw), h.intern(n.ne), h.intern(n.sw), h.intern(n.se))
+}
+
+// Population returns the number of live cells on the plane.
+func (h *HashLife) Population() uint64 {
+	return h.root.pop
+}
+
+// Generation returns the number of generations the plane was advanced since it was loaded.
+func (h *HashLife) Generation() uint64 {
+	return h.generation
+}
+
+// Set sets the value v to the cell with position x,y on the plane.
+func (h *HashLife) Set(x, y int64, v bool) {
+	for !h.contains(x, y) {
+		h.expand()
+	}
+	h.root = h.set(h.root, uint64(x-h.origin.X), uint64(y-h.origin.Y), v)
+}
+
+func (h *HashLife) contains(x, y int64) bool {
+	size := int64(1) << h.root.level
+	return x >= h.origin.X && y >= h.origin.Y && x-h.origin.X < size && y-h.origin.Y < size
+}
+
+func (h *HashLife) set(n *node, x, y uint64, v bool) *node {
+	if n.level == 0 {
+		if v {
+			return aliveLeaf
+		}
+		return deadLeaf
+	}
+	half := uint64(1) << (n.level - 1)
+	nw, ne, sw, se := n.nw, n.ne, n.sw, n.se
+	switch {
+	case x < half && y < half:
+		nw = h.set(nw, x, y, v)
+	case y < half:
+		ne = h.set(ne, x-half, y, v)
+	case x < half:
+		sw = h.set(sw, x, y-half, v)
+	default:
+		se = h.set(se, x-half, y-half, v)
+	}
+	return h.join(nw, ne, sw, se)
+}
+
+// Alive reports whether the cell at position x,y is alive or dead.
+func (h *HashLife) Alive(x, y int64) bool {
+	if !h.contains(x, y) {
+		return false
+	}
+	n := h.root
+	ux, uy := uint64(x-h.origin.X), uint64(y-h.origin.Y)
+	for n.level > 0 {
+		half := uint64(1) << (n.level - 1)
+		switch {
+		case ux < half && uy < half:
+			n = n.nw
+		case uy < half:
+			n, ux = n.ne, ux-half
+		case ux < half:
+			n, uy = n.sw, uy-half
+		default:
+			n, ux, uy = n.se, ux-half, uy-half
+		}
+	}
+	return n == aliveLeaf
+}
+
+// Viewport returns a dense copy of the rectangle of the given size with its top-left corner at position x,y.
+func (h *HashLife) Viewport(x, y int64, width, height uint) *Field {
+	f := NewField(width, height, false)
+	f.rule = h.rule
+	eachLiveOn(f, h.root, h.origin.X-x, h.origin.Y-y, func(x, y uint) { f.s[y][x] = true })
+	f.recount()
+	return f
+}
+
+// eachLiveOn calls fn with the position on f of every live cell of n that lies on f, with the top-left corner of n
+// at position x,y of f.
+func eachLiveOn(f *Field, n *node, x, y int64, fn func(x, y uint)) {
+	size := int64(1) << n.level
+	if n.pop == 0 || x >= int64(f.width) || y >= int64(f.height) || x+size <= 0 || y+size <= 0 {
+		return
+	}
+	if n.level == 0 {
+		fn(uint(x), uint(y))
+		return
+	}
+	half := size / 2
+	eachLiveOn(f, n.nw, x, y, fn)
+	eachLiveOn(f, n.ne, x+half, y, fn)
+	eachLiveOn(f, n.sw, x, y+half, fn)
+	eachLiveOn(f, n.se, x+half, y+half, fn)
+}
+
+// Advance advances the plane by n generations, taking steps of the largest powers of two that make up n.
+func (h *HashLife) Advance(n uint64) {
+	for j := uint8(0); n != 0; j++ {
+		if n&1 != 0 {
+			h.advance(j)
+		}
+		n >>= 1
+	}
+}
+
+// advance advances the plane by 2^j generations.
+func (h *HashLife) advance(j uint8) {
+	// The result of a step is the center half of the root, so the pattern must be in the center quarter of a
+	// root large enough that 2^j generations can't carry it past the center half.
+	for h.root.level < j+3 || !h.centered() {
+		h.expand()
+	}
+	h.expand()
+	quarter := int64(1) << (h.root.level - 2)
+	h.root = h.step(h.root, j)
+	h.origin.X += quarter
+	h.origin.Y += quarter
+	h.generation += uint64(1) << j
+	size := h.CacheSize
+	if size == 0 {
+		size = DefaultHashLifeCacheSize
+	}
+	if len(h.nodes)+len(h.memo) > size {
+		h.ResetCache()
+	}
+}
+
+// centered reports whether all live cells are in the center half of the root.
+func (h *HashLife) centered() bool {
+	r := h.root
+	return r.pop == r.nw.se.pop+r.ne.sw.pop+r.sw.ne.pop+r.se.nw.pop
+}
+
+// expand doubles the size of the root, keeping the current root in the center.
+func (h *HashLife) expand() {
+	r := h.root
+	e := h.emptyNode(r.level - 1)
+	h.root = h.join(
+		h.join(e, e, e, r.nw),
+		h.join(e, e, r.ne, e),
+		h.join(e, r.sw, e, e),
+		h.join(r.se, e, e, e),
+	)
+	half := int64(1) << (r.level - 1)
+	h.origin.X -= half
+	h.origin.Y -= half
+}
+
+func (h *HashLife) join(nw, ne, sw, se *node) *node {
+	q := quadrants{nw, ne, sw, se}
+	if n, ok := h.nodes[q]; ok {
+		return n
+	}
+	n := &node{nw: nw, ne: ne, sw: sw, se: se, level: nw.level + 1, pop: nw.pop + ne.pop + sw.pop + se.pop}
+	h.nodes[q] = n
+	return n
+}
+
+func (h *HashLife) emptyNode(level uint8) *node {
+	for uint8(len(h.empty)) <= level {
+		e := h.empty[len(h.empty)-1]
+		h.empty = append(h.empty, h.join(e, e, e, e))
+	}
+	return h.empty[level]
+}
+
+// step returns the center half of n advanced by 2^j generations, where j is at most the level of n minus 2.
+func (h *HashLife) step(n *node, j uint8) *node {
+	if n.pop == 0 {
+		return n.nw
+	}
+	if j > n.level-2 {
+		j = n.level - 2
+	}
+	key := memoKey{n, j}
+	if r, ok := h.memo[key]; ok {
+		return r
+	}
+	var r *node
+	if n.level == 2 {
+		r = h.base(n)
+	} else {
+		// Nine overlapping squares of half the size, advanced by 2^j generations if that is less than a
+		// quarter of the size of n, and by half of that otherwise.
+		c11 := h.step(n.nw, j)
+		c12 := h.step(h.join(n.nw.ne, n.ne.nw, n.nw.se, n.ne.sw), j)
+		c13 := h.step(n.ne, j)
+		c21 := h.step(h.join(n.nw.sw, n.nw.se, n.sw.nw, n.sw.ne), j)
+		c22 := h.step(h.join(n.nw.se, n.ne.sw, n.sw.ne, n.se.nw), j)
+		c23 := h.step(h.join(n.ne.sw, n.ne.se, n.se.nw, n.se.ne), j)
+		c31 := h.step(n.sw, j)
+		c32 := h.step(h.join(n.sw.ne, n.se.nw, n.sw.se, n.se.sw), j)
+		c33 := h.step(n.se, j)
+		if j < n.level-2 {
+			r = h.join(
+				h.join(c11.se, c12.sw, c21.ne, c22.nw),
+				h.join(c12.se, c13.sw, c22.ne, c23.nw),
+				h.join(c21.se, c22.sw, c31.ne, c32.nw),
+				h.join(c22.se, c23.sw, c32.ne, c33.nw),
+			)
+		} else {
+			r = h.join(
+				h.step(h.join(c11, c12, c21, c22), j),
+				h.step(h.join(c12, c13, c22, c23), j),
+				h.step(h.join(c21, c22, c31, c32), j),
+				h.step(h.join(c22, c23, c32, c33), j),
+			)
+		}
+	}
+	h.memo[key] = r
+	return r
+}
+
+// base computes the center 2x2 cells of a 4x4 node after one generation.
+func (h *HashLife) base(n *node) *node {
+	// Bit y*4+x holds the cell at x,y.
+	var cells uint16
+	for i, q := range [4]*node{n.nw, n.ne, n.sw, n.se} {
+		for k, leaf := range [4]*node{q.nw, q.ne, q.sw, q.se} {
+			if leaf == aliveLeaf {
+				x, y := i%2*2+k%2, i/2*2+k/2
+				cells |= 1 << (y*4 + x)
+			}
+		}
+	}
+	var result [4]*node
+	for k := range result {
+		x, y := 1+k%2, 1+k/2
+		// The 3x3 neighbourhood of x,y, including the cell itself.
+		var around uint16
+		for dy := -1; dy <= 1; dy++ {
+			around |= (cells >> ((y+dy)*4 + x - 1) & 7) << ((dy + 1) * 3)
+		}
+		alive := around&(1<<4) != 0
+		result[k] = deadLeaf
+		if h.rule.Next(alive, uint8(bits.OnesCount16(around&^(1<<4)))) {
+			result[k] = aliveLeaf
+		}
+	}
+	return h.join(result[0], result[1], result[2], result[3])
+}
+
+// HashLifeEngine computes generations with a HashLife plane, see HashLife.
+// HashLife works on an unbounded plane, so cells leaving the field are removed after every step, which matches a
+// field that doesn't wrap. Wrapping fields, and fields with a B0 rule that HashLife doesn't support, are computed by
+// NaiveEngine instead.
+type HashLifeEngine struct {
+	h *HashLife
+}
+
+// Step writes the generation following current into next.
+func (e *HashLifeEngine) Step(current, next *Field) {
+	if current.wrap || current.rule.Birth&1 != 0 {
+		NaiveEngine{}.Step(current, next)
+		return
+	}
+	if e.h == nil {
+		e.h = NewHashLife()
+	}
+	e.h.setRule(current.rule)
+	e.h.Load(current)
+	// The loaded root is current, with its top-left corner at the origin, to find the cells that died.
+	before := e.h.root
+	e.h.Advance(1)
+	for _, row := range next.s {
+		for x := range row {
+			row[x] = false
+		}
+	}
+	next.pop = 0
+	var c changes
+	eachLiveOn(next, e.h.root, e.h.origin.X, e.h.origin.Y, func(x, y uint) {
+		next.s[y][x] = true
+		next.pop++
+		if !current.s[y][x] {
+			c.flip(x, y, true)
+		}
+	})
+	eachLiveOn(current, before, 0, 0, func(x, y uint) {
+		if !next.s[y][x] {
+			c.flip(x, y, false)
+		}
+	})
+	c.apply(current, next)
+}
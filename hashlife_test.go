@@ -0,0 +1,90 @@
+package life
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHashLifeEngine(t *testing.T) {
+	assertSameEngine(t, func() Engine { return new(HashLifeEngine) })
+}
+
+func TestHashLife(t *testing.T) {
+	rPentomino := fieldFromRows(false,
+		".oo",
+		"oo.",
+		".o.",
+	)
+	// The sparse field is compared against the naive engine itself, see TestSparseField.
+	sparse := Sparse(rPentomino)
+	stepped := HashLifeFromField(rPentomino)
+	jumped := HashLifeFromField(rPentomino)
+	for gen := uint64(1); gen <= 3000; gen++ {
+		sparse.Step()
+		stepped.Advance(1)
+		if gen%500 != 0 {
+			continue
+		}
+		jumped.Advance(500)
+		min, max, _ := sparse.BoundingBox()
+		width, height := uint(max.X-min.X+1), uint(max.Y-min.Y+1)
+		want := sparse.Viewport(min.X, min.Y, width, height)
+		for _, h := range []*HashLife{stepped, jumped} {
+			if h.Generation() != gen {
+				t.Fatalf("got generation %d, wanted %d", h.Generation(), gen)
+			}
+			if h.Population() != uint64(sparse.Population()) {
+				t.Fatalf("generation %d: got population %d, wanted %d", gen, h.Population(), sparse.Population())
+			}
+			if got := h.Viewport(min.X, min.Y, width, height); !equalCells(got, want) {
+				t.Fatalf("generation %d: got:\n%s\nwanted:\n%s", gen, got, want)
+			}
+		}
+	}
+}
+
+func TestHashLifeGlider(t *testing.T) {
+	h := HashLifeFromField(fieldFromRows(false,
+		".o.",
+		"..o",
+		"ooo",
+	))
+	start := time.Now()
+	h.Advance(1 << 20)
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("advancing a glider by 2^20 generations took %v", d)
+	}
+	// A glider moves one cell diagonally every four generations.
+	const moved = 1 << 18
+	got := h.Viewport(moved, moved, 3, 3)
+	if want := fieldFromRows(false, ".o.", "..o", "ooo"); !equalCells(got, want) || h.Population() != 5 {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestHashLifeResetCache(t *testing.T) {
+	h := HashLifeFromField(fieldFromRows(false,
+		".oo",
+		"oo.",
+		".o.",
+	))
+	h.CacheSize = 1000
+	h.Advance(1103)
+	// The R-pentomino settles into 116 cells after 1103 generations.
+	if h.Population() != 116 {
+		t.Errorf("got population %d, wanted 116", h.Population())
+	}
+	if n := len(h.nodes) + len(h.memo); n > 100_000 {
+		t.Errorf("cache holds %d entries", n)
+	}
+}
+
+func TestHashLifeSet(t *testing.T) {
+	h := NewHashLife()
+	h.Set(-1_000_000, 5, true)
+	h.Set(3, 1_000_000, true)
+	h.Set(3, 1_000_000, false)
+	if !h.Alive(-1_000_000, 5) || h.Alive(3, 1_000_000) || h.Population() != 1 {
+		t.Errorf("got population %d", h.Population())
+	}
+}
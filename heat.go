@@ -0,0 +1,213 @@
+package life
+
+import (
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+)
+
+// HeatMap counts for every cell of a game the generations it was alive, which shows where a long run was busy,
+// e.g. the lanes of gliders and the zones of reactions. See Game.TrackHeat.
+// It is an image.Image with one pixel per cell, coloured like HeatRenderer colours the cells, so it can be encoded
+// as PNG with image/png.
+type HeatMap struct {
+	counts        [][]uint32
+	width, height uint
+	max           uint32
+}
+
+// TrackHeat enables or disables the heat layer of the game.
+// While enabled, every tick adds the cells that are alive in the new generation to the heat map, see HeatMap.
+// Enabling the layer starts the heat map with the cells that are currently alive.
+func (g *Game) TrackHeat(enabled bool) {
+	if !enabled {
+		g.heat = nil
+		return
+	}
+	if g.heat != nil {
+		return
+	}
+	g.heat = &HeatMap{counts: newAges(g.width, g.height), width: g.width, height: g.height}
+	g.heat.add(g.current)
+}
+
+// HeatMap returns a copy of the heat map of the game, or nil if the heat layer isn't enabled.
+func (g *Game) HeatMap() *HeatMap {
+	if g.heat == nil {
+		return nil
+	}
+	return g.heat.resized(g.heat.width, g.heat.height)
+}
+
+// add counts the live cells of f. Counts saturate instead of overflowing.
+func (h *HeatMap) add(f *Field) {
+	for y, row := range f.s {
+		counts := h.counts[y]
+		for x, alive := range row {
+			if alive && counts[x] < math.MaxUint32 {
+				counts[x]++
+				if counts[x] > h.max {
+					h.max = counts[x]
+				}
+			}
+		}
+	}
+}
+
+// resized returns a copy of h of the given size, holding the counts of the cells of h that lie within it.
+func (h *HeatMap) resized(width, height uint) *HeatMap {
+	c := &HeatMap{counts: newAges(width, height), width: width, height: height}
+	for y := uint(0); y < height && y < h.height; y++ {
+		copy(c.counts[y], h.counts[y])
+		for _, n := range c.counts[y] {
+			if n > c.max {
+				c.max = n
+			}
+		}
+	}
+	return c
+}
+
+// Count returns the number of generations the cell at position x,y was alive.
+func (h *HeatMap) Count(x, y uint) uint32 {
+	return h.counts[y][x]
+}
+
+// Max returns the largest count of any cell.
+func (h *HeatMap) Max() uint32 {
+	return h.max
+}
+
+// level returns where the count of the cell at position x,y lies between a single generation and the largest
+// count, as a number between 0 and 1. The scale is logarithmic, so that cells that were alive for a few
+// generations, like the lanes of gliders, still stand out next to still lifes that were alive all along.
+func (h *HeatMap) level(x, y uint) float64 {
+	if h.max <= 1 {
+		return 1
+	}
+	return math.Log(float64(h.counts[y][x])) / math.Log(float64(h.max))
+}
+
+var (
+	// DefaultHeatPalette256 goes from dark blue for the cells that were alive least through purple, red and yellow to
+	// white for those that were alive most, using 256-colour indices.
+	DefaultHeatPalette256 = []uint8{17, 18, 19, 55, 91, 127, 161, 160, 196, 202, 208, 214, 220, 226, 228, 230, 231}
+	// DefaultHeatPalette8 goes from blue through magenta, red and yellow to white, using the 8 standard colours.
+	DefaultHeatPalette8 = []uint8{4, 5, 1, 3, 7}
+)
+
+// HeatRenderer draws heat maps with one character per cell, like BlockRenderer draws fields. Every cell that was
+// ever alive is drawn in a colour of the palette, from the first for the smallest count to the last for the
+// largest, using ANSI escape sequences. Like those of AgeRenderer, lines that set a colour end with a reset
+// sequence.
+type HeatRenderer struct {
+	// Palette holds the colours from the smallest count to the largest. If empty, DefaultHeatPalette256 or
+	// DefaultHeatPalette8 is used.
+	Palette []uint8
+	// Basic selects the 8 standard colours (0 to 7) instead of the 256-colour palette.
+	Basic bool
+	// Plain draws the counts with the shades ░, ▒, ▓ and █ instead of colours, for output that isn't a terminal.
+	Plain bool
+}
+
+var heatShades = [...]string{"░", "▒", "▓", "█"}
+
+// Render writes h to w.
+func (hr HeatRenderer) Render(w io.Writer, h *HeatMap) error {
+	palette := hr.Palette
+	if len(palette) == 0 {
+		palette = DefaultHeatPalette256
+		if hr.Basic {
+			palette = DefaultHeatPalette8
+		}
+	}
+	var b []byte
+	for y := uint(0); y < h.height; y++ {
+		colour := -1
+		for x := uint(0); x < h.width; x++ {
+			if h.counts[y][x] == 0 {
+				b = append(b, deadGlyph...)
+				continue
+			}
+			if hr.Plain {
+				b = append(b, heatShades[scale(h.level(x, y), len(heatShades))]...)
+				continue
+			}
+			if c := int(palette[scale(h.level(x, y), len(palette))]); c != colour {
+				colour = c
+				if hr.Basic {
+					b = append(b, "\x1b[3"...)
+				} else {
+					b = append(b, "\x1b[38;5;"...)
+				}
+				b = strconv.AppendInt(b, int64(c), 10)
+				b = append(b, 'm')
+			}
+			b = append(b, aliveGlyph...)
+		}
+		if colour != -1 {
+			b = append(b, resetColour...)
+		}
+		b = append(b, '\n')
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// scale returns the index of one of n steps that level, between 0 and 1, falls into.
+func scale(level float64, n int) int {
+	i := int(level * float64(n))
+	if i >= n {
+		i = n - 1
+	}
+	return i
+}
+
+// ColorModel, Bounds and At make the heat map an image.Image. Cells that were never alive are black, the others
+// are coloured along DefaultHeatPalette256, blending between its colours.
+func (h *HeatMap) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (h *HeatMap) Bounds() image.Rectangle {
+	return image.Rect(0, 0, int(h.width), int(h.height))
+}
+
+func (h *HeatMap) At(x, y int) color.Color {
+	if x < 0 || y < 0 || x >= int(h.width) || y >= int(h.height) || h.counts[y][x] == 0 {
+		return color.RGBA{A: 0xff}
+	}
+	pos := h.level(uint(x), uint(y)) * float64(len(DefaultHeatPalette256)-1)
+	i := int(pos)
+	if i >= len(DefaultHeatPalette256)-1 {
+		return xterm256(DefaultHeatPalette256[len(DefaultHeatPalette256)-1])
+	}
+	a, b, t := xterm256(DefaultHeatPalette256[i]), xterm256(DefaultHeatPalette256[i+1]), pos-float64(i)
+	blend := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return color.RGBA{blend(a.R, b.R), blend(a.G, b.G), blend(a.B, b.B), 0xff}
+}
+
+// xterm256 returns the colour of index i of the 6×6×6 colour cube and grey ramp of 256-colour terminals. The 16
+// system colours, whose values vary from terminal to terminal, are taken to be those of xterm.
+func xterm256(i uint8) color.RGBA {
+	switch {
+	case i < 16:
+		system := [16][3]uint8{
+			{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+			{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+		}
+		c := system[i]
+		return color.RGBA{c[0], c[1], c[2], 0xff}
+	case i < 232:
+		levels := [6]uint8{0, 95, 135, 175, 215, 255}
+		i -= 16
+		return color.RGBA{levels[i/36], levels[i/6%6], levels[i%6], 0xff}
+	default:
+		v := 8 + 10*(i-232)
+		return color.RGBA{v, v, v, 0xff}
+	}
+}
@@ -0,0 +1,91 @@
+package life
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestHeatMap(t *testing.T) {
+	g := gameFromRows(true,
+		".....",
+		"..o..",
+		"..o..",
+		"..o..",
+		".....",
+	)
+	if g.HeatMap() != nil {
+		t.Fatal("got a heat map without tracking heat")
+	}
+	g.TrackHeat(true)
+	for i := 0; i < 4; i++ {
+		g.Tick()
+	}
+	h := g.HeatMap()
+	// The middle of the blinker is alive in all five generations, its ends in every other one.
+	for _, c := range []struct {
+		x, y uint
+		want uint32
+	}{{2, 2, 5}, {2, 1, 3}, {1, 2, 2}, {0, 0, 0}} {
+		if got := h.Count(c.x, c.y); got != c.want {
+			t.Errorf("count of %d,%d: got %d, wanted %d", c.x, c.y, got, c.want)
+		}
+	}
+	if h.Max() != 5 {
+		t.Errorf("max: got %d, wanted 5", h.Max())
+	}
+	g.Tick()
+	if h.Count(2, 2) != 5 {
+		t.Error("the heat map returned by HeatMap changed with the game")
+	}
+
+	g.Resize(3, 3)
+	if h := g.HeatMap(); h.Count(2, 2) != 6 || h.Bounds().Dx() != 3 {
+		t.Errorf("resized: got count %d and bounds %v", h.Count(2, 2), h.Bounds())
+	}
+
+	g.heat.counts[2][2] = math.MaxUint32
+	g.Tick()
+	if got := g.HeatMap().Count(2, 2); got != math.MaxUint32 {
+		t.Errorf("saturated count: got %d", got)
+	}
+}
+
+func TestHeatRenderer(t *testing.T) {
+	h := &HeatMap{counts: [][]uint32{{0, 1, 3}, {15, 0, 0}}, width: 3, height: 2, max: 15}
+	var b bytes.Buffer
+	if err := (HeatRenderer{Plain: true}).Render(&b, h); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), " ░▒\n█  \n"; got != want {
+		t.Errorf("plain: got %q, wanted %q", got, want)
+	}
+	b.Reset()
+	if err := (HeatRenderer{Palette: []uint8{1, 2}, Basic: true}).Render(&b, h); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), " \x1b[31m██\x1b[0m\n\x1b[32m█  \x1b[0m\n"; got != want {
+		t.Errorf("colours: got %q, wanted %q", got, want)
+	}
+}
+
+func TestHeatMapImage(t *testing.T) {
+	h := &HeatMap{counts: [][]uint32{{0, 1, 7}}, width: 3, height: 1, max: 7}
+	if got := h.At(0, 0); got != (color.RGBA{A: 0xff}) {
+		t.Errorf("dead cell: got %v, wanted black", got)
+	}
+	last := DefaultHeatPalette256[len(DefaultHeatPalette256)-1]
+	if got, want := h.At(2, 0), xterm256(last); got != want {
+		t.Errorf("largest count: got %v, wanted %v", got, want)
+	}
+	if got := h.At(1, 0); got == h.At(0, 0) || got == h.At(2, 0) {
+		t.Errorf("smallest count: got %v, the colour of another count", got)
+	}
+	if got, want := xterm256(196), (color.RGBA{255, 0, 0, 0xff}); got != want {
+		t.Errorf("xterm256(196): got %v, wanted %v", got, want)
+	}
+	if got, want := xterm256(244), (color.RGBA{128, 128, 128, 0xff}); got != want {
+		t.Errorf("xterm256(244): got %v, wanted %v", got, want)
+	}
+}
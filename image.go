@@ -0,0 +1,60 @@
+package life
+
+import (
+	"image"
+	"image/color"
+)
+
+// FieldImage is an image.Image of a field, with every cell drawn as a square of pixels: in the alive colour for live
+// cells and in the dead colour for dead ones. It reads the cells of the field as its pixels are read instead of
+// copying them, so it shows the field as it is at that moment. See Field.Image.
+type FieldImage struct {
+	f        *Field
+	cellSize int
+	palette  color.Palette
+}
+
+// Image returns an image of f with every cell a square of cellSize by cellSize pixels, coloured alive or dead. A
+// cellSize below 1 is taken to be 1, and nil colours are white for alive cells and black for dead ones.
+// The image can be encoded as PNG with image/png, as a two-colour paletted image.
+func (f *Field) Image(cellSize int, alive, dead color.Color) *FieldImage {
+	if cellSize < 1 {
+		cellSize = 1
+	}
+	if alive == nil {
+		alive = color.White
+	}
+	if dead == nil {
+		dead = color.Black
+	}
+	return &FieldImage{f: f, cellSize: cellSize, palette: color.Palette{dead, alive}}
+}
+
+// CellSize returns the width and height of a cell in pixels.
+func (i *FieldImage) CellSize() int {
+	return i.cellSize
+}
+
+// ColorModel, Bounds and At make the field an image.Image, and ColorIndexAt an image.PalettedImage, whose palette
+// holds the dead colour at index 0 and the alive colour at index 1.
+func (i *FieldImage) ColorModel() color.Model {
+	return i.palette
+}
+
+func (i *FieldImage) Bounds() image.Rectangle {
+	return image.Rect(0, 0, int(i.f.width)*i.cellSize, int(i.f.height)*i.cellSize)
+}
+
+func (i *FieldImage) At(x, y int) color.Color {
+	return i.palette[i.ColorIndexAt(x, y)]
+}
+
+func (i *FieldImage) ColorIndexAt(x, y int) uint8 {
+	if x < 0 || y < 0 || x >= int(i.f.width)*i.cellSize || y >= int(i.f.height)*i.cellSize {
+		return 0
+	}
+	if i.f.s[y/i.cellSize][x/i.cellSize] {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,45 @@
+package life
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestFieldImage(t *testing.T) {
+	f := fieldFromRows(false,
+		"o.",
+		".o",
+	)
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	img := f.Image(3, red, nil)
+	if got, want := img.Bounds(), image.Rect(0, 0, 6, 6); got != want {
+		t.Fatalf("bounds: got %v, wanted %v", got, want)
+	}
+	for _, c := range []struct {
+		x, y int
+		want color.Color
+	}{{0, 0, red}, {2, 2, red}, {3, 0, color.Black}, {3, 3, red}, {5, 5, red}, {2, 3, color.Black}, {6, 0, color.Black}} {
+		if got := img.At(c.x, c.y); got != c.want {
+			t.Errorf("%d,%d: got %v, wanted %v", c.x, c.y, got, c.want)
+		}
+	}
+	// The image shows the field as it is when read.
+	f.Set(1, 0, true)
+	if img.ColorIndexAt(4, 1) != 1 {
+		t.Error("the image doesn't follow the field")
+	}
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded.(*image.Paletted); !ok {
+		t.Errorf("encoded as %T, wanted a paletted image", decoded)
+	}
+}
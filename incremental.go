@@ -0,0 +1,135 @@
+package life
+
+// Cell is the position of a cell on a field.
+type Cell struct {
+	X, Y uint
+}
+
+// IncrementalEngine only evaluates the cells that changed during the previous generation and their neighbours,
+// because no other cell can change state. Settled patterns with a few moving parts are therefore computed in a
+// fraction of the time of a full scan.
+// The first generation, every generation after the fields were modified from the outside or the rule changed, and
+// every generation following one in which more than a quarter of the cells changed, are computed with a full scan.
+type IncrementalEngine struct {
+	// in and out are the fields of the previous step, and inEdits and outEdits their edit counters
+	// at the end of that step.
+	in, out           *Field
+	inEdits, outEdits uint64
+	// rule is the rule of the previous step.
+	rule Rule
+	// changed holds the cells that differ between in and out, if tracked is set.
+	// Tracking stops when too many cells change.
+	changed []Cell
+	tracked bool
+	// seen marks the candidates of the current step, seen[y*width+x] == stamp.
+	seen       []uint32
+	stamp      uint32
+	candidates []Cell
+	evaluated  uint64
+}
+
+// Evaluated returns the total number of cells the engine has evaluated.
+func (e *IncrementalEngine) Evaluated() uint64 {
+	return e.evaluated
+}
+
+// Step writes the generation following current into next.
+func (e *IncrementalEngine) Step(current, next *Field) {
+	// Incremental updates are only possible if the fields are the ones of the previous step, swapped, and
+	// weren't modified since, with the same rule.
+	if current != e.out || next != e.in || current.edits != e.outEdits || next.edits != e.inEdits || !e.tracked ||
+		current.rule != e.rule {
+		e.full(current, next)
+	} else {
+		e.incremental(current, next)
+	}
+	e.in, e.out = current, next
+	e.inEdits, e.outEdits = current.edits, next.edits
+	e.rule = current.rule
+}
+
+// full computes every cell and records which cells changed.
+func (e *IncrementalEngine) full(current, next *Field) {
+	NaiveEngine{}.Step(current, next)
+	e.evaluated += uint64(current.width * current.height)
+	limit := int(current.width * current.height / 4)
+	e.changed, e.tracked = e.changed[:0], true
+	for y, row := range next.s {
+		for x, alive := range row {
+			if alive != current.s[y][x] {
+				if len(e.changed) == limit {
+					e.tracked = false
+					return
+				}
+				e.changed = append(e.changed, Cell{uint(x), uint(y)})
+			}
+		}
+	}
+}
+
+// incremental computes the cells that changed during the previous step and their neighbours.
+func (e *IncrementalEngine) incremental(current, next *Field) {
+	// Bring next up to date with current, they only differ in the cells that changed during the previous step.
+	for _, c := range e.changed {
+		next.s[c.Y][c.X] = current.s[c.Y][c.X]
+	}
+	next.pop = current.pop
+	var flips changes
+
+	if n := int(current.width * current.height); len(e.seen) != n {
+		e.seen = make([]uint32, n)
+		e.stamp = 0
+	}
+	e.stamp++
+	if e.stamp == 0 {
+		// The stamp wrapped around, forget all old marks.
+		for i := range e.seen {
+			e.seen[i] = 0
+		}
+		e.stamp = 1
+	}
+	e.candidates = e.candidates[:0]
+	w, h := int(current.width), int(current.height)
+	for _, c := range e.changed {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				x, y := int(c.X)+dx, int(c.Y)+dy
+				if current.wrap {
+					x, y = (x+w)%w, (y+h)%h
+				} else if x < 0 || y < 0 || x >= w || y >= h {
+					continue
+				}
+				if i := y*w + x; e.seen[i] != e.stamp {
+					e.seen[i] = e.stamp
+					e.candidates = append(e.candidates, Cell{uint(x), uint(y)})
+				}
+			}
+		}
+	}
+
+	limit := int(current.width * current.height / 4)
+	e.changed = e.changed[:0]
+	for _, c := range e.candidates {
+		alive := current.Future(c.X, c.Y)
+		if alive == current.s[c.Y][c.X] {
+			continue
+		}
+		next.s[c.Y][c.X] = alive
+		flips.flip(c.X, c.Y, alive)
+		if alive {
+			next.pop++
+		} else {
+			next.pop--
+		}
+		if e.tracked {
+			if len(e.changed) == limit {
+				// Too much is going on, compute the next generation with a full scan.
+				e.tracked = false
+			} else {
+				e.changed = append(e.changed, c)
+			}
+		}
+	}
+	flips.apply(current, next)
+	e.evaluated += uint64(len(e.candidates))
+}
@@ -0,0 +1,142 @@
+package life
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Lexicon holds the patterns of a file in the format of the Life Lexicon, in which every entry starts with its name
+// between colons at the start of a line, followed by its definition:
+//
+//	:glider: (c/4 diagonally, p4) The smallest spaceship.
+//		.O
+//		..O
+//		OOO
+//
+// The patterns are the diagrams of the entries, lines that are indented and hold nothing but '.' for dead cells and
+// 'O' or '*' for live ones. Entries without a diagram are left out, and of entries with several, the first is taken.
+// Only the diagrams are kept, and they are turned into fields when they are asked for.
+type Lexicon struct {
+	// entries maps the lower case names to the entries.
+	entries  map[string]lexiconEntry
+	warnings []ParseWarning
+}
+
+// lexiconEntry is an entry of a Lexicon with a diagram.
+type lexiconEntry struct {
+	name string
+	// line is the number of the line of the first row of the diagram.
+	line int
+	rows []string
+}
+
+// OpenLexicon reads the entries of the Life Lexicon in r. An error is only returned if reading fails: entries
+// that can't be read are left out, and what is wrong with them is returned by Lexicon.Warnings.
+func OpenLexicon(r io.Reader) (*Lexicon, error) {
+	l := &Lexicon{entries: make(map[string]lexiconEntry)}
+	var e *lexiconEntry
+	// done is set once the diagram of the current entry is over, as only the first one is kept.
+	done := false
+	add := func() {
+		if e == nil || len(e.rows) == 0 {
+			return
+		}
+		for _, row := range e.rows {
+			if len(row) != len(e.rows[0]) {
+				l.warn(e.line, "the rows of the diagram of %q differ in length, the entry is skipped", e.name)
+				return
+			}
+		}
+		key := strings.ToLower(e.name)
+		if _, ok := l.entries[key]; ok {
+			l.warn(e.line, "%q is defined again and is skipped", e.name)
+			return
+		}
+		l.entries[key] = *e
+	}
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimRight(s.Text(), " \t\r")
+		if strings.HasPrefix(line, ":") {
+			add()
+			end := strings.IndexByte(line[1:], ':')
+			if end <= 0 {
+				l.warn(n, "the name of the entry isn't closed by ':'")
+				e = nil
+				continue
+			}
+			e, done = &lexiconEntry{name: line[1 : end+1]}, false
+			continue
+		}
+		row := strings.TrimLeft(line, " \t")
+		if e == nil || done || len(row) == len(line) || !isDiagramRow(row) {
+			// The first line that isn't a row of the diagram ends it.
+			if e != nil && len(e.rows) != 0 {
+				done = true
+			}
+			continue
+		}
+		if len(e.rows) == 0 {
+			e.line = n
+		}
+		e.rows = append(e.rows, row)
+	}
+	add()
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// isDiagramRow reports whether row is a row of a diagram.
+func isDiagramRow(row string) bool {
+	for _, c := range row {
+		if c != '.' && c != 'O' && c != '*' {
+			return false
+		}
+	}
+	return row != ""
+}
+
+func (l *Lexicon) warn(line int, format string, a ...interface{}) {
+	l.warnings = append(l.warnings, ParseWarning{line, fmt.Sprintf(format, a...)})
+}
+
+// Names returns the names of the entries with a diagram, sorted case-insensitively.
+func (l *Lexicon) Names() []string {
+	keys := make([]string, 0, len(l.entries))
+	for key := range l.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = l.entries[key].name
+	}
+	return names
+}
+
+// Get returns a new field, which doesn't wrap, holding the diagram of the named entry. Names are matched
+// case-insensitively.
+func (l *Lexicon) Get(name string) (*Field, error) {
+	e, ok := l.entries[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("the lexicon has no pattern %q", name)
+	}
+	f := NewField(uint(len(e.rows[0])), uint(len(e.rows)), false)
+	for y, row := range e.rows {
+		for x, c := range row {
+			f.s[y][x] = c != '.'
+		}
+	}
+	f.recount()
+	return f, nil
+}
+
+// Warnings returns what is wrong with the entries that were left out, in the order of their lines.
+func (l *Lexicon) Warnings() []ParseWarning {
+	return l.warnings
+}
@@ -0,0 +1,92 @@
+package life
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testLexicon = `THE LIFE LEXICON
+
+Introduction, which isn't an entry.
+	It mentions OO in passing.
+
+:acorn: (5206) A methuselah.
+  Its definition goes on for a few
+  lines before the diagram.
+	.O.....
+	...O...
+	OO..OOO
+  See also {R-pentomino}.
+
+:block: (p1) The most common still life.
+	OO
+	OO
+
+:blinker: (p2) The smallest oscillator, shown in both phases.
+	***
+  which becomes
+	.*.
+	.*.
+	.*.
+
+:generation: A definition without a diagram.
+
+:R-pentomino: The most active of the pentominoes.
+	.OO
+	OO.
+	.O.
+
+:broken: A diagram with rows of different lengths.
+	OO.
+	O
+
+:block: The block again.
+	OO
+	OO
+
+:unclosed name
+	OO
+`
+
+func TestLexicon(t *testing.T) {
+	l, err := OpenLexicon(strings.NewReader(testLexicon))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := l.Names(), []string{"acorn", "blinker", "block", "R-pentomino"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got the names %q, wanted %q", got, want)
+	}
+	testCases := []struct {
+		name string
+		want *Field
+	}{
+		{"acorn", fieldFromRows(false, ".o.....", "...o...", "oo..ooo")},
+		{"BLOCK", fieldFromRows(false, "oo", "oo")},
+		// Only the first diagram is the pattern.
+		{"blinker", fieldFromRows(false, "ooo")},
+		{"r-pentomino", fieldFromRows(false, ".oo", "oo.", ".o.")},
+	}
+	for _, tc := range testCases {
+		f, err := l.Get(tc.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !equalCells(f, tc.want) || f.Population() != tc.want.Population() {
+			t.Errorf("%s: got\n%swanted\n%s", tc.name, f, tc.want)
+		}
+	}
+	for _, name := range []string{"generation", "broken", "unclosed name", "glider"} {
+		if _, err := l.Get(name); err == nil {
+			t.Errorf("got no error for %q", name)
+		}
+	}
+	want := []ParseWarning{
+		{33, `the rows of the diagram of "broken" differ in length, the entry is skipped`},
+		{37, `"block" is defined again and is skipped`},
+		{40, "the name of the entry isn't closed by ':'"},
+	}
+	if !reflect.DeepEqual(l.Warnings(), want) {
+		t.Errorf("got the warnings %v, wanted %v", l.Warnings(), want)
+	}
+}
@@ -1,9 +1,12 @@
-package main
+// Package life implements Conway's Game of Life on a finite, optionally toroidal, field.
+package life
 
 import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"io"
+	"math"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -17,20 +20,187 @@ type Field struct {
 	s             [][]bool
 	width, height uint
 	wrap          bool
+	rule          Rule
+	// pop is the number of live cells.
+	pop uint
+	// edits counts the changes made through Set, so engines that keep state between steps can detect them.
+	edits uint64
+	// hash is the XOR of the cell keys of all live cells, see Game.Hash.
+	hash uint64
+	// births and deaths count the cells that came to life and died in the tick that computed the field, see
+	// Game.Stats.
+	births, deaths uint
+	// age is the optional age layer, see Game.TrackAges.
+	age [][]uint32
+}
+
+// MaxCells is the most cells a field may have. It keeps sizes that are mistakes, like those of corrupt files, from
+// taking all of the memory or overflowing.
+const MaxCells = 1 << 32
+
+// CheckSize returns an error unless a field of the given width and height can be created: both must be positive,
+// and the field may have at most MaxCells cells.
+func CheckSize(width, height uint) error {
+	if width == 0 || height == 0 {
+		return fmt.Errorf("invalid size %dx%d: the width and height must be positive", width, height)
+	}
+	if uint64(width) > MaxCells || uint64(height) > MaxCells/uint64(width) {
+		return fmt.Errorf("invalid size %dx%d: a field may have at most %d cells", width, height, uint64(MaxCells))
+	}
+	return nil
 }
 
 // NewField allocates a new empty board of the given height and width.
+// It panics if the size is invalid, see CheckSize and NewFieldE.
 func NewField(width, height uint, wrap bool) *Field {
+	if err := CheckSize(width, height); err != nil {
+		panic("life: " + err.Error())
+	}
 	s := make([][]bool, height)
 	for i := range s {
 		s[i] = make([]bool, width)
 	}
-	return &Field{s: s, width: width, height: height, wrap: wrap}
+	return &Field{s: s, width: width, height: height, wrap: wrap, rule: Conway}
+}
+
+// NewFieldE is like NewField, but returns an error instead of panicking if the size is invalid.
+func NewFieldE(width, height uint, wrap bool) (*Field, error) {
+	if err := CheckSize(width, height); err != nil {
+		return nil, err
+	}
+	return NewField(width, height, wrap), nil
 }
 
 // Set sets the value v to the cell with position x,y on the field.
+// If the field keeps track of ages, a cell that is brought to life becomes a newborn.
 func (f *Field) Set(x, y uint, v bool) {
+	if f.s[y][x] == v {
+		return
+	}
 	f.s[y][x] = v
+	f.edits++
+	f.hash ^= cellKey(x, y)
+	var age uint32
+	if v {
+		f.pop++
+		age = 1
+	} else {
+		f.pop--
+	}
+	if f.age != nil {
+		f.age[y][x] = age
+	}
+}
+
+// Width returns the number of columns of the field.
+func (f *Field) Width() uint {
+	return f.width
+}
+
+// Height returns the number of rows of the field.
+func (f *Field) Height() uint {
+	return f.height
+}
+
+// Population returns the number of live cells on the field.
+func (f *Field) Population() uint {
+	return f.pop
+}
+
+// BoundingBox returns the top-left and bottom-right corners of the smallest rectangle containing all live cells.
+// ok is false if there are no live cells.
+func (f *Field) BoundingBox() (min, max Cell, ok bool) {
+	for y, row := range f.s {
+		for x, alive := range row {
+			if !alive {
+				continue
+			}
+			c := Cell{uint(x), uint(y)}
+			if !ok {
+				min, max, ok = c, c, true
+				continue
+			}
+			if c.X < min.X {
+				min.X = c.X
+			}
+			if c.X > max.X {
+				max.X = c.X
+			}
+			max.Y = c.Y
+		}
+	}
+	return min, max, ok
+}
+
+// EachLive calls fn for every live cell, row by row from the top-left.
+func (f *Field) EachLive(fn func(x, y uint)) {
+	for y, row := range f.s {
+		for x, alive := range row {
+			if alive {
+				fn(uint(x), uint(y))
+			}
+		}
+	}
+}
+
+// Place copies the live cells of pattern onto f with the top-left corner of pattern at position x,y.
+// Cells of f that are dead in pattern are left untouched.
+// An error is returned if pattern doesn't fit on f at that position.
+func (f *Field) Place(pattern *Field, x, y uint) error {
+	if x+pattern.width > f.width || y+pattern.height > f.height || x+pattern.width < x || y+pattern.height < y {
+		return fmt.Errorf("%dx%d pattern at %d,%d doesn't fit on a %dx%d field", pattern.width, pattern.height, x, y, f.width, f.height)
+	}
+	pattern.EachLive(func(px, py uint) {
+		f.Set(x+px, y+py, true)
+	})
+	return nil
+}
+
+// Clone returns a copy of f, with the same cells, wrapping, rule and ages.
+func (f *Field) Clone() *Field {
+	c := NewField(f.width, f.height, f.wrap)
+	for y, row := range f.s {
+		copy(c.s[y], row)
+	}
+	c.rule, c.pop, c.hash, c.births, c.deaths = f.rule, f.pop, f.hash, f.births, f.deaths
+	if f.age != nil {
+		c.age = newAges(f.width, f.height)
+		for y, row := range f.age {
+			copy(c.age[y], row)
+		}
+	}
+	return c
+}
+
+// Resized returns a copy of f of the given size, like Clone, holding the cells of f that lie within it at the same
+// positions. Cells added on the right and bottom are dead.
+func (f *Field) Resized(width, height uint) *Field {
+	c := NewField(width, height, f.wrap)
+	for y := uint(0); y < height && y < f.height; y++ {
+		copy(c.s[y], f.s[y])
+	}
+	c.rule = f.rule
+	c.recount()
+	if f.age != nil {
+		c.age = newAges(width, height)
+		for y := uint(0); y < height && y < f.height; y++ {
+			copy(c.age[y], f.age[y])
+		}
+	}
+	return c
+}
+
+// recount recomputes the population and hash of the field, after the cells were modified without Set.
+func (f *Field) recount() {
+	f.pop, f.hash = 0, 0
+	for y, row := range f.s {
+		for x, alive := range row {
+			if alive {
+				f.pop++
+				f.hash ^= cellKey(uint(x), uint(y))
+			}
+		}
+	}
 }
 
 // Alive reports whether the cell at position x,y is alive or dead.
@@ -52,45 +222,108 @@ func (f *Field) Alive(x, y int) bool {
 	return f.s[y][x]
 }
 
-// Future returns the state of the cell at position x,y at the next tick.
-// A cell is alive if:
-//  - Any live cell with fewer than two live neighbours dies, as if by underpopulation.
-//  - Any live cell with two or three live neighbours lives on to the next generation.
-//  - Any live cell with more than three live neighbours dies, as if by overpopulation.
-//  - Any dead cell with exactly three live neighbours becomes a live cell, as if by reproduction.
+// Future returns the state of the cell at position x,y at the next tick according to the rule of the field,
+// which is Conway's by default:
+//   - Any live cell with fewer than two live neighbours dies, as if by underpopulation.
+//   - Any live cell with two or three live neighbours lives on to the next generation.
+//   - Any live cell with more than three live neighbours dies, as if by overpopulation.
+//   - Any dead cell with exactly three live neighbours becomes a live cell, as if by reproduction.
 func (f *Field) Future(x, y uint) bool {
 	var aliveNeighbours uint8
-	ix, iy := int(x), int(y)
+	xs, ys := neighbourhood(x, f.width, f.wrap), neighbourhood(y, f.height, f.wrap)
 	// Start at position x-1,y-1 (top left corner) and work our way through the neighbouring cells.
 	//	[
 	//		[0, 0, 0]
 	//		[0, 1, 0]
 	//		[0, 0, 0]
 	//	]
-	for i := -1; i <= 1; i++ {
-		for j := -1; j <= 1; j++ {
-			if (j != 0 || i != 0) && f.Alive(ix+i, iy+j) {
+	for j, ny := range ys {
+		if ny < 0 {
+			continue
+		}
+		for i, nx := range xs {
+			if (i != 1 || j != 1) && nx >= 0 && f.s[ny][nx] {
 				aliveNeighbours++
 			}
 		}
 	}
-	return aliveNeighbours == 3 || aliveNeighbours == 2 && f.Alive(ix, iy)
+	return f.rule.Next(f.s[y][x], aliveNeighbours)
 }
 
-// String is a string representation of the current state.
-func (f *Field) String() string {
-	w := new(strings.Builder)
-	for y := 0; y < int(f.height); y++ {
-		for x := 0; x < int(f.width); x++ {
-			if f.Alive(x, y) {
-				w.WriteRune('█')
+// neighbourhood returns the indices before, at and after i in a dimension of the given size.
+// Indices past the edges wrap around if wrap is set, and are -1 otherwise.
+func neighbourhood(i, size uint, wrap bool) [3]int {
+	n := [3]int{int(i) - 1, int(i), int(i) + 1}
+	if n[0] < 0 {
+		n[0] = -1
+		if wrap {
+			n[0] = int(size) - 1
+		}
+	}
+	if n[2] >= int(size) {
+		n[2] = -1
+		if wrap {
+			n[2] = 0
+		}
+	}
+	return n
+}
+
+const (
+	aliveGlyph = "█"
+	deadGlyph  = " "
+)
+
+// AppendTo appends the string representation of the current state to buf and returns the extended buffer.
+// Reusing the returned buffer across calls avoids allocating a new string for every frame.
+func (f *Field) AppendTo(buf []byte) []byte {
+	for y := uint(0); y < f.height; y++ {
+		for _, alive := range f.s[y] {
+			if alive {
+				buf = append(buf, aliveGlyph...)
+			} else {
+				buf = append(buf, deadGlyph...)
+			}
+		}
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+// WriteTo writes the string representation of the current state to w.
+// It implements io.WriterTo.
+func (f *Field) WriteTo(w io.Writer) (int64, error) {
+	var (
+		buf [4096]byte
+		n   int64
+	)
+	b := buf[:0]
+	for y := uint(0); y < f.height; y++ {
+		for _, alive := range f.s[y] {
+			// Flush whenever the next cell and new line might not fit anymore.
+			if len(b) > len(buf)-len(aliveGlyph)-1 {
+				m, err := w.Write(b)
+				n += int64(m)
+				if err != nil {
+					return n, err
+				}
+				b = buf[:0]
+			}
+			if alive {
+				b = append(b, aliveGlyph...)
 			} else {
-				w.WriteRune(' ')
+				b = append(b, deadGlyph...)
 			}
 		}
-		w.WriteRune('\n')
+		b = append(b, '\n')
 	}
-	return w.String()
+	m, err := w.Write(b)
+	return n + int64(m), err
+}
+
+// String is a string representation of the current state.
+func (f *Field) String() string {
+	return string(f.AppendTo(make([]byte, 0, (f.width*uint(len(aliveGlyph))+1)*f.height)))
 }
 
 // Game stores the state of a round of Conway's Game of Life.
@@ -99,38 +332,151 @@ type Game struct {
 	width, height uint
 	wrap          bool
 	comment       string
+	warnings      []ParseWarning
+	generation    uint
+	header        bool
+	engine        Engine
+	// posX and posY are the position of the top-left cell of a loaded pattern given by its #CXRLE line, if hasPos is
+	// set, see WithGrid.
+	posX, posY int
+	hasPos     bool
+	// heat is the optional heat layer, see Game.TrackHeat.
+	heat *HeatMap
+	// recording is set while the game is recorded, see Game.StartRecording.
+	recording *recording
+	// noise is the probability of a cell to flip after every tick, drawn from noiseRand, see Game.SetPerturbation.
+	noise     float64
+	noiseRand *rand.Rand
 }
 
-// uintn is basically Intn but casted to uintn
-func uintn(n uint) uint {
-	return uint(rand.Intn(int(n)))
-}
+// DefaultDensity is the probability of a cell being alive in the random initial state of NewGame.
+const DefaultDensity = 0.25
 
-// NewGame returns a new Life game state with a random initial state.
+// NewGame returns a new Life game state with a random initial state, in which every cell is alive with a
+// probability of DefaultDensity. The cells are drawn from the default source of math/rand, see NewRandomGame for
+// games that can be reproduced. It panics if the size is invalid, see CheckSize and NewGameE.
+//
+// Deprecated: Use New, which takes the settings as options, e.g. New(width, height, WithWrap(wrap)).
 func NewGame(width, height uint, wrap bool) *Game {
 	current := NewField(width, height, wrap)
-	for i := 0; i < int(width*height/4); i++ {
-		current.Set(uintn(width), uintn(height), true)
-	}
+	seedField(current, DefaultDensity, rand.Float64)
 	return &Game{
 		current: current,
 		next:    NewField(width, height, wrap),
 		width:   width,
 		height:  height,
+		wrap:    wrap,
+	}
+}
+
+// NewGameE is like NewGame, but returns an error instead of panicking if the size is invalid.
+//
+// Deprecated: Use New, which returns an error as well.
+func NewGameE(width, height uint, wrap bool) (*Game, error) {
+	if err := CheckSize(width, height); err != nil {
+		return nil, err
+	}
+	return NewGame(width, height, wrap), nil
+}
+
+// NewRandomGame is like NewGame, but every cell is alive with probability p and the cells are drawn from r instead
+// of the default source, which anything else in the program may draw from as well. The same arguments with a
+// source of the same seed always give the same game, on any machine. It panics if the size is invalid, see
+// CheckSize.
+//
+// Deprecated: Use New with the options WithWrap, WithDensity and WithRand.
+func NewRandomGame(width, height uint, wrap bool, p float64, r *rand.Rand) *Game {
+	f := NewField(width, height, wrap)
+	f.Randomize(p, r)
+	return NewGameFromField(f)
+}
+
+// seedField brings every cell of f to life with probability p, using random to draw numbers in [0, 1).
+// Drawing per cell, rather than drawing random positions, visits every cell exactly once, however large f is.
+func seedField(f *Field, p float64, random func() float64) {
+	for _, row := range f.s {
+		for x := range row {
+			row[x] = random() < p
+		}
+	}
+	f.recount()
+}
+
+// Randomize brings every cell of f to life with probability p and kills the others, drawing the numbers from r.
+// The same p and a source with the same seed always give the same cells. Ages start over.
+func (f *Field) Randomize(p float64, r *rand.Rand) {
+	seedField(f, p, r.Float64)
+	f.edits++
+	if f.age != nil {
+		for y, row := range f.s {
+			for x, alive := range row {
+				f.age[y][x] = 0
+				if alive {
+					f.age[y][x] = 1
+				}
+			}
+		}
+	}
+}
+
+// Fill sets every cell of f to what fn returns for its position, like Set does, so the population, the hash and
+// the ages are kept up to date. fn is called exactly once per cell, row by row from the top and from left to right
+// within a row, so that functions with state of their own, like a stream of noise, always give the same field.
+func (f *Field) Fill(fn func(x, y uint) bool) {
+	for y := uint(0); y < f.height; y++ {
+		for x := uint(0); x < f.width; x++ {
+			f.Set(x, y, fn(x, y))
+		}
+	}
+}
+
+// NewGameFromFunc returns a new Life game state of the given size whose initial state is computed by fn for every
+// cell, e.g. to seed stripes, discs or patterns of noise, see Field.Fill for the order fn is called in.
+// An error is returned if the size is invalid, see CheckSize.
+func NewGameFromFunc(width, height uint, wrap bool, fn func(x, y uint) bool) (*Game, error) {
+	f, err := NewFieldE(width, height, wrap)
+	if err != nil {
+		return nil, err
+	}
+	f.Fill(fn)
+	return NewGameFromField(f), nil
+}
+
+// NewGameFromField returns a new Life game state with f as its initial state. The game takes ownership of f.
+func NewGameFromField(f *Field) *Game {
+	next := NewField(f.width, f.height, f.wrap)
+	next.rule = f.rule
+	return &Game{
+		current: f,
+		next:    next,
+		width:   f.width,
+		height:  f.height,
+		wrap:    f.wrap,
 	}
 }
 
 var (
 	widthHeightRegex = regexp.MustCompile(`\d+`)
 	lifeRuleRegex    = regexp.MustCompile(`(?i)b3/s23`)
-	itemRegex        = regexp.MustCompile(`(?U)\d+[b|o]+|[b|o]`)
-	rule             = []byte{'r', 'u', 'l', 'e'}
+	ruleRegex        = regexp.MustCompile(`rule\s*=\s*(\S*)`)
+	cxrlePosRegex    = regexp.MustCompile(`Pos\s*=\s*(-?\d+)\s*,\s*(-?\d+)`)
 )
 
-// LoadGame loads a Life game state from a run-length encoded file.
-// The file must have the .rle extension.
+// LoadGame loads a Life game state from a run-length encoded file, or from a PBM image, see ReadPBM.
+// The file must have the .rle or the .pbm extension. A name like "collection.zip!patterns/glider.rle" loads the file
+// patterns/glider.rle from the zip archive collection.zip, see LoadGameZip.
 // An error is returned if an error occurred when reading the file or when parsing the contents.
-func LoadGame(filename string, wrap bool) (*Game, error) {
+//
+// The options WithWrap, which overrides wrap, WithRule and WithEngine apply to the loaded game, and WithGrid and
+// WithOffset put it on a larger field. The options for random initial states are an error.
+func LoadGame(filename string, wrap bool, opts ...Option) (*Game, error) {
+	if i := strings.Index(strings.ToLower(filename), ".zip!"); i >= 0 {
+		return LoadGameZip(filename[:i+len(".zip")], filename[i+len(".zip!"):], wrap, opts...)
+	}
+	o, err := loadOptions(wrap, opts)
+	if err != nil {
+		return nil, err
+	}
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, err
@@ -142,28 +488,172 @@ func LoadGame(filename string, wrap bool) (*Game, error) {
 	} else if info.IsDir() {
 		return nil, fmt.Errorf("%s is a directory", filename)
 	}
-	if filepath.Ext(filename) != ".rle" {
-		return nil, fmt.Errorf("only RLE files are supported currently")
+	return readFile(f, filename, o)
+}
+
+// loadOptions returns the options of LoadGame and LoadGameZip.
+func loadOptions(wrap bool, opts []Option) (options, error) {
+	o := defaultOptions()
+	o.wrap = wrap
+	if err := o.apply(opts); err != nil {
+		return o, err
+	}
+	if o.random != "" {
+		return o, fmt.Errorf("%s doesn't apply to loaded patterns", o.random)
+	}
+	if o.hasOffset && o.gridWidth == 0 {
+		return o, fmt.Errorf("WithOffset requires WithGrid")
+	}
+	return o, nil
+}
+
+// readFile reads a game from r in the format given by the extension of the file name, and applies o to it.
+func readFile(r io.Reader, name string, o options) (*Game, error) {
+	var g *Game
+	var err error
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".rle":
+		g, err = readRLE(r, o.wrap)
+	case ".pbm":
+		g, err = ReadPBM(r, o.wrap)
+	default:
+		return nil, fmt.Errorf("only RLE files and PBM images are supported currently")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := o.setUp(g); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// setUp applies the options that are left once a pattern is read to its game g.
+func (o options) setUp(g *Game) error {
+	if o.gridWidth != 0 {
+		if err := g.moveToGrid(o); err != nil {
+			return err
+		}
+	}
+	if o.hasRule {
+		g.SetRule(o.rule)
+	}
+	g.engine = o.engine
+	return nil
+}
+
+// moveToGrid puts the pattern of g on an empty field of the size of WithGrid, at the position of WithOffset, of its
+// #CXRLE line or in the middle, see WithGrid.
+func (g *Game) moveToGrid(o options) error {
+	p := g.current
+	// The field keeps the topology of the pattern, which may be that of a bounded grid, unless WithWrap says otherwise.
+	wrap := p.wrap
+	if o.hasWrap {
+		wrap = o.wrap
+	}
+	f, err := NewFieldE(o.gridWidth, o.gridHeight, wrap)
+	if err != nil {
+		return err
+	}
+	f.rule = p.rule
+	if p.width > f.width || p.height > f.height {
+		return fmt.Errorf("the %dx%d pattern doesn't fit on a %dx%d grid", p.width, p.height, f.width, f.height)
+	}
+	x, y := (f.width-p.width)/2, (f.height-p.height)/2
+	switch {
+	case o.hasOffset:
+		x, y = o.offsetX, o.offsetY
+	case g.hasPos:
+		// Golly puts 0,0 on the middle cell of a bounded grid, or the one right of and below the middle.
+		px, py := int64(f.width/2)+int64(g.posX), int64(f.height/2)+int64(g.posY)
+		if px < 0 || py < 0 {
+			return fmt.Errorf("the pattern at Pos=%d,%d lies beyond the top-left of a %dx%d grid",
+				g.posX, g.posY, f.width, f.height)
+		}
+		x, y = uint(px), uint(py)
+	}
+	if err := f.Place(p, x, y); err != nil {
+		return err
+	}
+	g.current, g.next = f, NewField(f.width, f.height, f.wrap)
+	g.next.rule = f.rule
+	g.width, g.height, g.wrap = f.width, f.height, f.wrap
+	return nil
+}
+
+// ParseWarning is a problem with a pattern that doesn't keep it from being read, see Game.Warnings.
+type ParseWarning struct {
+	// Line is the number of the line with the problem, starting at 1.
+	Line    int
+	Message string
+}
+
+func (w ParseWarning) String() string {
+	return fmt.Sprintf("line %d: %s", w.Line, w.Message)
+}
+
+// ReadGame reads a Life game state in the run-length encoded format from r.
+// An error is returned if an error occurred when reading or when parsing the contents. Problems that don't keep
+// the pattern from being read are returned by Game.Warnings instead.
+//
+// The rule may end in the bounded grid of Golly, e.g. "rule = B3/S23:T50,20" for a torus of 50x20 cells or
+// ":P30,30" for a plane of 30x30 cells. The field then has the size of the grid and wraps if it is a torus, whatever
+// wrap is, and the pattern of the size given by x and y is put in its middle, as Golly does. Other kinds of grids,
+// shifted tori and grids that are infinite in one direction aren't supported.
+//
+// The options apply as with LoadGame.
+func ReadGame(r io.Reader, wrap bool, opts ...Option) (*Game, error) {
+	o, err := loadOptions(wrap, opts)
+	if err != nil {
+		return nil, err
+	}
+	g, err := readRLE(r, o.wrap)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.setUp(g); err != nil {
+		return nil, err
 	}
+	return g, nil
+}
+
+// readRLE reads a game in the run-length encoded format from r, see ReadGame.
+func readRLE(r io.Reader, wrap bool) (*Game, error) {
 	comment := new(strings.Builder)
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(r)
 	game := new(Game)
-	game.wrap = true
+	game.wrap = wrap
+	// offsetX and offsetY are the position of the pattern on a bounded grid.
+	var offsetX, offsetY uint
+	n := 0
 	for scanner.Scan() {
+		n++
 		if line := scanner.Bytes(); len(line) > 0 {
 			if len(line) > 70 {
 				// Lines in the RLE file must not exceed 70 characters, although it is a good idea for RLE readers to be able to cope with longer lines.
-				fmt.Printf("warning: line exceeds 70 characters: %s\n", line)
+				game.warnings = append(game.warnings, ParseWarning{n, fmt.Sprintf("exceeds 70 characters and is skipped: %s", line)})
 				continue
 			}
 			if line[0] == '#' {
 				// Comment line
 				// Skip the 3 preceding bytes and append a new line for printing purposes.
 				comment.Write(append(line[3:], '\n'))
+				if bytes.HasPrefix(line, []byte("#CXRLE")) {
+					if err := game.readPos(line); err != nil {
+						game.warnings = append(game.warnings, ParseWarning{n, err.Error()})
+					}
+				}
 			} else if line[0] == 'x' {
-				// Alternative rules are not supported.
-				if bytes.Contains(line, rule) && !lifeRuleRegex.Match(line) {
-					return nil, fmt.Errorf("rules are not supported")
+				var grid string
+				if m := ruleRegex.FindSubmatch(line); m != nil {
+					rule := string(m[1])
+					if i := strings.IndexByte(rule, ':'); i >= 0 {
+						rule, grid = rule[:i], rule[i+1:]
+					}
+					// Alternative rules are not supported.
+					if !lifeRuleRegex.MatchString(rule) {
+						return nil, fmt.Errorf("rules are not supported")
+					}
 				}
 				widthHeight := widthHeightRegex.FindAll(line, 2)
 				if len(widthHeight) != 2 {
@@ -178,28 +668,68 @@ func LoadGame(filename string, wrap bool) (*Game, error) {
 					return nil, err
 				}
 				game.width, game.height = uint(width), uint(height)
-				game.current = NewField(game.width, game.height, wrap)
+				if game.current, err = NewFieldE(game.width, game.height, wrap); err != nil {
+					return nil, err
+				}
+				if grid != "" {
+					gridWidth, gridHeight, gridWrap, err := parseGrid(grid)
+					if err != nil {
+						return nil, err
+					}
+					if game.width > gridWidth || game.height > gridHeight {
+						return nil, fmt.Errorf("the %dx%d pattern doesn't fit on the bounded grid :%s", game.width, game.height, grid)
+					}
+					// The middle cell of the pattern is put on the middle cell of the grid.
+					offsetX, offsetY = gridWidth/2-game.width/2, gridHeight/2-game.height/2
+					if game.current, err = NewFieldE(gridWidth, gridHeight, gridWrap); err != nil {
+						return nil, err
+					}
+				}
 			} else {
 				// If we haven't encountered a header line this file is invalid.
 				if game.current == nil {
 					return nil, fmt.Errorf("invalid RLE format")
 				}
 				// An exclamation mark marks the end of the configuration.
+				// The scanner reuses its buffer, so the lines have to be joined in a copy.
+				line = append([]byte(nil), line...)
 				for !bytes.ContainsRune(line, '!') {
-					scanner.Scan()
+					if !scanner.Scan() {
+						return nil, fmt.Errorf("pattern is not terminated by '!'")
+					}
+					n++
 					line = append(line, scanner.Bytes()...)
 				}
-				game.current.s = make([][]bool, game.height)
-				// Dead cells at the end of the last line of the pattern do not need to be encoded.
-				definedCells := bytes.Split(line, []byte{'$'})
-				for i, item := range definedCells {
-					if game.current.s[i], err = generateLine(item, game.width); err != nil {
+				// The rows of the field are already allocated and dead, only live cells have to be written.
+				y := uint(0)
+				for _, item := range bytes.Split(line, []byte{'$'}) {
+					// A run count at the end of an item belongs to the '$' and stands for that many line ends.
+					i := len(item)
+					for i > 0 && item[i-1] >= '0' && item[i-1] <= '9' {
+						i--
+					}
+					if y >= game.height {
+						if bytes.ContainsAny(item[:i], "bo") {
+							return nil, fmt.Errorf("pattern exceeds the height of %d", game.height)
+						}
+						break
+					}
+					if err := generateLine(item[:i], game.current.s[offsetY+y][offsetX:offsetX+game.width]); err != nil {
 						return nil, err
 					}
-				}
-				// Define any dead cells if necessary.
-				for i := uint(len(definedCells)); i < game.height; i++ {
-					game.current.s[i] = make([]bool, game.width)
+					y++
+					if i < len(item) {
+						n, err := strconv.ParseUint(string(item[i:]), 10, 64)
+						if err != nil {
+							return nil, err
+						}
+						if n > uint64(game.height-y) {
+							n = uint64(game.height - y + 1)
+						}
+						if n > 1 {
+							y += uint(n - 1)
+						}
+					}
 				}
 			}
 		}
@@ -208,64 +738,185 @@ func LoadGame(filename string, wrap bool) (*Game, error) {
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
+	if game.current == nil {
+		return nil, fmt.Errorf("invalid RLE format: missing header line")
+	}
+	game.current.recount()
 	game.comment = comment.String()
-	game.next = NewField(game.width, game.height, wrap)
+	game.width, game.height, game.wrap = game.current.width, game.current.height, game.current.wrap
+	game.next = NewField(game.width, game.height, game.wrap)
 	return game, nil
 }
 
-// <tag>	description
-//   b		dead cell
-//   o		alive cell
-func tag2bool(tag byte) bool {
-	return tag == 'o'
+// readPos reads the position of the pattern from the Pos of a #CXRLE line, e.g. "#CXRLE Pos=-5,-3 Gen=120".
+func (g *Game) readPos(line []byte) error {
+	m := cxrlePosRegex.FindSubmatch(line)
+	if m == nil {
+		return nil
+	}
+	x, err := strconv.Atoi(string(m[1]))
+	if err != nil {
+		return fmt.Errorf("ignoring the position %s: %w", m[0], err)
+	}
+	y, err := strconv.Atoi(string(m[2]))
+	if err != nil {
+		return fmt.Errorf("ignoring the position %s: %w", m[0], err)
+	}
+	g.posX, g.posY, g.hasPos = x, y, true
+	return nil
 }
 
-// generateLine generates a slice representing one pattern line from an RLE file.
+// generateLine decodes one pattern line from an RLE file into row, which must be dead.
 // It follows all standards proposed by: https://conwaylife.com/wiki/Run_Length_Encoded#Description_of_format.
-// This function may be considered as dangerous because it makes an allocation with a size specified by the user.
-// An attacker could theoretically starve memory with this function.
-func generateLine(rawItem []byte, width uint) ([]bool, error) {
-	line := make([]bool, 0, width)
-	for _, item := range itemRegex.FindAll(rawItem, -1) {
-		if len(item) == 1 {
-			// run_count was omitted because it's equal to 1.
-			line = append(line, tag2bool(item[0]))
-		} else {
-			// run_count not emitted.
-			rawRunCount, tag := item[:len(item)-1], item[len(item)-1]
-			runCount, err := strconv.ParseUint(string(rawRunCount), 0, 64)
-			if err != nil {
-				return nil, err
+// A run count that isn't directly followed by a tag is ignored, like any other character that isn't a tag.
+func generateLine(item []byte, row []bool) error {
+	var x, runCount uint64
+	counted := false
+	for _, c := range item {
+		switch {
+		case c >= '0' && c <= '9':
+			if runCount > (math.MaxUint64-9)/10 {
+				return fmt.Errorf("run count %s... is too large", item)
+			}
+			runCount, counted = runCount*10+uint64(c-'0'), true
+		case c == 'b' || c == 'o':
+			// run_count is omitted when it's equal to 1.
+			if !counted {
+				runCount = 1
 			}
-			values := make([]bool, runCount)
-			// Because our slice is initialized with all 0 values (false), we only need set values when it's necessary.
-			if tag2bool(tag) {
-				for i := range values {
-					values[i] = true
+			if runCount > uint64(len(row))-x {
+				return fmt.Errorf("pattern exceeds the width of %d", len(row))
+			}
+			// <tag>	description
+			//   b		dead cell
+			//   o		alive cell
+			// Dead cells were never set, so only live cells need to be written.
+			if c == 'o' {
+				for i := x; i < x+runCount; i++ {
+					row[i] = true
 				}
 			}
-			line = append(line, values...)
+			x += runCount
+			runCount, counted = 0, false
+		default:
+			runCount, counted = 0, false
 		}
 	}
 	// Dead cells at the end of a pattern line do not need to be encoded.
-	return append(line, make([]bool, width-uint(len(line)))...), nil
+	return nil
 }
 
 // Tick is a single discrete moment when births and deaths are processed.
 func (g *Game) Tick() {
-	for y := uint(0); y < g.height; y++ {
-		for x := uint(0); x < g.width; x++ {
-			g.next.Set(x, y, g.current.Future(x, y))
-		}
+	if g.engine == nil {
+		g.engine = NaiveEngine{}
+	}
+	g.engine.Step(g.current, g.next)
+	if g.noise > 0 {
+		g.perturb(g.current, g.next)
+	}
+	if g.current.age != nil {
+		g.next.ageFrom(g.current)
 	}
 	g.current, g.next = g.next, g.current
+	g.generation++
+	if g.heat != nil {
+		g.heat.add(g.current)
+	}
+	if g.recording != nil {
+		g.recording.record(g)
+	}
+}
+
+// Resize changes the size of the field, see Field.Resized. The cells that no longer fit die, the generation goes
+// on. Both dimensions must be positive.
+func (g *Game) Resize(width, height uint) {
+	g.current = g.current.Resized(width, height)
+	g.next = NewField(width, height, g.current.wrap)
+	g.next.rule = g.current.rule
+	if g.current.age != nil {
+		g.next.age = newAges(width, height)
+	}
+	if g.heat != nil {
+		g.heat = g.heat.resized(width, height)
+	}
+	g.width, g.height = width, height
+}
+
+// Generation returns the number of ticks since the game was created.
+func (g *Game) Generation() uint {
+	return g.generation
+}
+
+// Population returns the number of live cells.
+func (g *Game) Population() uint {
+	return g.current.pop
+}
+
+// Rule returns the rule the game is played with.
+func (g *Game) Rule() Rule {
+	return g.current.rule
+}
+
+// SetRule changes the rule the following generations are computed with.
+func (g *Game) SetRule(r Rule) {
+	g.current.rule, g.next.rule = r, r
+}
+
+// ShowHeader enables or disables the header line that String puts in front of the field, see Header.
+// The header is disabled by default.
+func (g *Game) ShowHeader(enabled bool) {
+	g.header = enabled
+}
+
+// Header returns a single line summarizing the game, e.g. "gen 1532  pop 417  rule B3/S23  120x80 torus".
+// If max is non-zero, the line is shortened to at most max characters by leaving out the trailing parts,
+// and truncated if even the generation doesn't fit.
+func (g *Game) Header(max int) string {
+	topology := "plane"
+	if g.current.wrap {
+		topology = "torus"
+	}
+	parts := [...]string{
+		"gen " + strconv.FormatUint(uint64(g.generation), 10),
+		"pop " + strconv.FormatUint(uint64(g.current.pop), 10),
+		"rule " + g.current.rule.String(),
+		strconv.FormatUint(uint64(g.width), 10) + "x" + strconv.FormatUint(uint64(g.height), 10) + " " + topology,
+	}
+	header := parts[0]
+	for _, part := range parts[1:] {
+		if max != 0 && len(header)+2+len(part) > max {
+			break
+		}
+		header += "  " + part
+	}
+	if max != 0 && len(header) > max {
+		header = header[:max]
+	}
+	return header
+}
+
+// Field returns the field holding the current game state.
+// The returned field is reused by the game and is only valid until the next call to Tick.
+func (g *Game) Field() *Field {
+	return g.current
 }
 
 // String is a string representation of the current game state.
+// If the header is enabled, it precedes the field on a line of its own, shortened to the width of the field.
 func (g *Game) String() string {
+	if g.header {
+		return g.Header(int(g.width)) + "\n" + g.current.String()
+	}
 	return g.current.String()
 }
 
+// Warnings returns the problems found when the game was read with LoadGame or ReadGame that didn't keep the pattern
+// from being read, in the order of their lines.
+func (g *Game) Warnings() []ParseWarning {
+	return g.warnings
+}
+
 // Comment returns the comment(s) of the loaded RLE file.
 // A string with length 0 is returned if there are no comments, or the game was created using NewGame.
 func (g *Game) Comment() string {
@@ -1,7 +1,13 @@
-package main
+package life
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -52,9 +58,14 @@ func TestExamples(t *testing.T) {
 				{false, false, false, false, false, false, false, false, false, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, true, false, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, true, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
+				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
+				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
+				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
+				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, true, true, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
+				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, true, false, true, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, true, false, false, false, true, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, false, true, false, false, false, false, false, false, false, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
@@ -64,11 +75,6 @@ func TestExamples(t *testing.T) {
 				{true, true, false, false, false, false, false, false, false, false, false, true, true, false, true, false, true, false, false, false, false, false, false, true, false, true, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, true, true, true, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 				{false, false, false, false, false, false, false, false, false, false, false, false, false, true, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
-				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
-				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
-				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
-				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
-				{false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false, false},
 			},
 		},
 	}
@@ -91,3 +97,550 @@ func TestExamples(t *testing.T) {
 		})
 	}
 }
+
+func TestReadGame(t *testing.T) {
+	data, err := os.ReadFile("./examples/bi-gun.rle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadGame(bytes.NewReader(data), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := LoadGame("./examples/bi-gun.rle", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalCells(got.Field(), want.Field()) || got.Comment() != want.Comment() {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+	if _, err := ReadGame(strings.NewReader(""), true); err == nil {
+		t.Error("expected an error for empty input")
+	}
+}
+
+func TestReadGameWarnings(t *testing.T) {
+	long := "#C " + strings.Repeat("long ", 15)
+	data := "#C A glider.\n" + long + "\nx = 3, y = 3\nbo$2bo$\n3o!\n" + long + "\n"
+	// Nothing may be printed, as programs like the CLI draw on standard output.
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	g, err := ReadGame(strings.NewReader(data), false)
+	os.Stdout = stdout
+	w.Close()
+	printed, _ := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(printed) != 0 {
+		t.Errorf("printed %q", printed)
+	}
+	want := []ParseWarning{
+		{2, "exceeds 70 characters and is skipped: " + long},
+		{6, "exceeds 70 characters and is skipped: " + long},
+	}
+	if !reflect.DeepEqual(g.Warnings(), want) {
+		t.Errorf("got the warnings %v, wanted %v", g.Warnings(), want)
+	}
+	if g.Population() != 5 {
+		t.Errorf("got a population of %d, wanted the 5 cells of the glider", g.Population())
+	}
+	if g, _ := LoadGame("./examples/glider.rle", false); len(g.Warnings()) != 0 {
+		t.Errorf("got the warnings %v for a valid pattern", g.Warnings())
+	}
+}
+
+func TestReadGameBoundedGrid(t *testing.T) {
+	// A blinker across the left and right edges of a torus, which only survives if the field wraps.
+	g, err := ReadGame(strings.NewReader("x = 6, y = 5, rule = B3/S23:T6,5\no3b2o!\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := g.Field(); f.Width() != 6 || f.Height() != 5 || !f.wrap {
+		t.Fatalf("got a %dx%d field that wraps: %t, wanted a 6x5 torus", f.Width(), f.Height(), f.wrap)
+	}
+	g.Tick()
+	want := fieldFromRows(true,
+		".....o",
+		".....o",
+		"......",
+		"......",
+		".....o",
+	)
+	if !equalCells(g.Field(), want) {
+		t.Errorf("got:\n%s\nwanted:\n%s", g.Field(), want)
+	}
+
+	// The plane doesn't wrap even if asked to, and the pattern is put in the middle of the grid.
+	g, err = ReadGame(strings.NewReader("x = 3, y = 1, rule = b3/s23:p6,5\n3o!\n"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = fieldFromRows(false,
+		"......",
+		"......",
+		"..ooo.",
+		"......",
+		"......",
+	)
+	if !equalCells(g.Field(), want) || g.Field().wrap {
+		t.Errorf("got:\n%s\nwanted the blinker in the middle of a 6x5 plane", g.Field())
+	}
+
+	// Wrapping fields are written with the grid of a torus, so that they are read back as one.
+	torus := fieldFromRows(true, "o.", ".o", "..")
+	b := new(strings.Builder)
+	if err := torus.WriteRLE(b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "x = 2, y = 3, rule = B3/S23:T2,3\no$bo!\n"; got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+	g, err = ReadGame(strings.NewReader(b.String()), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalCells(g.Field(), torus) || !g.Field().wrap {
+		t.Errorf("got:\n%s\nwanted the torus that was written", g.Field())
+	}
+
+	for _, test := range []struct{ header, err string }{
+		{"x = 3, y = 1, rule = B3/S23:K10,0", ":K10,0 is a Klein bottle"},
+		{"x = 3, y = 1, rule = B3/S23:C10,10", ":C10,10 is a cross-surface"},
+		{"x = 3, y = 1, rule = B3/S23:S10", ":S10 is a sphere"},
+		{"x = 3, y = 1, rule = B3/S23:T10+2,20", ":T10+2,20 is shifted or twisted"},
+		{"x = 3, y = 1, rule = B3/S23:T10,0", ":T10,0 is infinite in one direction"},
+		{"x = 3, y = 1, rule = B3/S23:T10", ":T10 doesn't give a width and a height"},
+		{"x = 3, y = 1, rule = B3/S23:Q10,10", ":Q10,10 is of the unknown kind"},
+		{"x = 3, y = 1, rule = B3/S23:P2,2", "doesn't fit on the bounded grid :P2,2"},
+		{"x = 3, y = 1, rule = B36/S23:T10,10", "rules are not supported"},
+	} {
+		_, err := ReadGame(strings.NewReader(test.header+"\n3o!\n"), false)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got the error %v, wanted one containing %q", test.header, err, test.err)
+		}
+	}
+}
+
+func TestGenerateLine(t *testing.T) {
+	for _, test := range []struct {
+		item, want string
+		err        bool
+	}{
+		{item: "", want: "....."},
+		{item: "bo", want: ".o..."},
+		{item: "2o2bo", want: "oo..o"},
+		{item: "5o", want: "ooooo"},
+		{item: "b3x2o", want: ".oo.."},
+		{item: "6o", err: true},
+		{item: "3bo2o", err: true},
+	} {
+		row := make([]bool, 5)
+		err := generateLine([]byte(test.item), row)
+		if test.err {
+			if err == nil {
+				t.Errorf("%q: expected an error", test.item)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.item, err)
+		} else if got := fieldFromRows(false, test.want).s[0]; !reflect.DeepEqual(row, got) {
+			t.Errorf("%q: got %v, wanted %v", test.item, row, got)
+		}
+	}
+}
+
+func TestNewGameLarge(t *testing.T) {
+	g := NewGame(100_000, 100, true)
+	if g.Field().Width() != 100_000 || g.Field().Height() != 100 {
+		t.Fatalf("got %dx%d", g.Field().Width(), g.Field().Height())
+	}
+	if density := float64(g.Population()) / 10_000_000; density < DefaultDensity-0.01 || density > DefaultDensity+0.01 {
+		t.Errorf("got density %f, wanted %f", density, DefaultDensity)
+	}
+}
+
+func TestInvalidSize(t *testing.T) {
+	for _, size := range [][2]uint{{0, 10}, {10, 0}, {0, 0}, {1 << 17, 1<<15 + 1}, {MaxCells + 1, 1}} {
+		if _, err := NewFieldE(size[0], size[1], true); err == nil {
+			t.Errorf("NewFieldE(%d, %d): expected an error", size[0], size[1])
+		}
+		if _, err := NewGameE(size[0], size[1], false); err == nil {
+			t.Errorf("NewGameE(%d, %d): expected an error", size[0], size[1])
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewField(%d, %d): expected a panic", size[0], size[1])
+				}
+			}()
+			NewField(size[0], size[1], true)
+		}()
+	}
+	if err := CheckSize(1<<16, 1<<16); err != nil {
+		t.Errorf("got %v for a field of MaxCells cells", err)
+	}
+	for _, data := range []string{"x = 0, y = 3\n!\n", "x = 3, y = 0\n!\n", "x = 100000, y = 100000\n!\n"} {
+		if _, err := ReadGame(strings.NewReader(data), true); err == nil || !strings.Contains(err.Error(), "invalid size") {
+			t.Errorf("%q: got the error %v, wanted one about the size", data, err)
+		}
+	}
+}
+
+func TestFieldOutput(t *testing.T) {
+	rand.Seed(1)
+	// 1500 cells don't fit in the WriteTo buffer at once.
+	f := NewGame(50, 30, true).current
+	want := new(strings.Builder)
+	for y := 0; y < int(f.height); y++ {
+		for x := 0; x < int(f.width); x++ {
+			if f.Alive(x, y) {
+				want.WriteRune('█')
+			} else {
+				want.WriteRune(' ')
+			}
+		}
+		want.WriteRune('\n')
+	}
+	if got := f.String(); got != want.String() {
+		t.Errorf("String: got:\n%s\nwanted:\n%s", got, want)
+	}
+	if got := string(f.AppendTo([]byte("prefix"))); got != "prefix"+want.String() {
+		t.Errorf("AppendTo: got:\n%s\nwanted:\n%s", got, want)
+	}
+	b := new(bytes.Buffer)
+	n, err := f.WriteTo(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(b.Len()) {
+		t.Errorf("WriteTo: reported %d bytes, wrote %d bytes", n, b.Len())
+	}
+	if got := b.String(); got != want.String() {
+		t.Errorf("WriteTo: got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func BenchmarkFieldString(b *testing.B) {
+	f := NewGame(256, 256, true).current
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = f.String()
+	}
+}
+
+func BenchmarkFieldAppendTo(b *testing.B) {
+	f := NewGame(256, 256, true).current
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = f.AppendTo(buf[:0])
+	}
+}
+
+func BenchmarkFieldWriteTo(b *testing.B) {
+	f := NewGame(256, 256, true).current
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.WriteTo(io.Discard)
+	}
+}
+
+// gameFromRows builds a game from rows of '.' (dead) and 'o' (alive) characters.
+func gameFromRows(wrap bool, rows ...string) *Game {
+	current := fieldFromRows(wrap, rows...)
+	return &Game{
+		current: current,
+		next:    NewField(current.width, current.height, wrap),
+		width:   current.width,
+		height:  current.height,
+		wrap:    wrap,
+	}
+}
+
+func TestHeader(t *testing.T) {
+	g, err := LoadGame("./examples/glider.rle", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Population(); got != 5 {
+		t.Errorf("population after loading: got %d, wanted 5", got)
+	}
+
+	g = gameFromRows(false,
+		".....",
+		".....",
+		".ooo.",
+		".....",
+		".....",
+	)
+	g.Tick()
+	testCases := []struct {
+		max  int
+		want string
+	}{
+		{0, "gen 1  pop 3  rule B3/S23  5x5 plane"},
+		{36, "gen 1  pop 3  rule B3/S23  5x5 plane"},
+		{35, "gen 1  pop 3  rule B3/S23"},
+		{20, "gen 1  pop 3"},
+		{3, "gen"},
+	}
+	for _, test := range testCases {
+		if got := g.Header(test.max); got != test.want {
+			t.Errorf("Header(%d): got %q, wanted %q", test.max, got, test.want)
+		}
+	}
+
+	if got := g.String(); strings.Contains(got, "gen") {
+		t.Errorf("header shown by default:\n%s", got)
+	}
+	g.ShowHeader(true)
+	if got, want := g.String(), "gen 1\n"+g.Field().String(); got != want {
+		t.Errorf("String with header: got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestWriteRLE(t *testing.T) {
+	for _, filepath := range []string{"./examples/bi-gun.rle", "./examples/glider.rle", "./examples/inverter.rle"} {
+		t.Run(filepath, func(t *testing.T) {
+			want, err := LoadGame(filepath, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := t.TempDir() + "/out.rle"
+			f, err := os.Create(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := want.Field().WriteRLE(f); err != nil {
+				t.Fatal(err)
+			}
+			f.Close()
+			got, err := LoadGame(out, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalCells(got.Field(), want.Field()) {
+				t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+			}
+		})
+	}
+
+	// Empty lines in the middle are merged, empty lines at the end are dropped.
+	f := fieldFromRows(false,
+		"o..o",
+		"....",
+		"....",
+		".oo.",
+		"....",
+	)
+	b := new(strings.Builder)
+	if err := f.WriteRLE(b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "x = 4, y = 5, rule = B3/S23\no2bo3$b2o!\n"; got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
+
+func TestRandomize(t *testing.T) {
+	f := NewField(100, 100, true)
+	f.Randomize(0.5, rand.New(rand.NewSource(7)))
+	if p := f.Population(); p < 4500 || p > 5500 {
+		t.Errorf("got population %d with density 0.5, wanted about 5000", p)
+	}
+	g := NewField(100, 100, true)
+	g.Randomize(0.5, rand.New(rand.NewSource(7)))
+	if !equalCells(f, g) || f.hash != g.hash {
+		t.Error("the same seed gave different fields")
+	}
+	g.Randomize(0, rand.New(rand.NewSource(7)))
+	if g.Population() != 0 {
+		t.Errorf("got population %d with density 0, wanted 0", g.Population())
+	}
+}
+
+func TestNewRandomGame(t *testing.T) {
+	a := NewRandomGame(40, 30, true, 0.3, rand.New(rand.NewSource(5)))
+	// Draws from the default source in between don't change the game.
+	rand.Float64()
+	b := NewRandomGame(40, 30, true, 0.3, rand.New(rand.NewSource(5)))
+	if !equalCells(a.Field(), b.Field()) || a.Hash() != b.Hash() {
+		t.Error("the same seed gave different games")
+	}
+	if c := NewRandomGame(40, 30, true, 0.3, rand.New(rand.NewSource(6))); c.Hash() == a.Hash() {
+		t.Error("different seeds gave the same game")
+	}
+	if p := a.Population(); p < 300 || p > 420 {
+		t.Errorf("got population %d of 1200 cells with density 0.3", p)
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := gameFromRows(false,
+		".o.",
+		"..o",
+		"ooo",
+	)
+	g.SetRule(Rule{Birth: 1 << 3})
+	g.TrackAges(true)
+	g.Tick()
+	c := g.Field().Clone()
+	if !equalCells(c, g.Field()) || c.Population() != g.Population() || c.rule != g.Rule() || c.hash != g.Hash() || c.Age(1, 2) != g.Field().Age(1, 2) {
+		t.Fatalf("got:\n%s\nwanted:\n%s", c, g.Field())
+	}
+	c.Set(0, 0, true)
+	if g.Field().Alive(0, 0) {
+		t.Error("clone shares cells with the original")
+	}
+}
+
+func TestResize(t *testing.T) {
+	g := gameFromRows(true,
+		".....",
+		"..o..",
+		"..o..",
+		"..o..",
+		".....",
+	)
+	g.TrackAges(true)
+	g.Tick()
+	g.Resize(4, 3)
+	want := fieldFromRows(true,
+		"....",
+		"....",
+		".ooo",
+	)
+	if !equalCells(g.Field(), want) || g.Population() != 3 || g.Generation() != 1 || g.Field().Age(2, 2) != 2 {
+		t.Fatalf("shrunk: got:\n%s\nwanted:\n%s", g.Field(), want)
+	}
+	want.recount()
+	if g.Hash() != want.hash {
+		t.Errorf("shrunk: got hash %x, wanted %x", g.Hash(), want.hash)
+	}
+	g.Resize(6, 5)
+	g.Tick()
+	want = fieldFromRows(true,
+		"......",
+		"......",
+		"......",
+		"......",
+		"......",
+	)
+	want.Set(2, 1, true)
+	want.Set(2, 2, true)
+	want.Set(2, 3, true)
+	if !equalCells(g.Field(), want) || g.Population() != 3 {
+		t.Fatalf("grown: got:\n%s\nwanted:\n%s", g.Field(), want)
+	}
+}
+
+func TestPlace(t *testing.T) {
+	f := NewField(5, 4, true)
+	glider := fieldFromRows(false,
+		".o.",
+		"..o",
+		"ooo",
+	)
+	if err := f.Place(glider, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Place(glider, 3, 1); err == nil {
+		t.Error("expected an error for a pattern that doesn't fit")
+	}
+	min, max, ok := f.BoundingBox()
+	if !ok || min != (Cell{2, 1}) || max != (Cell{4, 3}) {
+		t.Errorf("bounding box: got %v %v %t", min, max, ok)
+	}
+	if f.Population() != 5 {
+		t.Errorf("population: got %d, wanted 5", f.Population())
+	}
+}
+
+func BenchmarkLoadGame500(b *testing.B) {
+	path := b.TempDir() + "/soup.rle"
+	f, err := os.Create(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := randomField(rand.New(rand.NewSource(1)), 500, 500, true).WriteRLE(f); err != nil {
+		b.Fatal(err)
+	}
+	f.Close()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadGame(path, true); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestNewGameFromFunc(t *testing.T) {
+	stripes, err := NewGameFromFunc(10, 6, true, func(x, y uint) bool { return x%3 == 0 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stripes.Population() != 24 || !stripes.Field().wrap {
+		t.Errorf("got %d cells, wanted 4 stripes of 6", stripes.Population())
+	}
+
+	// fn is called once per cell in row-major order, so a counter numbers the cells.
+	var calls uint
+	var order []Cell
+	g, err := NewGameFromFunc(4, 3, false, func(x, y uint) bool {
+		order = append(order, Cell{x, y})
+		calls++
+		return calls%2 == 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range order {
+		if c != (Cell{uint(i) % 4, uint(i) / 4}) {
+			t.Fatalf("call %d was for cell %v", i, c)
+		}
+	}
+	if len(order) != 12 || g.Population() != 6 || !g.Field().s[0][1] || g.Field().s[0][0] {
+		t.Errorf("got %d calls and %d cells", len(order), g.Population())
+	}
+
+	if _, err := NewGameFromFunc(0, 3, true, func(x, y uint) bool { return true }); err == nil {
+		t.Error("expected an error for an empty field")
+	}
+}
+
+func TestFill(t *testing.T) {
+	f := fieldFromRows(false,
+		"oo.",
+		"...",
+	)
+	f.Fill(func(x, y uint) bool { return y == 1 })
+	want := fieldFromRows(false,
+		"...",
+		"ooo",
+	)
+	if !equalCells(f, want) || f.Population() != 3 || f.hash != want.hash {
+		t.Errorf("got:\n%s\nwith %d cells, wanted:\n%s", f, f.Population(), want)
+	}
+}
+
+// ExampleNewGameFromFunc seeds a disc in the middle of the field and a checkerboard.
+func ExampleNewGameFromFunc() {
+	disc, _ := NewGameFromFunc(9, 9, false, func(x, y uint) bool {
+		dx, dy := int(x)-4, int(y)-4
+		return dx*dx+dy*dy <= 9
+	})
+	fmt.Printf("%d cells: %q\n", disc.Population(), disc)
+
+	checkerboard, _ := NewGameFromFunc(6, 4, true, func(x, y uint) bool { return (x+y)%2 == 0 })
+	fmt.Printf("%d cells: %q\n", checkerboard.Population(), checkerboard)
+	// Output:
+	// 29 cells: "x = 9, y = 9, rule = B3/S23 $4bo$2b5o$2b5o$b7o$2b5o$2b5o$4bo!"
+	// 12 cells: "x = 6, y = 4, rule = B3/S23:T6,4 obobo$bobobo$obobo$bobobo!"
+}
@@ -0,0 +1,49 @@
+package life
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestLookupEngine(t *testing.T) {
+	assertSameEngine(t, func() Engine { return new(LookupEngine) })
+
+	// Changing the rule halfway must rebuild the table.
+	highLife := Rule{Birth: 1<<3 | 1<<6, Survival: 1<<2 | 1<<3}
+	rng := rand.New(rand.NewSource(2))
+	for _, wrap := range []bool{true, false} {
+		want := &Game{current: randomField(rng, 40, 30, wrap), next: NewField(40, 30, wrap), width: 40, height: 30}
+		got := &Game{current: NewField(40, 30, wrap), next: NewField(40, 30, wrap), width: 40, height: 30}
+		if err := got.current.Place(want.current, 0, 0); err != nil {
+			t.Fatal(err)
+		}
+		got.SetEngine(new(LookupEngine))
+		for gen := 1; gen <= 40; gen++ {
+			if gen == 20 {
+				want.SetRule(highLife)
+				got.SetRule(highLife)
+			}
+			want.Tick()
+			got.Tick()
+			if !equalCells(got.current, want.current) || got.Population() != want.Population() {
+				t.Fatalf("wrap %t: generation %d differs:\n%s\nwanted:\n%s", wrap, gen, got.current, want.current)
+			}
+		}
+	}
+}
+
+func BenchmarkLookupEngine(b *testing.B) {
+	for _, size := range []uint{64, 256, 1024} {
+		for _, wrap := range []bool{true, false} {
+			b.Run(fmt.Sprintf("%dx%d wrap %t", size, size, wrap), func(b *testing.B) {
+				g := &Game{current: randomField(rand.New(rand.NewSource(1)), size, size, wrap), next: NewField(size, size, wrap), width: size, height: size}
+				g.SetEngine(new(LookupEngine))
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					g.Tick()
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package life
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// SetPerturbation makes every tick flip every cell with probability p after the rule was applied, for "noisy Life".
+// The flips are drawn from r, so that runs can be repeated, and are part of the generation that the tick computes:
+// they count as births and deaths in Stats and are seen by the hash, ages, heat map and recording like the changes
+// of the rule. A p of 0 disables the perturbation. An error is returned if p isn't between 0 and 1, or if r is nil
+// while p isn't 0.
+func (g *Game) SetPerturbation(p float64, r *rand.Rand) error {
+	if !(p >= 0 && p <= 1) {
+		return errors.New("the probability of a flip must be between 0 and 1")
+	}
+	if p > 0 && r == nil {
+		return errors.New("perturbation requires a source of random numbers")
+	}
+	if p == 0 {
+		r = nil
+	}
+	g.noise, g.noiseRand = p, r
+	return nil
+}
+
+// perturb flips every cell of f, the generation following current, with the probability of the perturbation.
+// Rather than drawing a number for every cell, the number of cells up to the next flip is drawn from the geometric
+// distribution, so that small probabilities take time in proportion to the flips. A flip that undoes a change of the
+// rule takes it back from the births or deaths.
+func (g *Game) perturb(current, f *Field) {
+	n := f.width * f.height
+	logq := math.Log1p(-g.noise)
+	flipped := false
+	for i := uint(0); ; i++ {
+		// With a probability of 1, logq is -Inf and every cell flips.
+		skip := math.Log(1-g.noiseRand.Float64()) / logq
+		if skip >= float64(n-i) {
+			break
+		}
+		i += uint(skip)
+		x, y := i%f.width, i/f.width
+		alive := !f.s[y][x]
+		f.s[y][x] = alive
+		f.hash ^= cellKey(x, y)
+		if alive {
+			f.pop++
+		} else {
+			f.pop--
+		}
+		switch was := current.s[y][x]; {
+		case alive && !was:
+			f.births++
+		case alive:
+			f.deaths--
+		case was:
+			f.deaths++
+		default:
+			f.births--
+		}
+		flipped = true
+	}
+	// Engines that keep state between steps don't know of the flips.
+	if flipped {
+		f.edits++
+	}
+}
@@ -0,0 +1,117 @@
+package life
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPerturbationRate(t *testing.T) {
+	// Under a rule in which no cell is born or dies, every change is a flip.
+	still, _ := ParseRule("B/S012345678")
+	for _, p := range []float64{0.0005, 0.01, 0.3} {
+		g := NewGameFromField(NewField(100, 100, true))
+		g.SetRule(still)
+		if err := g.SetPerturbation(p, rand.New(rand.NewSource(1))); err != nil {
+			t.Fatal(err)
+		}
+		const ticks = 200
+		flips := 0.0
+		for i := 0; i < ticks; i++ {
+			g.Tick()
+			s := g.Stats()
+			flips += float64(s.Births + s.Deaths)
+		}
+		// The flips are binomially distributed, 5 standard deviations make a false alarm practically impossible.
+		n := float64(100 * 100 * ticks)
+		if want, tolerance := n*p, 5*math.Sqrt(n*p*(1-p)); math.Abs(flips-want) > tolerance {
+			t.Errorf("p=%g: got %.0f flips in %.0f cells, wanted %.0f±%.0f", p, flips, n, want, tolerance)
+		}
+	}
+}
+
+func TestPerturbationBirthsAndDeaths(t *testing.T) {
+	// Flips that undo a change of the rule must not count as births or deaths.
+	g := NewRandomGame(40, 30, true, 0.3, rand.New(rand.NewSource(5)))
+	if err := g.SetPerturbation(0.3, rand.New(rand.NewSource(1))); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		before := g.Field().Clone()
+		g.Tick()
+		var births, deaths uint
+		for y, row := range g.Field().s {
+			for x, alive := range row {
+				if alive && !before.s[y][x] {
+					births++
+				} else if !alive && before.s[y][x] {
+					deaths++
+				}
+			}
+		}
+		if s := g.Stats(); s.Births != births || s.Deaths != deaths {
+			t.Fatalf("generation %d: got %d births and %d deaths, wanted %d and %d",
+				s.Generation, s.Births, s.Deaths, births, deaths)
+		}
+	}
+}
+
+func TestPerturbation(t *testing.T) {
+	run := func(p float64, seed int64, e Engine) *Game {
+		g := NewRandomGame(40, 30, true, 0.3, rand.New(rand.NewSource(7))).WithEngine(e)
+		if err := g.SetPerturbation(p, rand.New(rand.NewSource(seed))); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 50; i++ {
+			g.Tick()
+		}
+		return g
+	}
+	plain := NewRandomGame(40, 30, true, 0.3, rand.New(rand.NewSource(7)))
+	for i := 0; i < 50; i++ {
+		plain.Tick()
+	}
+	if g := run(0, 1, NaiveEngine{}); !equalCells(g.Field(), plain.Field()) {
+		t.Error("a probability of 0 changed the run")
+	}
+	a, b := run(0.01, 1, NaiveEngine{}), run(0.01, 1, NaiveEngine{})
+	if !equalCells(a.Field(), b.Field()) {
+		t.Error("the same seed gave different runs")
+	}
+	if equalCells(a.Field(), plain.Field()) {
+		t.Error("the perturbation didn't change the run")
+	}
+	// The hash and population are kept up to date with the flips, and all engines see them.
+	h := a.Field().Clone()
+	h.recount()
+	if a.Hash() != h.hash || a.Population() != h.Population() {
+		t.Errorf("got hash %x and population %d, wanted %x and %d", a.Hash(), a.Population(), h.hash, h.Population())
+	}
+	for _, name := range EngineNames() {
+		e, _ := NewEngine(name)
+		if g := run(0.01, 1, e); !equalCells(g.Field(), a.Field()) {
+			t.Errorf("%s: got another run than the naive engine", name)
+		}
+	}
+
+	g := plain
+	for _, p := range []float64{-0.1, 1.5, math.NaN()} {
+		if err := g.SetPerturbation(p, rand.New(rand.NewSource(1))); err == nil {
+			t.Errorf("got no error for a probability of %g", p)
+		}
+	}
+	if err := g.SetPerturbation(0.1, nil); err == nil {
+		t.Error("got no error without a source of random numbers")
+	}
+	if err := g.SetPerturbation(1, rand.New(rand.NewSource(1))); err != nil {
+		t.Fatal(err)
+	}
+	// With a probability of 1, every cell flips after the rule was applied.
+	want := NewGameFromField(g.Field().Clone())
+	want.Tick()
+	want.Field().Invert()
+	g.Tick()
+	if !equalCells(g.Field(), want.Field()) {
+		t.Errorf("a probability of 1 gave\n%swanted\n%s", g.Field(), want.Field())
+	}
+}
@@ -0,0 +1,148 @@
+package life
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Option sets up a game created by New or loaded by LoadGame, e.g. WithWrap(false) for a field that doesn't wrap.
+type Option func(*options) error
+
+// options are the settings of New and LoadGame, which start out as the defaults.
+type options struct {
+	wrap    bool
+	density float64
+	rule    Rule
+	rand    *rand.Rand
+	engine  Engine
+	// hasRule is set by WithRule, so that loaded games keep their rule otherwise, and hasWrap by WithWrap, so that
+	// loaded patterns put on the field of WithGrid keep their topology otherwise.
+	hasRule bool
+	hasWrap bool
+	// random names the last given option that only applies to random initial states.
+	random string
+	// gridWidth and gridHeight are the size of the field of WithGrid, 0 without it, and offsetX and offsetY the
+	// position of the pattern on it if hasOffset is set by WithOffset.
+	gridWidth, gridHeight uint
+	offsetX, offsetY      uint
+	hasOffset             bool
+	// loaded names the last given option that only applies to loaded patterns.
+	loaded string
+}
+
+func defaultOptions() options {
+	return options{wrap: true, density: DefaultDensity, rule: Conway}
+}
+
+// apply applies opts in order, stopping at the first error.
+func (o *options) apply(opts []Option) error {
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithWrap sets whether the field wraps around its edges, as a torus, or is a plane beyond whose edges all cells are
+// dead. Fields wrap by default.
+func WithWrap(wrap bool) Option {
+	return func(o *options) error {
+		o.wrap, o.hasWrap = wrap, true
+		return nil
+	}
+}
+
+// WithDensity sets the probability of a cell being alive in the random initial state, DefaultDensity by default.
+// It must be between 0 and 1, and doesn't apply to LoadGame.
+func WithDensity(p float64) Option {
+	return func(o *options) error {
+		if math.IsNaN(p) || p < 0 || p > 1 {
+			return fmt.Errorf("WithDensity: the density %v isn't between 0 and 1", p)
+		}
+		o.density, o.random = p, "WithDensity"
+		return nil
+	}
+}
+
+// WithRule sets the rule of the game, Conway by default.
+func WithRule(r Rule) Option {
+	return func(o *options) error {
+		o.rule, o.hasRule = r, true
+		return nil
+	}
+}
+
+// WithRand draws the random initial state from r instead of the default source of math/rand, which anything else in
+// the program may draw from as well, so that the same seed always gives the same game. It doesn't apply to LoadGame.
+func WithRand(r *rand.Rand) Option {
+	return func(o *options) error {
+		if r == nil {
+			return errors.New("WithRand: the source is nil")
+		}
+		o.rand, o.random = r, "WithRand"
+		return nil
+	}
+}
+
+// WithEngine sets the engine that computes the generations, see Game.SetEngine. NaiveEngine is used by default.
+func WithEngine(e Engine) Option {
+	return func(o *options) error {
+		if e == nil {
+			return errors.New("WithEngine: the engine is nil")
+		}
+		o.engine = e
+		return nil
+	}
+}
+
+// WithGrid puts a loaded pattern on an empty field of width by height cells instead of a field of the size of the
+// pattern. The field wraps like the one the pattern was read onto, which is that of the bounded grid in its rule if
+// it has one, unless WithWrap is given. Unless WithOffset gives its position, the pattern is put where the Pos of its
+// #CXRLE line says, with 0,0 the middle cell of the field as in Golly, or else in the middle. It must fit on the
+// field there, and the size must be valid, see CheckSize. It doesn't apply to New.
+func WithGrid(width, height uint) Option {
+	return func(o *options) error {
+		if err := CheckSize(width, height); err != nil {
+			return fmt.Errorf("WithGrid: %w", err)
+		}
+		o.gridWidth, o.gridHeight, o.loaded = width, height, "WithGrid"
+		return nil
+	}
+}
+
+// WithOffset puts the top-left cell of a loaded pattern at x,y on the field of WithGrid, which it requires.
+func WithOffset(x, y uint) Option {
+	return func(o *options) error {
+		o.offsetX, o.offsetY, o.hasOffset, o.loaded = x, y, true, "WithOffset"
+		return nil
+	}
+}
+
+// New returns a new Life game with a random initial state of the given size. Without options, the field wraps,
+// every cell is alive with a probability of DefaultDensity, drawn from the default source of math/rand, and the rule
+// is Conway, as with NewGame. An error is returned if the size is invalid, see CheckSize, or an option is.
+func New(width, height uint, opts ...Option) (*Game, error) {
+	o := defaultOptions()
+	if err := o.apply(opts); err != nil {
+		return nil, err
+	}
+	if o.loaded != "" {
+		return nil, fmt.Errorf("%s only applies to loaded patterns", o.loaded)
+	}
+	f, err := NewFieldE(width, height, o.wrap)
+	if err != nil {
+		return nil, err
+	}
+	random := rand.Float64
+	if o.rand != nil {
+		random = o.rand.Float64
+	}
+	seedField(f, o.density, random)
+	f.rule = o.rule
+	g := NewGameFromField(f)
+	g.engine = o.engine
+	return g, nil
+}
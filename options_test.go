@@ -0,0 +1,186 @@
+package life
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	g, err := New(200, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := g.Field()
+	if f.Width() != 200 || f.Height() != 100 || !f.wrap || f.rule != Conway {
+		t.Errorf("got a %dx%d field that wraps: %t, with the rule %s, wanted the defaults of NewGame",
+			f.Width(), f.Height(), f.wrap, f.rule)
+	}
+	if density := float64(g.Population()) / 20_000; math.Abs(density-DefaultDensity) > 0.02 {
+		t.Errorf("got density %f, wanted %f", density, DefaultDensity)
+	}
+
+	// The same seed gives the same game as NewRandomGame.
+	highLife, _ := ParseRule("B36/S23")
+	g, err = New(30, 20, WithWrap(false), WithDensity(0.4), WithRand(rand.New(rand.NewSource(9))), WithRule(highLife),
+		WithEngine(new(LookupEngine)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := NewRandomGame(30, 20, false, 0.4, rand.New(rand.NewSource(9)))
+	if !equalCells(g.Field(), want.Field()) || g.Field().wrap {
+		t.Errorf("got:\n%s\nwanted:\n%s", g.Field(), want.Field())
+	}
+	if g.Field().rule != highLife || g.next.rule != highLife {
+		t.Errorf("got the rule %s, wanted %s", g.Field().rule, highLife)
+	}
+	if _, ok := g.engine.(*LookupEngine); !ok {
+		t.Errorf("got the engine %T, wanted LookupEngine", g.engine)
+	}
+
+	for _, test := range []struct {
+		width, height uint
+		opts          []Option
+		err           string
+	}{
+		{0, 10, nil, "invalid size"},
+		{10, 10, []Option{WithDensity(1.5)}, "density 1.5"},
+		{10, 10, []Option{WithDensity(math.NaN())}, "density NaN"},
+		{10, 10, []Option{WithRand(nil)}, "WithRand"},
+		{10, 10, []Option{WithEngine(nil)}, "WithEngine"},
+	} {
+		if _, err := New(test.width, test.height, test.opts...); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%dx%d: got the error %v, wanted one containing %q", test.width, test.height, err, test.err)
+		}
+	}
+}
+
+func TestLoadGameOptions(t *testing.T) {
+	g, err := LoadGame("./examples/glider.rle", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.Field().wrap || g.Field().rule != Conway || g.engine != nil {
+		t.Errorf("got wrap %t, rule %s and engine %T without options", g.Field().wrap, g.Field().rule, g.engine)
+	}
+	highLife, _ := ParseRule("B36/S23")
+	g, err = LoadGame("./examples/glider.rle", true, WithWrap(false), WithRule(highLife), WithEngine(new(LookupEngine)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Field().wrap || g.Field().rule != highLife || g.next.rule != highLife {
+		t.Errorf("got wrap %t and rule %s, wanted a plane with %s", g.Field().wrap, g.Field().rule, highLife)
+	}
+	if _, ok := g.engine.(*LookupEngine); !ok {
+		t.Errorf("got the engine %T, wanted LookupEngine", g.engine)
+	}
+	for _, opt := range []Option{WithDensity(0.5), WithRand(rand.New(rand.NewSource(1)))} {
+		if _, err := LoadGame("./examples/glider.rle", true, opt); err == nil ||
+			!strings.Contains(err.Error(), "doesn't apply to loaded patterns") {
+			t.Errorf("got the error %v, wanted one for an option that doesn't apply", err)
+		}
+	}
+}
+
+func TestLoadGameGrid(t *testing.T) {
+	// The glider is put in the middle of the larger field, which wraps as asked.
+	g, err := LoadGame("./examples/glider.rle", false, WithGrid(8, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fieldFromRows(false,
+		"........",
+		"...o....",
+		"....o...",
+		"..ooo...",
+		"........",
+		"........",
+	)
+	if !equalCells(g.Field(), want) || g.Field().wrap || g.Population() != 5 {
+		t.Errorf("got:\n%s\nwanted the glider in the middle of an 8x6 plane", g.Field())
+	}
+
+	// The field keeps the topology of a bounded grid, unless WithWrap is given.
+	for _, test := range []struct {
+		grid       string
+		wrap, want bool
+		opts       []Option
+	}{
+		{"P10,10", true, false, nil},
+		{"T10,10", false, true, nil},
+		{"P10,10", false, true, []Option{WithWrap(true)}},
+		{"T10,10", true, false, []Option{WithWrap(false)}},
+	} {
+		rle := "x = 3, y = 1, rule = B3/S23:" + test.grid + "\n3o!\n"
+		g, err := ReadGame(strings.NewReader(rle), test.wrap, append(test.opts, WithGrid(20, 20))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f := g.Field(); f.Width() != 20 || f.wrap != test.want || g.next.wrap != test.want || g.Population() != 3 {
+			t.Errorf(":%s with %d options: got a %dx%d field that wraps: %t, wanted 20x20 and %t",
+				test.grid, len(test.opts), f.Width(), f.Height(), f.wrap, test.want)
+		}
+	}
+
+	// On a torus, the glider travels across the edges and is back where it started after 4 generations per cell of the
+	// field's size.
+	g, err = LoadGame("./examples/glider.rle", true, WithGrid(8, 8), WithOffset(0, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := g.Field().Clone()
+	for g.Generation() < 32 {
+		g.Tick()
+		if g.Generation() == 16 && equalCells(g.Field(), start) {
+			t.Error("the glider is back after 16 generations, on a field that is 8 cells across")
+		}
+	}
+	if !equalCells(g.Field(), start) || g.Field().Width() != 8 || g.next.Width() != 8 {
+		t.Errorf("got:\n%s\nwanted:\n%s", g.Field(), start)
+	}
+
+	// The Pos of a #CXRLE line is relative to the middle cell, unless WithOffset gives the position.
+	rle := "#CXRLE Pos=-3,1 Gen=7\nx = 3, y = 1\n3o!\n"
+	for _, test := range []struct {
+		opts []Option
+		x, y uint
+	}{
+		{[]Option{WithGrid(10, 6)}, 2, 4},
+		{[]Option{WithGrid(10, 6), WithOffset(7, 0)}, 7, 0},
+	} {
+		g, err := ReadGame(strings.NewReader(rle), false, test.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if min, max, _ := g.Field().BoundingBox(); min != (Cell{test.x, test.y}) || max != (Cell{test.x + 2, test.y}) {
+			t.Errorf("the blinker is at %v-%v, wanted it at %d,%d", min, max, test.x, test.y)
+		}
+	}
+	if g, _ := ReadGame(strings.NewReader(rle), false); g.Field().Width() != 3 {
+		t.Errorf("got a field of %d cells across without WithGrid, wanted the 3 of the pattern", g.Field().Width())
+	}
+
+	blinker := "x = 3, y = 1\n3o!\n"
+	for _, test := range []struct {
+		rle  string
+		opts []Option
+		err  string
+	}{
+		{blinker, []Option{WithGrid(2, 6)}, "the 3x1 pattern doesn't fit on a 2x6 grid"},
+		{blinker, []Option{WithGrid(10, 6), WithOffset(8, 0)}, "3x1 pattern at 8,0 doesn't fit on a 10x6 field"},
+		{blinker, []Option{WithOffset(1, 1)}, "WithOffset requires WithGrid"},
+		{blinker, []Option{WithGrid(0, 6)}, "WithGrid: "},
+		{rle, []Option{WithGrid(4, 6)}, "the pattern at Pos=-3,1 lies beyond the top-left of a 4x6 grid"},
+		{"#CXRLE Pos=4,0\n" + blinker, []Option{WithGrid(10, 6)}, "3x1 pattern at 9,3 doesn't fit on a 10x6 field"},
+	} {
+		_, err := ReadGame(strings.NewReader(test.rle), true, test.opts...)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("got the error %v, wanted one containing %q", err, test.err)
+		}
+	}
+	_, err = New(10, 10, WithGrid(20, 20))
+	if err == nil || !strings.Contains(err.Error(), "WithGrid only applies to loaded patterns") {
+		t.Errorf("got the error %v from New, wanted one for WithGrid", err)
+	}
+}
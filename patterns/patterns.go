@@ -0,0 +1,107 @@
+// Package patterns provides classic patterns of Conway's Game of Life, so that they don't have to be looked up as
+// RLE files first. Every pattern is a field just large enough to hold it, which can be placed onto a larger one with
+// Field.Place:
+//
+//	f := life.NewField(100, 60, true)
+//	f.Place(patterns.GosperGun(), 10, 10)
+//	g := life.NewGameFromField(f)
+package patterns
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/418Coffee/life"
+)
+
+//go:embed *.rle
+var files embed.FS
+
+var (
+	parseOnce sync.Once
+	parsed    map[string]*life.Field
+)
+
+// load parses the embedded patterns, the first time it is called.
+func load() map[string]*life.Field {
+	parseOnce.Do(func() {
+		entries, err := files.ReadDir(".")
+		if err != nil {
+			panic(err)
+		}
+		parsed = make(map[string]*life.Field, len(entries))
+		for _, e := range entries {
+			f, err := files.Open(e.Name())
+			if err != nil {
+				panic(err)
+			}
+			g, err := life.ReadGame(f, false)
+			f.Close()
+			// The patterns are part of the package, so they are known to be valid.
+			if err == nil && len(g.Warnings()) != 0 {
+				err = fmt.Errorf("%v", g.Warnings()[0])
+			}
+			if err != nil {
+				panic(fmt.Sprintf("patterns: %s: %v", e.Name(), err))
+			}
+			parsed[strings.TrimSuffix(e.Name(), ".rle")] = g.Field()
+		}
+	})
+	return parsed
+}
+
+// Names returns the names of the patterns, sorted, e.g. "gosper-gun".
+func Names() []string {
+	p := load()
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns a new field holding the pattern of the given name, see Names.
+func Get(name string) (*life.Field, error) {
+	f, ok := load()[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown pattern %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return f.Clone(), nil
+}
+
+// get returns the pattern of a name that is known to exist.
+func get(name string) *life.Field {
+	f, err := Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Glider returns the glider, the smallest spaceship, which moves diagonally by one cell every 4 generations.
+func Glider() *life.Field { return get("glider") }
+
+// LWSS returns the lightweight spaceship, which moves orthogonally by two cells every 4 generations.
+func LWSS() *life.Field { return get("lwss") }
+
+// RPentomino returns the R-pentomino, a methuselah that settles after 1103 generations.
+func RPentomino() *life.Field { return get("r-pentomino") }
+
+// Acorn returns the acorn, a methuselah that settles after 5206 generations.
+func Acorn() *life.Field { return get("acorn") }
+
+// Diehard returns the diehard, a methuselah that dies out after 130 generations.
+func Diehard() *life.Field { return get("diehard") }
+
+// GosperGun returns the Gosper glider gun, which emits a glider every 30 generations.
+func GosperGun() *life.Field { return get("gosper-gun") }
+
+// Pulsar returns the pulsar, an oscillator of period 3.
+func Pulsar() *life.Field { return get("pulsar") }
+
+// Pentadecathlon returns the pentadecathlon, an oscillator of period 15.
+func Pentadecathlon() *life.Field { return get("pentadecathlon") }
@@ -0,0 +1,119 @@
+package patterns
+
+import (
+	"testing"
+
+	"github.com/418Coffee/life"
+)
+
+func TestPatterns(t *testing.T) {
+	testCases := []struct {
+		name                      string
+		pattern                   func() *life.Field
+		width, height, population uint
+	}{
+		{"acorn", Acorn, 7, 3, 7},
+		{"diehard", Diehard, 8, 3, 7},
+		{"glider", Glider, 3, 3, 5},
+		{"gosper-gun", GosperGun, 36, 9, 36},
+		{"lwss", LWSS, 5, 4, 9},
+		{"pentadecathlon", Pentadecathlon, 10, 3, 12},
+		{"pulsar", Pulsar, 13, 13, 48},
+		{"r-pentomino", RPentomino, 3, 3, 5},
+	}
+	names := Names()
+	if len(names) != len(testCases) {
+		t.Errorf("got the patterns %v, wanted %d", names, len(testCases))
+	}
+	for i, tc := range testCases {
+		if i < len(names) && names[i] != tc.name {
+			t.Errorf("got %q as pattern %d, wanted %q", names[i], i, tc.name)
+		}
+		f, err := Get(tc.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Width() != tc.width || f.Height() != tc.height || f.Population() != tc.population {
+			t.Errorf("%s: got %dx%d with %d cells, wanted %dx%d with %d", tc.name, f.Width(), f.Height(),
+				f.Population(), tc.width, tc.height, tc.population)
+		}
+		if f.String() != tc.pattern().String() {
+			t.Errorf("%s: the constructor returns a different pattern than Get", tc.name)
+		}
+	}
+}
+
+func TestGetReturnsCopies(t *testing.T) {
+	f, _ := Get("glider")
+	f.Set(0, 0, true)
+	if g, _ := Get("glider"); g.Population() != 5 {
+		t.Errorf("changing a pattern changed the next one returned, got a population of %d", g.Population())
+	}
+	if _, err := Get("glider-gun"); err == nil {
+		t.Error("got no error for an unknown pattern")
+	}
+}
+
+// run places pattern at x,y on an empty plane of the given size and returns the game.
+func run(pattern *life.Field, width, height, x, y uint) *life.Game {
+	f := life.NewField(width, height, false)
+	f.Place(pattern, x, y)
+	return life.NewGameFromField(f)
+}
+
+func TestOscillators(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		pattern *life.Field
+		period  int
+	}{
+		{"pulsar", Pulsar(), 3},
+		{"pentadecathlon", Pentadecathlon(), 15},
+	} {
+		g := run(tc.pattern, tc.pattern.Width()+8, tc.pattern.Height()+8, 4, 4)
+		start := g.Field().String()
+		for i := 1; i <= tc.period; i++ {
+			g.Tick()
+			if repeated := g.Field().String() == start; repeated != (i == tc.period) {
+				t.Errorf("%s: repeated=%v after %d generations, wanted a period of %d", tc.name, repeated, i, tc.period)
+			}
+		}
+	}
+}
+
+func TestDiehard(t *testing.T) {
+	g := run(Diehard(), 60, 60, 26, 28)
+	for i := 0; i < 129; i++ {
+		g.Tick()
+	}
+	if g.Population() == 0 {
+		t.Fatal("died out before generation 130")
+	}
+	g.Tick()
+	if g.Population() != 0 {
+		t.Errorf("got a population of %d at generation 130, wanted none", g.Population())
+	}
+}
+
+func TestGosperGunEmitsGliders(t *testing.T) {
+	g := run(GosperGun(), 60, 40, 0, 0)
+	// The first glider forms within the rows of the gun and leaves them a few generations later.
+	for g.Generation() < 30 {
+		g.Tick()
+		if _, max, _ := g.Field().BoundingBox(); max.Y >= 9 {
+			break
+		}
+	}
+	if g.Generation() < 15 || g.Generation() > 25 {
+		t.Errorf("the first glider left the gun at generation %d, wanted around 20", g.Generation())
+	}
+	// From then on, the gun is back to its 36 cells every 30 generations, with another glider of 5 cells.
+	for _, gen := range []uint{30, 60, 90} {
+		for g.Generation() < gen {
+			g.Tick()
+		}
+		if want := 36 + 5*gen/30; g.Population() != want {
+			t.Errorf("got a population of %d at generation %d, wanted %d", g.Population(), gen, want)
+		}
+	}
+}
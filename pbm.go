@@ -0,0 +1,173 @@
+package life
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WritePBM writes f as a PBM image of the netpbm formats, with a pixel for every cell, black for live cells and
+// white for dead ones, preceded by the given comments. The image is in the binary P4 format, with 8 cells to a byte
+// and every row padded to a whole byte, or in the plain P1 format, with a '0' or '1' for every cell, if plain is set.
+func (f *Field) WritePBM(w io.Writer, plain bool, comments ...string) error {
+	bw := bufio.NewWriter(w)
+	if plain {
+		bw.WriteString("P1\n")
+	} else {
+		bw.WriteString("P4\n")
+	}
+	for _, comment := range comments {
+		bw.WriteString("# ")
+		bw.WriteString(comment)
+		bw.WriteByte('\n')
+	}
+	fmt.Fprintf(bw, "%d %d\n", f.width, f.height)
+	if plain {
+		// Lines of the plain format should not be longer than 70 characters.
+		for _, row := range f.s {
+			for x, alive := range row {
+				if x > 0 && x%70 == 0 {
+					bw.WriteByte('\n')
+				}
+				if alive {
+					bw.WriteByte('1')
+				} else {
+					bw.WriteByte('0')
+				}
+			}
+			bw.WriteByte('\n')
+		}
+		return bw.Flush()
+	}
+	packed := make([]byte, (f.width+7)/8)
+	for _, row := range f.s {
+		for i := range packed {
+			packed[i] = 0
+		}
+		for x, alive := range row {
+			if alive {
+				packed[x/8] |= 0x80 >> (x % 8)
+			}
+		}
+		bw.Write(packed)
+	}
+	return bw.Flush()
+}
+
+// ReadPBM reads a Life game state from a PBM image of the netpbm formats in r, in the plain P1 or the binary P4
+// format, with black pixels as live cells. The comments of the image are returned by Game.Comment. Only the first
+// image is read if r holds several.
+func ReadPBM(r io.Reader, wrap bool) (*Game, error) {
+	p := pbmReader{r: bufio.NewReader(r)}
+	magic := make([]byte, 2)
+	if _, err := io.ReadFull(p.r, magic); err != nil || magic[0] != 'P' || (magic[1] != '1' && magic[1] != '4') {
+		return nil, errors.New("not a PBM image")
+	}
+	width, err := p.number()
+	if err != nil {
+		return nil, fmt.Errorf("width: %w", err)
+	}
+	height, err := p.number()
+	if err != nil {
+		return nil, fmt.Errorf("height: %w", err)
+	}
+	f, err := NewFieldE(width, height, wrap)
+	if err != nil {
+		return nil, err
+	}
+	if magic[1] == '1' {
+		for y, row := range f.s {
+			for x := range row {
+				c, err := p.token()
+				if err != nil {
+					return nil, fmt.Errorf("pixel %d,%d: %w", x, y, truncatedPBM(err))
+				}
+				if c != '0' && c != '1' {
+					return nil, fmt.Errorf("pixel %d,%d: %q isn't 0 or 1", x, y, c)
+				}
+				row[x] = c == '1'
+			}
+		}
+	} else {
+		packed := make([]byte, (width+7)/8)
+		for y, row := range f.s {
+			if _, err := io.ReadFull(p.r, packed); err != nil {
+				return nil, fmt.Errorf("row %d: %w", y, truncatedPBM(err))
+			}
+			for x := range row {
+				row[x] = packed[x/8]&(0x80>>(x%8)) != 0
+			}
+		}
+	}
+	f.recount()
+	g := NewGameFromField(f)
+	g.comment = p.comment.String()
+	return g, nil
+}
+
+// truncatedPBM returns an error that says that the image ended early for the errors that do.
+func truncatedPBM(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("the image is truncated")
+	}
+	return err
+}
+
+// pbmReader reads the tokens of a PBM image, skipping whitespace and collecting comments.
+type pbmReader struct {
+	r       *bufio.Reader
+	comment strings.Builder
+}
+
+// token returns the next byte that is neither whitespace nor part of a comment, which run from '#' to the end of the
+// line.
+func (p *pbmReader) token() (byte, error) {
+	for {
+		c, err := p.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+		case '#':
+			line, err := p.r.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return 0, err
+			}
+			p.comment.WriteString(strings.TrimPrefix(strings.TrimRight(line, "\r\n"), " "))
+			p.comment.WriteByte('\n')
+		default:
+			return c, nil
+		}
+	}
+}
+
+// number reads a number of the header, along with the single whitespace character after it.
+func (p *pbmReader) number() (uint, error) {
+	c, err := p.token()
+	if err != nil {
+		return 0, truncatedPBM(err)
+	}
+	var n uint64
+	for {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%q isn't a digit", c)
+		}
+		if n = n*10 + uint64(c-'0'); n > 1<<31 {
+			return 0, errors.New("too large")
+		}
+		if c, err = p.r.ReadByte(); err != nil {
+			return 0, truncatedPBM(err)
+		}
+		switch c {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+			return uint(n), nil
+		case '#':
+			// The comment ends the number, and is read along with the next token.
+			p.r.UnreadByte()
+			return uint(n), nil
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package life
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWritePBM(t *testing.T) {
+	f := fieldFromRows(false,
+		"o........o",
+		".oo.......",
+	)
+	b := new(bytes.Buffer)
+	if err := f.WritePBM(b, true, "A test."); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "P1\n# A test.\n10 2\n1000000001\n0110000000\n"; got != want {
+		t.Errorf("plain: got %q, wanted %q", got, want)
+	}
+	b.Reset()
+	if err := f.WritePBM(b, false); err != nil {
+		t.Fatal(err)
+	}
+	// Every row takes two bytes, the second padded with 6 bits.
+	if got, want := b.String(), "P4\n10 2\n\x80\x40\x60\x00"; got != want {
+		t.Errorf("binary: got %q, wanted %q", got, want)
+	}
+}
+
+func TestReadPBM(t *testing.T) {
+	// A width that isn't a multiple of 8 needs padding in the binary format.
+	want := randomField(rand.New(rand.NewSource(3)), 13, 7, true)
+	for _, plain := range []bool{true, false} {
+		b := new(bytes.Buffer)
+		if err := want.WritePBM(b, plain, "Generation 3.", "Second line."); err != nil {
+			t.Fatal(err)
+		}
+		g, err := ReadPBM(b, true)
+		if err != nil {
+			t.Fatalf("plain %t: %v", plain, err)
+		}
+		if !equalCells(g.Field(), want) || g.Field().Population() != want.Population() {
+			t.Errorf("plain %t: got:\n%s\nwanted:\n%s", plain, g.Field(), want)
+		}
+		if got, want := g.Comment(), "Generation 3.\nSecond line.\n"; got != want {
+			t.Errorf("plain %t: got the comment %q, wanted %q", plain, got, want)
+		}
+	}
+
+	// The plain format may have comments anywhere and any whitespace between the pixels, or none.
+	g, err := ReadPBM(strings.NewReader("P1 # a blinker\n3# width\n3\n000 1 1 1\r\n\t0\n00\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := g.Field(), fieldFromRows(false, "...", "ooo", "..."); !equalCells(got, want) {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+	if got, want := g.Comment(), "a blinker\nwidth\n"; got != want {
+		t.Errorf("got the comment %q, wanted %q", got, want)
+	}
+
+	for _, data := range []string{
+		"",
+		"P2\n3 3\n",
+		"P1\n3\n",
+		"P1\nx 3\n",
+		"P1\n0 3\n",
+		"P1\n2 2\n0 1 1\n",
+		"P1\n2 2\n0 1 2 1\n",
+		"P4\n9 2\n\x00\x00\x00",
+	} {
+		if _, err := ReadPBM(strings.NewReader(data), false); err == nil {
+			t.Errorf("%q: expected an error", data)
+		}
+	}
+}
+
+func TestLoadGamePBM(t *testing.T) {
+	want := fieldFromRows(true, ".o.", "..o", "ooo")
+	name := t.TempDir() + "/glider.pbm"
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := want.WritePBM(f, false); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	g, err := LoadGame(name, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalCells(g.Field(), want) {
+		t.Errorf("got:\n%s\nwanted:\n%s", g.Field(), want)
+	}
+}
@@ -0,0 +1,129 @@
+package life
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxPredecessorWidth is the widest field FindPredecessor searches, as it keeps the rows in the bits of a uint64.
+const maxPredecessorWidth = 64
+
+// FindPredecessor searches for a parent of f: a field of the same size, wrapping and rule, whose next generation is
+// f. It reports false if there is none, which makes f a Garden of Eden on its field. Cells beyond the edges of a
+// field that doesn't wrap are dead, in the parent as in every field.
+//
+// The search goes through the rows of the parent from the top, trying all 2^width rows at every step, and backs up
+// as soon as a row of f can't come out right anymore. The width decides the cost: fields up to 8 cells wide are
+// searched in milliseconds, 10 in up to seconds and 12 in up to minutes, beyond that the search rarely ends. Fields
+// without a parent, like most random ones, take the longest, as every choice has to be ruled out. Fields wider than
+// 64 cells aren't supported. The search stops with the error of ctx when ctx is done, so give it a deadline.
+func FindPredecessor(ctx context.Context, f *Field) (*Field, bool, error) {
+	if f.width > maxPredecessorWidth {
+		return nil, false, fmt.Errorf("can't search predecessors of fields wider than %d cells", maxPredecessorWidth)
+	}
+	s := predecessorSearch{
+		ctx:    ctx,
+		f:      f,
+		xs:     make([][3]int, f.width),
+		ys:     make([][3]int, f.height),
+		parent: make([]uint64, f.height),
+		checks: make([][]uint, f.height),
+	}
+	for x := range s.xs {
+		s.xs[x] = neighbourhood(uint(x), f.width, f.wrap)
+	}
+	// A row of f can be checked once the last of the rows of the parent it depends on is chosen.
+	for y := range s.ys {
+		s.ys[y] = neighbourhood(uint(y), f.height, f.wrap)
+		last := 0
+		for _, ny := range s.ys[y] {
+			if ny > last {
+				last = ny
+			}
+		}
+		s.checks[last] = append(s.checks[last], uint(y))
+	}
+	found, err := s.row(0)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	p := NewField(f.width, f.height, f.wrap)
+	p.rule = f.rule
+	for y, row := range s.parent {
+		for x := uint(0); x < f.width; x++ {
+			p.s[y][x] = row&(1<<x) != 0
+		}
+	}
+	p.recount()
+	return p, true, nil
+}
+
+// predecessorSearch is the state of FindPredecessor.
+type predecessorSearch struct {
+	ctx context.Context
+	f   *Field
+	// xs and ys are the neighbourhoods of the columns and rows, see neighbourhood.
+	xs, ys [][3]int
+	// parent holds the rows of the parent chosen so far, with the cell of column x in bit x.
+	parent []uint64
+	// checks holds for every row of the parent the rows of f that can be checked once it is chosen.
+	checks [][]uint
+	nodes  uint
+}
+
+// row tries every choice of row y of the parent and searches on below it, and reports whether the whole parent was
+// found.
+func (s *predecessorSearch) row(y int) (bool, error) {
+	if y == len(s.parent) {
+		return true, nil
+	}
+	end := uint64(1) << s.f.width
+	for choice := uint64(0); ; choice++ {
+		if s.nodes++; s.nodes%4096 == 0 {
+			if err := s.ctx.Err(); err != nil {
+				return false, err
+			}
+		}
+		s.parent[y] = choice
+		if s.matches(y) {
+			found, err := s.row(y + 1)
+			if found || err != nil {
+				return found, err
+			}
+		}
+		// With a width of 64, end wraps around to 0, and the last choice has all bits set.
+		if choice == end-1 {
+			return false, nil
+		}
+	}
+}
+
+// matches reports whether the rows of f that depend on no rows of the parent below y come out right.
+func (s *predecessorSearch) matches(y int) bool {
+	for _, r := range s.checks[y] {
+		row := s.f.s[r]
+		for x, nx := range s.xs {
+			var alive bool
+			var neighbours uint8
+			for j, ny := range s.ys[r] {
+				if ny < 0 {
+					continue
+				}
+				for i, c := range nx {
+					if c < 0 || s.parent[ny]&(1<<uint(c)) == 0 {
+						continue
+					}
+					if i == 1 && j == 1 {
+						alive = true
+					} else {
+						neighbours++
+					}
+				}
+			}
+			if s.f.rule.Next(alive, neighbours) != row[x] {
+				return false
+			}
+		}
+	}
+	return true
+}
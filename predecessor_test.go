@@ -0,0 +1,109 @@
+package life
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// checkPredecessor checks that ticking p gives f.
+func checkPredecessor(t *testing.T, p, f *Field) {
+	t.Helper()
+	g := NewGameFromField(p.Clone())
+	g.SetRule(f.rule)
+	g.Tick()
+	if !equalCells(g.Field(), f) || p.wrap != f.wrap {
+		t.Errorf("the predecessor\n%sof\n%sbecomes\n%s", p, f, g.Field())
+	}
+}
+
+func TestFindPredecessorBlock(t *testing.T) {
+	for _, wrap := range []bool{false, true} {
+		f := fieldFromRows(wrap,
+			"......",
+			"......",
+			"..oo..",
+			"..oo..",
+			"......",
+			"......",
+		)
+		p, ok, err := FindPredecessor(context.Background(), f)
+		if err != nil || !ok {
+			t.Fatalf("wrap=%v: got %v, %v, wanted a predecessor", wrap, ok, err)
+		}
+		checkPredecessor(t, p, f)
+	}
+}
+
+// Every 3x3 field is compared with what all 512 fields of its size become.
+func TestFindPredecessorExhaustive(t *testing.T) {
+	for _, wrap := range []bool{false, true} {
+		children := make(map[string]bool)
+		for bits := 0; bits < 1<<9; bits++ {
+			p := NewField(3, 3, wrap)
+			for i := uint(0); i < 9; i++ {
+				p.Set(i%3, i/3, bits&(1<<i) != 0)
+			}
+			g := NewGameFromField(p)
+			g.Tick()
+			children[g.Field().String()] = true
+		}
+		orphans := 0
+		for bits := 0; bits < 1<<9; bits++ {
+			f := NewField(3, 3, wrap)
+			for i := uint(0); i < 9; i++ {
+				f.Set(i%3, i/3, bits&(1<<i) != 0)
+			}
+			p, ok, err := FindPredecessor(context.Background(), f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != children[f.String()] {
+				t.Fatalf("wrap=%v: got %v for\n%s", wrap, ok, f)
+			}
+			if ok {
+				checkPredecessor(t, p, f)
+			} else {
+				orphans++
+			}
+		}
+		if orphans == 0 {
+			t.Errorf("wrap=%v: every 3x3 field has a predecessor", wrap)
+		}
+	}
+}
+
+func TestFindPredecessorGardenOfEden(t *testing.T) {
+	// No field of 5x5 cells becomes this one, as trying all 2^25 of them shows, which takes too long for a test.
+	f := fieldFromRows(false,
+		"o....",
+		"o.o.o",
+		".o.o.",
+		"o.o.o",
+		"....o",
+	)
+	_, ok, err := FindPredecessor(context.Background(), f)
+	if err != nil || ok {
+		t.Errorf("got %v, %v, wanted no predecessor", ok, err)
+	}
+}
+
+func TestFindPredecessorCancel(t *testing.T) {
+	f := NewField(24, 24, true)
+	f.Randomize(0.5, rand.New(rand.NewSource(1)))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, ok, err := FindPredecessor(ctx, f)
+	if ok || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, %v, wanted the search to run out of time", ok, err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("the search stopped %v after the deadline", d)
+	}
+	if _, _, err := FindPredecessor(context.Background(), NewField(65, 2, false)); err == nil {
+		t.Error("got no error for a field wider than 64 cells")
+	}
+}
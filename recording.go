@@ -0,0 +1,385 @@
+package life
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A recording, see Game.StartRecording, starts with recordingMagic and a version byte, followed by records. Every
+// record is a kind byte, the length of its payload as uvarint and the payload. The first record is the header, with
+// whether the field wraps and the rule, and every generation after it is either a keyframe with all of its live cells
+// or a diff with the cells that came to life and died since the one before. Numbers are uvarints, and cells are given
+// by their index y*width+x, each as the distance to the one before it in the list, so that lists are short.
+const (
+	recordingMagic   = "LIFE-RUN"
+	recordingVersion = 1
+
+	recordHeader   = 'H'
+	recordKeyframe = 'K'
+	recordDiff     = 'D'
+
+	// maxRecordSize keeps a corrupt length from allocating all of the memory.
+	maxRecordSize = 1 << 30
+)
+
+// KeyframeInterval is how often recordings hold all live cells of a generation instead of the changes since the
+// generation before, see Game.StartRecording.
+const KeyframeInterval = 100
+
+// errTruncated is returned by OpenReplay for recordings that end within a record.
+var errTruncated = errors.New("recording is truncated")
+
+// recording is the state of a game that is being recorded.
+type recording struct {
+	w io.Writer
+	// last holds the cells of the last recorded generation, which the next one is compared with.
+	last          []bool
+	width, height uint
+	buf           []byte
+	err           error
+}
+
+// StartRecording starts writing the run of the game to w: its rule and current generation, and after every tick
+// the cells that came to life and died. Every KeyframeInterval generations, and whenever the field was resized, all
+// live cells are written instead, which lets a Replay seek quickly. Cells changed with Field.Set between ticks are
+// recorded as well, the rule is that of the game when the recording starts.
+//
+// The records are written to w as they come, so w should be buffered. The first error that occurs while writing is
+// returned by StopRecording, and ends the recording. See OpenReplay to play a recording back.
+func (g *Game) StartRecording(w io.Writer) error {
+	if g.recording != nil {
+		return errors.New("the game is already being recorded")
+	}
+	if _, err := io.WriteString(w, recordingMagic+string(rune(recordingVersion))); err != nil {
+		return err
+	}
+	r := &recording{w: w}
+	header := []byte{0}
+	if g.current.wrap {
+		header[0] = 1
+	}
+	header = append(header, g.current.rule.String()...)
+	r.write(recordHeader, header)
+	r.keyframe(g)
+	if r.err != nil {
+		return r.err
+	}
+	g.recording = r
+	return nil
+}
+
+// StopRecording stops recording the game and returns the first error that occurred while writing, if any.
+func (g *Game) StopRecording() error {
+	if g.recording == nil {
+		return nil
+	}
+	err := g.recording.err
+	g.recording = nil
+	return err
+}
+
+// record writes the current generation of g, as a keyframe or a diff.
+func (r *recording) record(g *Game) {
+	if r.err != nil {
+		return
+	}
+	f := g.current
+	if g.generation%KeyframeInterval == 0 || f.width != r.width || f.height != r.height {
+		r.keyframe(g)
+		return
+	}
+	var births, deaths []uint64
+	for y, row := range f.s {
+		last := r.last[uint(y)*f.width:]
+		for x, alive := range row {
+			if alive != last[x] {
+				i := uint64(uint(y)*f.width + uint(x))
+				if alive {
+					births = append(births, i)
+				} else {
+					deaths = append(deaths, i)
+				}
+				last[x] = alive
+			}
+		}
+	}
+	payload := appendCells(nil, births)
+	r.write(recordDiff, appendCells(payload, deaths))
+}
+
+// keyframe writes the current generation of g with all of its live cells.
+func (r *recording) keyframe(g *Game) {
+	f := g.current
+	if f.width != r.width || f.height != r.height {
+		r.last = make([]bool, f.width*f.height)
+		r.width, r.height = f.width, f.height
+	}
+	var cells []uint64
+	for y, row := range f.s {
+		copy(r.last[uint(y)*f.width:], row)
+		for x, alive := range row {
+			if alive {
+				cells = append(cells, uint64(uint(y)*f.width+uint(x)))
+			}
+		}
+	}
+	payload := appendUvarint(nil, uint64(g.generation))
+	payload = appendUvarint(payload, uint64(f.width))
+	payload = appendUvarint(payload, uint64(f.height))
+	r.write(recordKeyframe, appendCells(payload, cells))
+}
+
+// write writes a record of the given kind, unless writing failed before.
+func (r *recording) write(kind byte, payload []byte) {
+	if r.err != nil {
+		return
+	}
+	r.buf = append(r.buf[:0], kind)
+	r.buf = appendUvarint(r.buf, uint64(len(payload)))
+	r.buf = append(r.buf, payload...)
+	_, r.err = r.w.Write(r.buf)
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// appendCells appends the number of cells and their increasing indices, each as the distance to the one before.
+func appendCells(b []byte, cells []uint64) []byte {
+	b = appendUvarint(b, uint64(len(cells)))
+	next := uint64(0)
+	for _, i := range cells {
+		b = appendUvarint(b, i-next)
+		next = i + 1
+	}
+	return b
+}
+
+// Replay plays back a recording written by Game.StartRecording. It starts at the first recorded generation, and
+// moves through the others with Next and Seek.
+type Replay struct {
+	rule Rule
+	wrap bool
+	// records holds the payloads of the records of the generations from first on, and keyframe whether each is a
+	// keyframe.
+	records  [][]byte
+	keyframe []bool
+	first    uint
+	pos      int
+	f        *Field
+}
+
+// OpenReplay reads the recording in r. An error is returned if r isn't a recording of a version that is supported,
+// or if it is truncated or corrupt.
+func OpenReplay(r io.Reader) (*Replay, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(recordingMagic)+1)
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic[:len(recordingMagic)]) != recordingMagic {
+		return nil, errors.New("not a recording")
+	}
+	if v := magic[len(recordingMagic)]; v != recordingVersion {
+		return nil, fmt.Errorf("recording of the unsupported version %d", v)
+	}
+	p := new(Replay)
+	for n := 0; ; n++ {
+		kind, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, truncated(err)
+		}
+		if size > maxRecordSize {
+			return nil, fmt.Errorf("record %d has a size of %d bytes, more than the supported %d", n, size, maxRecordSize)
+		}
+		// The payload is read as it comes rather than into a buffer of its size, as a corrupt size could be huge.
+		payload, err := io.ReadAll(io.LimitReader(br, int64(size)))
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(payload)) != size {
+			return nil, errTruncated
+		}
+		switch {
+		case n == 0 && kind == recordHeader:
+			if len(payload) == 0 {
+				return nil, errors.New("empty header")
+			}
+			p.wrap = payload[0] == 1
+			if p.rule, err = ParseRule(string(payload[1:])); err != nil {
+				return nil, fmt.Errorf("header: %w", err)
+			}
+		case n == 0:
+			return nil, errors.New("the recording doesn't start with a header")
+		case n == 1 && kind != recordKeyframe:
+			return nil, errors.New("the first generation of the recording isn't a keyframe")
+		case kind == recordKeyframe || kind == recordDiff:
+			p.records = append(p.records, payload)
+			p.keyframe = append(p.keyframe, kind == recordKeyframe)
+		default:
+			return nil, fmt.Errorf("record %d is of the unknown kind %q", n, kind)
+		}
+	}
+	if len(p.records) == 0 {
+		return nil, errTruncated
+	}
+	if err := p.apply(0); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// truncated returns errTruncated for an error that says that the input ended early.
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errTruncated
+	}
+	return err
+}
+
+// Next moves to the next generation. It returns io.EOF after the last one.
+func (p *Replay) Next() error {
+	if p.pos+1 >= len(p.records) {
+		return io.EOF
+	}
+	return p.apply(p.pos + 1)
+}
+
+// Seek moves to the given generation, starting from the keyframe before it unless that is further back than the
+// current generation.
+func (p *Replay) Seek(gen uint) error {
+	if gen < p.first || gen > p.Last() {
+		return fmt.Errorf("generation %d isn't recorded, only %d to %d are", gen, p.first, p.Last())
+	}
+	i := int(gen - p.first)
+	start := i
+	for !p.keyframe[start] {
+		start--
+	}
+	if p.pos < start || p.pos > i {
+		if err := p.apply(start); err != nil {
+			return err
+		}
+	}
+	for p.pos < i {
+		if err := p.apply(p.pos + 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Generation returns the current generation.
+func (p *Replay) Generation() uint {
+	return p.first + uint(p.pos)
+}
+
+// Last returns the last recorded generation.
+func (p *Replay) Last() uint {
+	return p.first + uint(len(p.records)) - 1
+}
+
+// Rule returns the rule of the recorded game when the recording started.
+func (p *Replay) Rule() Rule {
+	return p.rule
+}
+
+// Field returns the field holding the current generation.
+// The returned field is reused by the replay and is only valid until the next call to Next or Seek.
+func (p *Replay) Field() *Field {
+	return p.f
+}
+
+// apply moves to record i, which must be a keyframe or the one after the current record.
+func (p *Replay) apply(i int) error {
+	b := p.records[i]
+	if p.keyframe[i] {
+		var header [3]uint64
+		for j := range header {
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return fmt.Errorf("generation %d: corrupt keyframe", p.first+uint(i))
+			}
+			header[j], b = v, b[n:]
+		}
+		width, height := uint(header[1]), uint(header[2])
+		if width == 0 || height == 0 || header[1] > maxRecordSize || header[2] > maxRecordSize/header[1] {
+			return fmt.Errorf("generation %d: keyframe of the invalid size %dx%d", p.first+uint(i), header[1], header[2])
+		}
+		cells, b, err := readCells(b, width*height)
+		if err != nil || len(b) != 0 {
+			return fmt.Errorf("generation %d: corrupt keyframe", p.first+uint(i))
+		}
+		f := NewField(width, height, p.wrap)
+		f.rule = p.rule
+		for _, c := range cells {
+			f.s[c/width][c%width] = true
+		}
+		f.recount()
+		if i == 0 {
+			p.first = uint(header[0])
+		} else if header[0] != uint64(p.first)+uint64(i) {
+			return fmt.Errorf("generation %d: keyframe of generation %d", p.first+uint(i), header[0])
+		}
+		p.f, p.pos = f, i
+		return nil
+	}
+	f := p.f
+	births, b, err := readCells(b, f.width*f.height)
+	if err != nil {
+		return fmt.Errorf("generation %d: corrupt diff", p.first+uint(i))
+	}
+	deaths, b, err := readCells(b, f.width*f.height)
+	if err != nil || len(b) != 0 {
+		return fmt.Errorf("generation %d: corrupt diff", p.first+uint(i))
+	}
+	// The cells are checked before any is changed, so that a corrupt diff leaves the field as it was.
+	for _, c := range births {
+		if f.s[c/f.width][c%f.width] {
+			return fmt.Errorf("generation %d: a live cell comes to life", p.first+uint(i))
+		}
+	}
+	for _, c := range deaths {
+		if !f.s[c/f.width][c%f.width] {
+			return fmt.Errorf("generation %d: a dead cell dies", p.first+uint(i))
+		}
+	}
+	for _, c := range births {
+		f.Set(c%f.width, c/f.width, true)
+	}
+	for _, c := range deaths {
+		f.Set(c%f.width, c/f.width, false)
+	}
+	p.pos = i
+	return nil
+}
+
+// readCells reads a list of cells written by appendCells from b, and returns the rest of b. All cells must be below
+// n.
+func readCells(b []byte, n uint) ([]uint, []byte, error) {
+	count, size := binary.Uvarint(b)
+	// Every cell takes at least a byte, which also keeps a corrupt count from allocating too much.
+	if size <= 0 || count > uint64(len(b)-size) {
+		return nil, nil, errors.New("corrupt list of cells")
+	}
+	b = b[size:]
+	cells := make([]uint, count)
+	next := uint64(0)
+	for i := range cells {
+		d, size := binary.Uvarint(b)
+		if size <= 0 || d >= uint64(n) || next+d >= uint64(n) {
+			return nil, nil, errors.New("corrupt list of cells")
+		}
+		cells[i] = uint(next + d)
+		next += d + 1
+		b = b[size:]
+	}
+	return cells, b, nil
+}
@@ -0,0 +1,143 @@
+package life
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// recordRun records a run of 250 generations of a random game, with a cell set by hand and a resize on the way, and
+// returns the recording and the fields of all generations.
+func recordRun(t *testing.T) ([]byte, []string) {
+	t.Helper()
+	g := NewRandomGame(40, 30, true, 0.3, rand.New(rand.NewSource(3)))
+	rule, _ := ParseRule("B36/S23")
+	g.SetRule(rule)
+	var buf bytes.Buffer
+	if err := g.StartRecording(&buf); err != nil {
+		t.Fatal(err)
+	}
+	fields := []string{g.Field().String()}
+	for g.Generation() < 250 {
+		switch g.Generation() {
+		case 42:
+			g.Field().Set(0, 0, !g.Field().Alive(0, 0))
+		case 150:
+			g.Resize(50, 20)
+		}
+		g.Tick()
+		fields = append(fields, g.Field().String())
+	}
+	if err := g.StopRecording(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), fields
+}
+
+func TestReplay(t *testing.T) {
+	recorded, fields := recordRun(t)
+	// The recording is far smaller than the frames of the run.
+	frames := 0
+	for _, f := range fields {
+		frames += len(f)
+	}
+	if len(recorded) > frames/8 {
+		t.Errorf("got a recording of %d bytes, wanted less than an eighth of the %d bytes of the frames", len(recorded),
+			frames)
+	}
+	p, err := OpenReplay(bytes.NewReader(recorded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Generation() != 0 || p.Last() != 250 || p.Rule().String() != "B36/S23" {
+		t.Errorf("got generations %d to %d with rule %v, wanted 0 to 250 with B36/S23", p.Generation(), p.Last(), p.Rule())
+	}
+	for gen := 0; ; gen++ {
+		if got := p.Field().String(); got != fields[gen] {
+			t.Fatalf("generation %d: got\n%s\nwanted\n%s", gen, got, fields[gen])
+		}
+		if p.Field().wrap != true || p.Field().rule != p.Rule() {
+			t.Fatalf("generation %d: the field doesn't wrap or has another rule", gen)
+		}
+		err := p.Next()
+		if err == io.EOF {
+			if gen != 250 {
+				t.Errorf("got io.EOF after generation %d, wanted 250", gen)
+			}
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Back and forth, across keyframes and the resize.
+	for _, gen := range []uint{120, 99, 100, 101, 250, 0, 149, 151, 150, 42, 43, 199, 200} {
+		if err := p.Seek(gen); err != nil {
+			t.Fatal(err)
+		}
+		if p.Generation() != gen || p.Field().String() != fields[gen] {
+			t.Errorf("seeking %d: got generation %d:\n%s\nwanted\n%s", gen, p.Generation(), p.Field(), fields[gen])
+		}
+	}
+	if err := p.Seek(251); err == nil {
+		t.Error("got no error seeking beyond the recording")
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	recorded, _ := recordRun(t)
+	// Cut within every record of the start, and at some later points.
+	cuts := []int{0, 5, 9, 10, 11, 12, 15, 20, 30, 50, 100, 200, len(recorded) / 2, len(recorded) - 1}
+	for _, n := range cuts {
+		if _, err := OpenReplay(bytes.NewReader(recorded[:n])); err == nil {
+			t.Errorf("got no error for the first %d bytes of the recording", n)
+		}
+	}
+	_, err := OpenReplay(bytes.NewReader(recorded[:len(recorded)-1]))
+	if err == nil || !strings.Contains(err.Error(), "truncated") {
+		t.Errorf("got %v for a truncated recording, wanted it to say so", err)
+	}
+
+	version := append([]byte(nil), recorded...)
+	version[len(recordingMagic)] = 2
+	if _, err := OpenReplay(bytes.NewReader(version)); err == nil || !strings.Contains(err.Error(), "version 2") {
+		t.Errorf("got %v for a recording of version 2", err)
+	}
+	if _, err := OpenReplay(strings.NewReader("x = 3, y = 3\nbo$2bo$3o!\n")); err == nil {
+		t.Error("got no error for an RLE pattern")
+	}
+}
+
+func TestRecordingWriteError(t *testing.T) {
+	g := gameFromRows(true, ".o.", ".o.", ".o.")
+	w := &failingWriter{n: 3}
+	if err := g.StartRecording(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartRecording(w); err == nil {
+		t.Error("got no error for recording twice")
+	}
+	for i := 0; i < 5; i++ {
+		g.Tick()
+	}
+	if err := g.StopRecording(); err == nil {
+		t.Error("got no error from a writer that failed")
+	}
+	if w.n != 0 {
+		t.Errorf("writing went on after the first error")
+	}
+}
+
+// failingWriter fails all writes after the first n.
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if w.n == 0 {
+		return 0, io.ErrClosedPipe
+	}
+	w.n--
+	return len(b), nil
+}
@@ -0,0 +1,356 @@
+package life
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Renderer draws the cells of a field as text.
+type Renderer interface {
+	// Render writes a textual representation of f to w.
+	Render(w io.Writer, f *Field) error
+}
+
+// BlockRenderer draws every cell as a single character: '█' for alive cells and ' ' for dead cells.
+// Unless scaled, it produces the same output as Field.String.
+type BlockRenderer struct {
+	// Scale draws every cell as a block of Scale characters side by side, on (Scale+1)/2 lines. As characters are
+	// about twice as tall as they are wide, an even Scale draws square cells. 0 and 1 draw one character per cell.
+	Scale uint
+}
+
+// Render writes f to w using one character per cell, or a block of them if scaled.
+func (br BlockRenderer) Render(w io.Writer, f *Field) error {
+	if br.Scale <= 1 {
+		_, err := f.WriteTo(w)
+		return err
+	}
+	cols, rows := scaleSize(br.Scale)
+	var b []byte
+	for y := uint(0); y < f.height; y++ {
+		start := len(b)
+		for _, alive := range f.s[y] {
+			b = appendCell(b, alive, cols)
+		}
+		b = append(b, '\n')
+		b = repeatLine(b, start, rows)
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// scaleSize returns the number of columns and rows of characters a cell is drawn as at the given scale.
+func scaleSize(scale uint) (columns, rows uint) {
+	if scale <= 1 {
+		return 1, 1
+	}
+	return scale, (scale + 1) / 2
+}
+
+// repeatLine appends the line that starts at b[start:] until rows copies of it end b.
+func repeatLine(b []byte, start int, rows uint) []byte {
+	end := len(b)
+	for i := uint(1); i < rows; i++ {
+		b = append(b, b[start:end]...)
+	}
+	return b
+}
+
+// HalfBlockRenderer packs two rows of cells into every line of output using the Unicode half-block characters.
+// Because terminal cells are roughly twice as tall as they are wide, this keeps the aspect ratio of
+// patterns intact and doubles the number of rows that fit on screen.
+//
+//	upper lower	glyph
+//	dead  dead	' '
+//	alive dead	'▀'
+//	dead  alive	'▄'
+//	alive alive	'█'
+//
+// If the field has an odd height, the lower half of the last line is considered dead.
+type HalfBlockRenderer struct{}
+
+var halfBlocks = [4]rune{' ', '▀', '▄', '█'}
+
+// Render writes f to w using one character per two vertically adjacent cells.
+func (HalfBlockRenderer) Render(w io.Writer, f *Field) error {
+	b := new(strings.Builder)
+	for y := uint(0); y < f.height; y += 2 {
+		for x := uint(0); x < f.width; x++ {
+			var i int
+			if f.s[y][x] {
+				i |= 1
+			}
+			// Don't use Alive here, it would wrap the missing row of an odd height field to the top.
+			if y+1 < f.height && f.s[y+1][x] {
+				i |= 2
+			}
+			b.WriteRune(halfBlocks[i])
+		}
+		b.WriteRune('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// BrailleRenderer packs a block of 2×4 cells into every character of output using the Unicode braille patterns
+// (U+2800 to U+28FF), where every dot of a character represents one cell:
+//
+//	(0,0) dot 1	(1,0) dot 4
+//	(0,1) dot 2	(1,1) dot 5
+//	(0,2) dot 3	(1,2) dot 6
+//	(0,3) dot 7	(1,3) dot 8
+//
+// Blocks that extend past the right or bottom edge of the field are padded with dead cells.
+type BrailleRenderer struct{}
+
+// brailleDots maps the position of a cell within a 2×4 block to its dot bit, indexed by [y][x].
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Render writes f to w using one character per 2×4 block of cells.
+func (BrailleRenderer) Render(w io.Writer, f *Field) error {
+	b := new(strings.Builder)
+	for y := uint(0); y < f.height; y += 4 {
+		for x := uint(0); x < f.width; x += 2 {
+			r := rune(0x2800)
+			for dy := uint(0); dy < 4 && y+dy < f.height; dy++ {
+				for dx := uint(0); dx < 2 && x+dx < f.width; dx++ {
+					if f.s[y+dy][x+dx] {
+						r |= brailleDots[dy][dx]
+					}
+				}
+			}
+			b.WriteRune(r)
+		}
+		b.WriteRune('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ZoomRenderer zooms out of fields too large to draw a character per cell, by drawing every block of Block×Block
+// cells as a single character that shows how many of them are alive:
+//
+//	alive cells	glyph
+//	none		' '
+//	up to 1/4	'░'
+//	up to 1/2	'▒'
+//	up to 3/4	'▓'
+//	more		'█'
+//
+// Blocks at the right and bottom edge that are cut off by the field are measured by the cells they have, so a
+// narrow column of live cells along the edge is drawn as full as one in the middle.
+type ZoomRenderer struct {
+	// Block is the width and height of the blocks of cells. If 0, the smallest one is chosen that fits the field
+	// into Columns×Rows characters, see ZoomToFit.
+	Block uint
+	// Columns and Rows are the size of the output that blocks are chosen to fit if Block is 0.
+	Columns, Rows uint
+}
+
+// zoomGlyphs are the glyphs of ZoomRenderer by the quarters of a block that are alive, rounded up.
+var zoomGlyphs = [5]rune{' ', '░', '▒', '▓', '█'}
+
+// Render writes f to w using one character per block of cells.
+func (zr ZoomRenderer) Render(w io.Writer, f *Field) error {
+	n := zr.Block
+	if n == 0 {
+		n = ZoomToFit(f.width, f.height, zr.Columns, zr.Rows)
+	}
+	b := new(strings.Builder)
+	for y := uint(0); y < f.height; y += n {
+		for x := uint(0); x < f.width; x += n {
+			var alive, cells uint
+			for _, row := range f.s[y:minUint(y+n, f.height)] {
+				for _, a := range row[x:minUint(x+n, f.width)] {
+					if a {
+						alive++
+					}
+					cells++
+				}
+			}
+			b.WriteRune(zoomGlyphs[(4*alive+cells-1)/cells])
+		}
+		b.WriteRune('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ZoomToFit returns the smallest width and height of blocks of cells that fits a field of the given size into the
+// given number of columns and rows of characters with ZoomRenderer, at least 1. Columns or rows of 0 don't limit
+// the size.
+func ZoomToFit(width, height, columns, rows uint) uint {
+	n := uint(1)
+	if columns > 0 && width > columns {
+		n = (width + columns - 1) / columns
+	}
+	if rows > 0 && height > rows {
+		if m := (height + rows - 1) / rows; m > n {
+			n = m
+		}
+	}
+	return n
+}
+
+func minUint(a, b uint) uint {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// AgeRenderer draws every cell as a single character like BlockRenderer, but colours living cells by their age
+// using ANSI escape sequences. Every line that sets a colour ends with a reset sequence, so the colour never bleeds
+// into whatever follows the line.
+// If the field doesn't keep track of ages, see Game.TrackAges, AgeRenderer falls back to BlockRenderer.
+type AgeRenderer struct {
+	// Palette holds the colours to use from newborn cells onwards: a cell of age n is drawn in Palette[n-1], and
+	// every cell older than the palette is drawn in its last colour. If empty, DefaultPalette256 or DefaultPalette8 is used.
+	Palette []uint8
+	// Basic selects the 8 standard colours (0 to 7) instead of the 256-colour palette.
+	Basic bool
+	// Scale draws every cell as a block of characters, like the Scale of BlockRenderer.
+	Scale uint
+}
+
+var (
+	// DefaultPalette256 fades from bright white newborns through yellow and orange to dark red, using 256-colour indices.
+	DefaultPalette256 = []uint8{231, 229, 227, 226, 220, 214, 208, 202, 196, 160, 124, 88}
+	// DefaultPalette8 fades from white newborns through yellow and red to magenta, using the 8 standard colours.
+	DefaultPalette8 = []uint8{7, 3, 1, 5}
+)
+
+const resetColour = "\x1b[0m"
+
+// Render writes f to w using one coloured character per cell.
+func (ar AgeRenderer) Render(w io.Writer, f *Field) error {
+	if !f.HasAges() {
+		return BlockRenderer{Scale: ar.Scale}.Render(w, f)
+	}
+	palette := ar.Palette
+	if len(palette) == 0 {
+		palette = DefaultPalette256
+		if ar.Basic {
+			palette = DefaultPalette8
+		}
+	}
+	cols, rows := scaleSize(ar.Scale)
+	var b []byte
+	for y := uint(0); y < f.height; y++ {
+		start := len(b)
+		colour := -1
+		for x := uint(0); x < f.width; x++ {
+			age := f.age[y][x]
+			if age == 0 {
+				for i := uint(0); i < cols; i++ {
+					b = append(b, deadGlyph...)
+				}
+				continue
+			}
+			i := uint(len(palette) - 1)
+			if uint(age-1) < i {
+				i = uint(age - 1)
+			}
+			// Dead cells are blank, so the colour only needs to change when the next living cell differs.
+			if c := int(palette[i]); c != colour {
+				colour = c
+				if ar.Basic {
+					b = append(b, "\x1b[3"...)
+				} else {
+					b = append(b, "\x1b[38;5;"...)
+				}
+				b = strconv.AppendInt(b, int64(c), 10)
+				b = append(b, 'm')
+			}
+			for i := uint(0); i < cols; i++ {
+				b = append(b, aliveGlyph...)
+			}
+		}
+		if colour != -1 {
+			b = append(b, resetColour...)
+		}
+		b = append(b, '\n')
+		b = repeatLine(b, start, rows)
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// CharactersPerCell returns the number of columns and rows of characters that r draws a single cell as, e.g. 2 and
+// 1 for a BlockRenderer with a Scale of 2. Renderers that draw several cells per character, see CellsPerCharacter,
+// and those other than the ones of this package are taken to draw a cell as at most one character.
+func CharactersPerCell(r Renderer) (columns, rows uint) {
+	switch r := r.(type) {
+	case BlockRenderer:
+		return scaleSize(r.Scale)
+	case AgeRenderer:
+		return scaleSize(r.Scale)
+	case *DiffRenderer:
+		return scaleSize(r.Scale)
+	case FrameRenderer:
+		if r.Renderer != nil {
+			return CharactersPerCell(r.Renderer)
+		}
+	}
+	return 1, 1
+}
+
+// CellsPerCharacter returns the number of columns and rows of cells that r draws as a single character, e.g. 1 and
+// 2 for HalfBlockRenderer. Renderers other than those of this package are taken to draw one cell per character.
+func CellsPerCharacter(r Renderer) (columns, rows uint) {
+	switch r := r.(type) {
+	case HalfBlockRenderer:
+		return 1, 2
+	case BrailleRenderer:
+		return 2, 4
+	case ZoomRenderer:
+		if r.Block > 1 {
+			return r.Block, r.Block
+		}
+	case *ZoomRenderer:
+		// Only Block is read, as the other fields may change while r renders.
+		if r.Block > 1 {
+			return r.Block, r.Block
+		}
+	case FrameRenderer:
+		if r.Renderer != nil {
+			return CellsPerCharacter(r.Renderer)
+		}
+	}
+	return 1, 1
+}
+
+var renderers = map[string]Renderer{
+	"age":       AgeRenderer{},
+	"block":     BlockRenderer{},
+	"braille":   BrailleRenderer{},
+	"halfblock": HalfBlockRenderer{},
+}
+
+// LookupRenderer returns the renderer registered under name.
+// An error is returned if no such renderer exists.
+func LookupRenderer(name string) (Renderer, error) {
+	r, ok := renderers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown renderer %q (available: %s)", name, strings.Join(RendererNames(), ", "))
+	}
+	return r, nil
+}
+
+// RendererNames returns the names of all registered renderers in alphabetical order.
+func RendererNames() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,369 @@
+package life
+
+import (
+	"strings"
+	"testing"
+)
+
+// fieldFromRows builds a field from rows of '.' (dead) and 'o' (alive) characters.
+func fieldFromRows(wrap bool, rows ...string) *Field {
+	f := NewField(uint(len(rows[0])), uint(len(rows)), wrap)
+	for y, row := range rows {
+		for x, c := range row {
+			f.Set(uint(x), uint(y), c == 'o')
+		}
+	}
+	return f
+}
+
+func TestHalfBlockRenderer(t *testing.T) {
+	testCases := []struct {
+		name string
+		rows []string
+		want string
+	}{
+		{
+			name: "glider",
+			rows: []string{
+				".o.",
+				"..o",
+				"ooo",
+			},
+			want: " ▀▄\n" +
+				"▀▀▀\n",
+		},
+		{
+			name: "all pairs",
+			rows: []string{
+				".o.o",
+				"..oo",
+			},
+			want: " ▀▄█\n",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			for _, wrap := range []bool{true, false} {
+				b := new(strings.Builder)
+				if err := (HalfBlockRenderer{}).Render(b, fieldFromRows(wrap, test.rows...)); err != nil {
+					t.Fatal(err)
+				}
+				if got := b.String(); got != test.want {
+					t.Errorf("wrap %t: got:\n%q\nwanted:\n%q", wrap, got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBrailleRenderer(t *testing.T) {
+	testCases := []struct {
+		name string
+		rows []string
+		want string
+	}{
+		{
+			name: "dot order",
+			// Every block of 2×4 cells sets exactly one dot, in dot number order 1 through 8.
+			rows: []string{
+				"o......o........",
+				"..o......o......",
+				"....o......o....",
+				"............o..o",
+			},
+			want: "\u2801\u2802\u2804\u2808\u2810\u2820\u2840\u2880\n",
+		},
+		{
+			name: "partial blocks",
+			rows: []string{
+				"ooo",
+				"ooo",
+				"ooo",
+				"ooo",
+				"ooo",
+			},
+			want: "\u28ff\u2847\n" +
+				"\u2809\u2801\n",
+		},
+		{
+			name: "empty",
+			rows: []string{
+				"...",
+			},
+			want: "\u2800\u2800\n",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			b := new(strings.Builder)
+			if err := (BrailleRenderer{}).Render(b, fieldFromRows(true, test.rows...)); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != test.want {
+				t.Errorf("got:\n%q\nwanted:\n%q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAgeRenderer(t *testing.T) {
+	f := fieldFromRows(true,
+		"o.oo.o",
+		"......",
+	)
+	f.age = [][]uint32{
+		{1, 0, 2, 2, 0, 9},
+		{0, 0, 0, 0, 0, 0},
+	}
+	testCases := []struct {
+		name     string
+		renderer AgeRenderer
+		want     string
+	}{
+		{
+			name:     "256 colours",
+			renderer: AgeRenderer{Palette: []uint8{231, 196}},
+			want:     "\x1b[38;5;231m█ \x1b[38;5;196m██ █\x1b[0m\n      \n",
+		},
+		{
+			name:     "8 colours",
+			renderer: AgeRenderer{Basic: true},
+			want:     "\x1b[37m█ \x1b[33m██ \x1b[35m█\x1b[0m\n      \n",
+		},
+		{
+			name:     "scaled",
+			renderer: AgeRenderer{Basic: true, Scale: 2},
+			want:     "\x1b[37m██  \x1b[33m████  \x1b[35m██\x1b[0m\n            \n",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			b := new(strings.Builder)
+			if err := test.renderer.Render(b, f); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != test.want {
+				t.Errorf("got:\n%q\nwanted:\n%q", got, test.want)
+			}
+		})
+	}
+
+	t.Run("without ages", func(t *testing.T) {
+		f := fieldFromRows(true, "o.o")
+		b := new(strings.Builder)
+		if err := (AgeRenderer{}).Render(b, f); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := b.String(), f.String(); got != want {
+			t.Errorf("got:\n%q\nwanted:\n%q", got, want)
+		}
+	})
+}
+
+func TestLookupRenderer(t *testing.T) {
+	for _, name := range RendererNames() {
+		if _, err := LookupRenderer(name); err != nil {
+			t.Errorf("LookupRenderer(%q): %v", name, err)
+		}
+	}
+	if _, err := LookupRenderer("nope"); err == nil {
+		t.Error("expected an error for an unknown renderer")
+	}
+}
+
+func TestScaledBlockRenderer(t *testing.T) {
+	f := fieldFromRows(true,
+		"o.",
+		".o",
+	)
+	for _, test := range []struct {
+		scale uint
+		want  string
+	}{
+		{1, "█ \n █\n"},
+		{2, "██  \n  ██\n"},
+		{3, "███   \n███   \n   ███\n   ███\n"},
+	} {
+		b := new(strings.Builder)
+		if err := (BlockRenderer{Scale: test.scale}).Render(b, f); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("scale %d: got %q, wanted %q", test.scale, got, test.want)
+		}
+	}
+}
+
+func TestZoomRenderer(t *testing.T) {
+	// In blocks of 4×4 cells, the blocks along the right and bottom edge are cut off to 2×4, 4×2 and 2×2 cells.
+	f := fieldFromRows(false,
+		"....oooooo",
+		"........oo",
+		"........o.",
+		"..........",
+		"o...oo..oo",
+		"....oo..oo",
+	)
+	for _, test := range []struct {
+		zr   ZoomRenderer
+		want string
+	}{
+		// 0, 4 of 16 and 5 of 8 cells, then 1 of 8, 4 of 8 and 4 of 4.
+		{ZoomRenderer{Block: 4}, " ░▓\n░▒█\n"},
+		{ZoomRenderer{Block: 2}, "  ▒▒█\n    ░\n░ █ █\n"},
+		{ZoomRenderer{Block: 1}, f.String()},
+		{ZoomRenderer{Block: 10}, "▒\n"},
+		{ZoomRenderer{Columns: 5}, "  ▒▒█\n    ░\n░ █ █\n"},
+		{ZoomRenderer{Columns: 3, Rows: 2}, " ░▓\n░▒█\n"},
+		{ZoomRenderer{}, f.String()},
+	} {
+		b := new(strings.Builder)
+		if err := test.zr.Render(b, f); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%+v: got:\n%s\nwanted:\n%s", test.zr, got, test.want)
+		}
+	}
+}
+
+func TestZoomToFit(t *testing.T) {
+	for _, test := range []struct {
+		width, height, columns, rows, want uint
+	}{
+		{2000, 2000, 80, 24, 84},
+		{2000, 2000, 200, 0, 10},
+		{2000, 2001, 200, 200, 11},
+		{80, 24, 80, 24, 1},
+		{10, 10, 0, 0, 1},
+	} {
+		if n := ZoomToFit(test.width, test.height, test.columns, test.rows); n != test.want {
+			t.Errorf("%dx%d in %dx%d: got %d, wanted %d", test.width, test.height, test.columns, test.rows, n, test.want)
+		}
+	}
+}
+
+func TestCharactersPerCell(t *testing.T) {
+	for _, tt := range []struct {
+		r          Renderer
+		cols, rows uint
+	}{
+		{BlockRenderer{}, 1, 1},
+		{BlockRenderer{Scale: 2}, 2, 1},
+		{AgeRenderer{Scale: 4}, 4, 2},
+		{FrameRenderer{Renderer: BlockRenderer{Scale: 3}}, 3, 2},
+		{HalfBlockRenderer{}, 1, 1},
+		{&DiffRenderer{Scale: 2}, 2, 1},
+	} {
+		if cols, rows := CharactersPerCell(tt.r); cols != tt.cols || rows != tt.rows {
+			t.Errorf("%T: got %dx%d, wanted %dx%d", tt.r, cols, rows, tt.cols, tt.rows)
+		}
+	}
+}
+
+func TestCellsPerCharacter(t *testing.T) {
+	for _, tt := range []struct {
+		r          Renderer
+		cols, rows uint
+	}{
+		{BlockRenderer{}, 1, 1},
+		{AgeRenderer{}, 1, 1},
+		{HalfBlockRenderer{}, 1, 2},
+		{BrailleRenderer{}, 2, 4},
+		{FrameRenderer{Renderer: BrailleRenderer{}}, 2, 4},
+		{FrameRenderer{}, 1, 1},
+		{NewDiffRenderer(), 1, 1},
+		{ZoomRenderer{Block: 8}, 8, 8},
+		{&ZoomRenderer{Block: 3}, 3, 3},
+		{FrameRenderer{Renderer: ZoomRenderer{Block: 2}}, 2, 2},
+		{ZoomRenderer{Columns: 80, Rows: 24}, 1, 1},
+	} {
+		if cols, rows := CellsPerCharacter(tt.r); cols != tt.cols || rows != tt.rows {
+			t.Errorf("%T: got %dx%d, wanted %dx%d", tt.r, cols, rows, tt.cols, tt.rows)
+		}
+	}
+}
+
+func TestFrameRenderer(t *testing.T) {
+	rows := []string{
+		"............",
+		"..o.........",
+		"...o........",
+		".ooo........",
+		"............",
+		"..........oo",
+		"..........oo",
+	}
+	testCases := []struct {
+		name     string
+		renderer FrameRenderer
+		want     string
+	}{
+		{
+			name:     "border",
+			renderer: FrameRenderer{},
+			want: "┌────────────┐\n" +
+				"│            │\n" +
+				"│  █         │\n" +
+				"│   █        │\n" +
+				"│ ███        │\n" +
+				"│            │\n" +
+				"│          ██│\n" +
+				"│          ██│\n" +
+				"└────────────┘\n",
+		},
+		{
+			name:     "rulers",
+			renderer: FrameRenderer{Rulers: true},
+			want: "            10\n" +
+				"  012345678901\n" +
+				" ┌────────────┐\n" +
+				"0│            │\n" +
+				"1│  █         │\n" +
+				"2│   █        │\n" +
+				"3│ ███        │\n" +
+				"4│            │\n" +
+				"5│          ██│\n" +
+				"6│          ██│\n" +
+				" └────────────┘\n",
+		},
+		{
+			name:     "ascii halfblock",
+			renderer: FrameRenderer{Renderer: HalfBlockRenderer{}, ASCII: true},
+			want: "+------------+\n" +
+				"|  ▄         |\n" +
+				"| ▄▄█        |\n" +
+				"|          ▄▄|\n" +
+				"|          ▀▀|\n" +
+				"+------------+\n",
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			b := new(strings.Builder)
+			if err := test.renderer.Render(b, fieldFromRows(true, rows...)); err != nil {
+				t.Fatal(err)
+			}
+			if got := b.String(); got != test.want {
+				t.Errorf("got:\n%s\nwanted:\n%s", got, test.want)
+			}
+		})
+	}
+
+	if err := (FrameRenderer{Renderer: BrailleRenderer{}, Rulers: true}).Render(new(strings.Builder), fieldFromRows(true, rows...)); err == nil {
+		t.Error("expected an error for rulers around the braille renderer")
+	}
+}
+
+func TestFrameRendererTallRuler(t *testing.T) {
+	b := new(strings.Builder)
+	if err := (FrameRenderer{Rulers: true}).Render(b, NewField(1, 12, true)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(b.String(), "\n")
+	for i, want := range map[int]string{0: "   0", 2: " 0│ │", 11: " 9│ │", 12: "10│ │", 13: " 1│ │"} {
+		if lines[i] != want {
+			t.Errorf("line %d: got %q, wanted %q", i, lines[i], want)
+		}
+	}
+}
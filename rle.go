@@ -0,0 +1,142 @@
+package life
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// rleLineLength is the maximum length of the lines written by WriteRLE.
+const rleLineLength = 70
+
+// WriteRLE writes f in the run-length encoded format, see LoadGame, preceded by the given comments as #C lines.
+// Dead cells at the end of a line and empty lines at the end of the pattern are left out, and pattern lines are
+// wrapped so no line exceeds 70 characters. If f wraps, the rule ends in the bounded grid of a torus of the size of
+// f, e.g. "B3/S23:T50,20", see ReadGame.
+func (f *Field) WriteRLE(w io.Writer, comments ...string) error {
+	bw := bufio.NewWriter(w)
+	for _, comment := range comments {
+		bw.WriteString("#C ")
+		bw.WriteString(comment)
+		bw.WriteByte('\n')
+	}
+	bw.WriteString("x = ")
+	bw.WriteString(strconv.FormatUint(uint64(f.width), 10))
+	bw.WriteString(", y = ")
+	bw.WriteString(strconv.FormatUint(uint64(f.height), 10))
+	bw.WriteString(", rule = ")
+	bw.WriteString(rleRule(f))
+	bw.WriteByte('\n')
+
+	e := rleEncoder{w: bw, max: rleLineLength}
+	e.pattern(f)
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+// rleRule returns the rule of f as written in the header of an RLE file, with the bounded grid of a torus if f wraps.
+func rleRule(f *Field) string {
+	if !f.wrap {
+		return f.rule.String()
+	}
+	return fmt.Sprintf("%s:T%d,%d", f.rule, f.width, f.height)
+}
+
+// parseGrid parses the bounded grid of Golly at the end of a rule, e.g. "T50,20" for a torus of 50x20 cells or
+// "P30,30" for a plane of 30x30 cells, and returns its size and whether it wraps.
+func parseGrid(grid string) (width, height uint, wrap bool, err error) {
+	if grid == "" {
+		return 0, 0, false, fmt.Errorf("empty bounded grid after ':' in the rule")
+	}
+	switch grid[0] {
+	case 'T', 't':
+		wrap = true
+	case 'P', 'p':
+	case 'K', 'k':
+		return 0, 0, false, fmt.Errorf("the bounded grid :%s is a Klein bottle, which isn't supported", grid)
+	case 'C', 'c':
+		return 0, 0, false, fmt.Errorf("the bounded grid :%s is a cross-surface, which isn't supported", grid)
+	case 'S', 's':
+		return 0, 0, false, fmt.Errorf("the bounded grid :%s is a sphere, which isn't supported", grid)
+	default:
+		return 0, 0, false, fmt.Errorf("the bounded grid :%s is of the unknown kind %q", grid, grid[0])
+	}
+	size := strings.Split(grid[1:], ",")
+	if len(size) != 2 {
+		return 0, 0, false, fmt.Errorf("the bounded grid :%s doesn't give a width and a height", grid)
+	}
+	var dims [2]uint
+	for i, s := range size {
+		if strings.ContainsAny(s, "+-*") {
+			return 0, 0, false, fmt.Errorf("the bounded grid :%s is shifted or twisted, which isn't supported", grid)
+		}
+		d, err := strconv.ParseUint(s, 10, strconv.IntSize)
+		if err != nil {
+			return 0, 0, false, fmt.Errorf("the bounded grid :%s has the invalid size %q", grid, s)
+		}
+		if d == 0 {
+			return 0, 0, false, fmt.Errorf("the bounded grid :%s is infinite in one direction, which isn't supported", grid)
+		}
+		dims[i] = uint(d)
+	}
+	return dims[0], dims[1], wrap, nil
+}
+
+// rleEncoder writes run-length encoded items, starting a new line whenever an item doesn't fit on the current one.
+type rleEncoder struct {
+	w *bufio.Writer
+	// max is the maximum length of a line, or 0 to write all items on one line.
+	max int
+	n   int
+	buf []byte
+}
+
+// pattern writes the items of the cells of f, up to and including the final '!'.
+func (e *rleEncoder) pattern(f *Field) {
+	var lineEnds uint64
+	for _, row := range f.s {
+		// Drop the dead cells at the end of the row.
+		end := len(row)
+		for end > 0 && !row[end-1] {
+			end--
+		}
+		if end > 0 {
+			e.item(lineEnds, '$')
+			lineEnds = 0
+		}
+		for x := 0; x < end; {
+			run := x + 1
+			for run < end && row[run] == row[x] {
+				run++
+			}
+			tag := byte('b')
+			if row[x] {
+				tag = 'o'
+			}
+			e.item(uint64(run-x), tag)
+			x = run
+		}
+		lineEnds++
+	}
+	e.item(1, '!')
+}
+
+// item writes count times tag, leaving out a count of 1 and skipping a count of 0.
+func (e *rleEncoder) item(count uint64, tag byte) {
+	if count == 0 {
+		return
+	}
+	e.buf = e.buf[:0]
+	if count > 1 {
+		e.buf = strconv.AppendUint(e.buf, count, 10)
+	}
+	e.buf = append(e.buf, tag)
+	if e.max != 0 && e.n+len(e.buf) > e.max {
+		e.w.WriteByte('\n')
+		e.n = 0
+	}
+	e.w.Write(e.buf)
+	e.n += len(e.buf)
+}
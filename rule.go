@@ -0,0 +1,77 @@
+package life
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule is a Life-like rule: the numbers of live neighbours for which a dead cell is born and for which a live
+// cell survives. Bit n of Birth or Survival is set if n live neighbours lead to birth or survival respectively.
+type Rule struct {
+	Birth, Survival uint16
+}
+
+// Conway is the rule of Conway's Game of Life, B3/S23.
+var Conway = Rule{Birth: 1 << 3, Survival: 1<<2 | 1<<3}
+
+// Next returns the next state of a cell that is currently alive or dead and has the given number of live neighbours.
+func (r Rule) Next(alive bool, neighbours uint8) bool {
+	if alive {
+		return r.Survival&(1<<neighbours) != 0
+	}
+	return r.Birth&(1<<neighbours) != 0
+}
+
+// String returns the rule in B/S notation, e.g. "B3/S23".
+func (r Rule) String() string {
+	b := new(strings.Builder)
+	b.WriteByte('B')
+	writeCounts(b, r.Birth)
+	b.WriteString("/S")
+	writeCounts(b, r.Survival)
+	return b.String()
+}
+
+func writeCounts(b *strings.Builder, counts uint16) {
+	for n := byte(0); n <= 8; n++ {
+		if counts&(1<<n) != 0 {
+			b.WriteByte('0' + n)
+		}
+	}
+}
+
+// ParseRule parses a rule in B/S notation, e.g. "B3/S23" for Conway's Game of Life or "B36/S23" for HighLife.
+// Letters may be lower case and the parts may come in either order. The older S/B notation without letters,
+// e.g. "23/3", is accepted as well.
+func ParseRule(s string) (Rule, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid rule %q: expected two parts separated by '/'", s)
+	}
+	var r Rule
+	var birth, survival bool
+	for i, part := range parts {
+		counts := &r.Survival
+		switch {
+		case strings.HasPrefix(part, "B") || strings.HasPrefix(part, "b"):
+			counts, birth, part = &r.Birth, true, part[1:]
+		case strings.HasPrefix(part, "S") || strings.HasPrefix(part, "s"):
+			survival, part = true, part[1:]
+		case i == 1:
+			// S/B notation, the birth counts come second.
+			counts, birth = &r.Birth, true
+		default:
+			survival = true
+		}
+		for _, c := range part {
+			if c < '0' || c > '8' {
+				return Rule{}, fmt.Errorf("invalid rule %q: %q is not a neighbour count", s, c)
+			}
+			*counts |= 1 << (c - '0')
+		}
+	}
+	if !birth || !survival {
+		return Rule{}, fmt.Errorf("invalid rule %q: expected a birth and a survival part", s)
+	}
+	return r, nil
+}
@@ -0,0 +1,42 @@
+package life
+
+import "testing"
+
+func TestParseRule(t *testing.T) {
+	highLife := Rule{Birth: 1<<3 | 1<<6, Survival: 1<<2 | 1<<3}
+	for _, test := range []struct {
+		s    string
+		want Rule
+		err  bool
+	}{
+		{s: "B3/S23", want: Conway},
+		{s: "b3/s23", want: Conway},
+		{s: "S23/B3", want: Conway},
+		{s: "23/3", want: Conway},
+		{s: "B36/S23", want: highLife},
+		{s: "B/S", want: Rule{}},
+		{s: "B3", err: true},
+		{s: "B3/S23/", err: true},
+		{s: "B9/S23", err: true},
+		{s: "B3/B3", err: true},
+		{s: "B3/Sx", err: true},
+	} {
+		got, err := ParseRule(test.s)
+		if test.err {
+			if err == nil {
+				t.Errorf("%q: expected an error", test.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.s, err)
+		} else if got != test.want {
+			t.Errorf("%q: got %v, wanted %v", test.s, got, test.want)
+		}
+		if !test.err && test.s[0] == 'B' {
+			if got.String() != test.s {
+				t.Errorf("%q: round trip gives %q", test.s, got.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package life
+
+import "fmt"
+
+// HammingDistance returns the number of cells that are alive in one of f and other and dead in the other, which must
+// be of the same size.
+func (f *Field) HammingDistance(other *Field) (uint, error) {
+	if other.width != f.width || other.height != f.height {
+		return 0, fmt.Errorf("can't compare a %dx%d field with a %dx%d one", f.width, f.height, other.width, other.height)
+	}
+	var d uint
+	for y, row := range f.s {
+		for x, alive := range row {
+			if alive != other.s[y][x] {
+				d++
+			}
+		}
+	}
+	return d, nil
+}
+
+// Jaccard returns the Jaccard index of the live cells of f and other, which must be of the same size: the number of
+// cells alive in both divided by the number alive in either. It is 1 for fields with the same live cells, including
+// two empty ones, and 0 for fields without a live cell in common.
+func (f *Field) Jaccard(other *Field) (float64, error) {
+	if other.width != f.width || other.height != f.height {
+		return 0, fmt.Errorf("can't compare a %dx%d field with a %dx%d one", f.width, f.height, other.width, other.height)
+	}
+	var both uint
+	for y, row := range f.s {
+		for x, alive := range row {
+			if alive && other.s[y][x] {
+				both++
+			}
+		}
+	}
+	return jaccard(both, f.pop, other.pop), nil
+}
+
+// jaccard returns the Jaccard index of two sets of the sizes a and b with both elements in common.
+func jaccard(both, a, b uint) float64 {
+	if a+b == 0 {
+		return 1
+	}
+	return float64(both) / float64(a+b-both)
+}
+
+// AlignedJaccard is like Jaccard, but compares the patterns of f and other wherever they are, so that a pattern that
+// moved, like a spaceship, is recognized. The fields may be of different sizes. The bounding boxes of the live cells
+// are put on top of each other at their top-left corners, and then the live cells of other are shifted by up to
+// radius cells in each direction, which makes up for patterns that grew or shrank on one side. The best index is
+// returned along with the shift that gives it, the smallest one if several do. Wrapping is ignored, so a pattern
+// across the edges of a torus counts as spread out.
+func (f *Field) AlignedJaccard(other *Field, radius uint) (score float64, shift Point) {
+	minA, _, okA := f.BoundingBox()
+	minB, _, okB := other.BoundingBox()
+	if !okA || !okB {
+		return jaccard(0, f.pop, other.pop), Point{}
+	}
+	// The live cells of f, relative to its bounding box, are looked up in other.
+	var cells []Point
+	f.EachLive(func(x, y uint) {
+		cells = append(cells, Point{int64(x - minA.X), int64(y - minA.Y)})
+	})
+	r := int64(radius)
+	score = -1
+	for _, d := range shifts(r) {
+		var both uint
+		for _, c := range cells {
+			x, y := c.X-d.X+int64(minB.X), c.Y-d.Y+int64(minB.Y)
+			if x >= 0 && y >= 0 && x < int64(other.width) && y < int64(other.height) && other.s[y][x] {
+				both++
+			}
+		}
+		if s := jaccard(both, f.pop, other.pop); s > score {
+			score, shift = s, d
+		}
+	}
+	return score, shift
+}
+
+// shifts returns the shifts of up to r cells in each direction, ordered by their distance from 0,0 in moves of a
+// king, and then row by row.
+func shifts(r int64) []Point {
+	var s []Point
+	for ring := int64(0); ring <= r; ring++ {
+		for y := -ring; y <= ring; y++ {
+			for x := -ring; x <= ring; x++ {
+				if x == -ring || x == ring || y == -ring || y == ring {
+					s = append(s, Point{x, y})
+				}
+			}
+		}
+	}
+	return s
+}
@@ -0,0 +1,83 @@
+package life
+
+import "testing"
+
+func TestHammingDistanceAndJaccard(t *testing.T) {
+	a := fieldFromRows(true,
+		"oo.",
+		"...",
+	)
+	b := fieldFromRows(true,
+		".o.",
+		"..o",
+	)
+	if d, err := a.HammingDistance(b); err != nil || d != 2 {
+		t.Errorf("got the distance %d (%v), wanted 2", d, err)
+	}
+	// One cell alive in both, three in either.
+	if j, err := a.Jaccard(b); err != nil || j != 1.0/3 {
+		t.Errorf("got the index %v (%v), wanted 1/3", j, err)
+	}
+	if d, _ := a.HammingDistance(a); d != 0 {
+		t.Errorf("got the distance %d of a field to itself", d)
+	}
+	empty := NewField(3, 2, true)
+	if j, _ := empty.Jaccard(NewField(3, 2, false)); j != 1 {
+		t.Errorf("got the index %v for two empty fields, wanted 1", j)
+	}
+	if j, _ := a.Jaccard(empty); j != 0 {
+		t.Errorf("got the index %v for an empty and another field, wanted 0", j)
+	}
+	if _, err := a.HammingDistance(NewField(2, 3, true)); err == nil {
+		t.Error("HammingDistance: expected an error for fields of different sizes")
+	}
+	if _, err := a.Jaccard(NewField(3, 3, true)); err == nil {
+		t.Error("Jaccard: expected an error for fields of different sizes")
+	}
+}
+
+func TestAlignedJaccard(t *testing.T) {
+	// The same glider four generations later, one cell further down and right, on a field of another size.
+	a := fieldFromRows(false,
+		".o....",
+		"..o...",
+		"ooo...",
+		"......",
+	)
+	b := fieldFromRows(false,
+		".......",
+		"..o....",
+		"...o...",
+		".ooo...",
+		".......",
+	)
+	if j, shift := a.AlignedJaccard(b, 0); j != 1 || shift != (Point{}) {
+		t.Errorf("got the index %v with the shift %v, wanted 1 without one", j, shift)
+	}
+
+	a = fieldFromRows(false, "ooo...")
+	b = fieldFromRows(false, "o.ooo.")
+	// Without a shift, two cells are alive in both and five in either. Shifted 2 to the left, the 3 cells of a match.
+	for _, test := range []struct {
+		radius uint
+		j      float64
+		shift  Point
+	}{
+		{0, 0.4, Point{}},
+		{1, 0.4, Point{}},
+		{2, 0.75, Point{-2, 0}},
+		{5, 0.75, Point{-2, 0}},
+	} {
+		if j, shift := a.AlignedJaccard(b, test.radius); j != test.j || shift != test.shift {
+			t.Errorf("radius %d: got the index %v with the shift %v, wanted %v with %v",
+				test.radius, j, shift, test.j, test.shift)
+		}
+	}
+
+	if j, _ := a.AlignedJaccard(NewField(2, 2, false), 3); j != 0 {
+		t.Errorf("got the index %v for an empty field, wanted 0", j)
+	}
+	if j, _ := NewField(2, 2, false).AlignedJaccard(NewField(4, 1, true), 3); j != 1 {
+		t.Errorf("got the index %v for two empty fields, wanted 1", j)
+	}
+}
@@ -0,0 +1,115 @@
+package life
+
+import (
+	"image/color"
+	"io"
+	"strconv"
+)
+
+// DefaultSixelCellSize is the width and height in pixels of the cells drawn by a SixelRenderer without a CellSize.
+const DefaultSixelCellSize = 4
+
+// SixelRenderer draws fields as sixel graphics, images made of escape sequences that terminals like xterm (started
+// with -ti vt340), mlterm, foot and Windows Terminal draw as pixels, at the cursor. Unlike the renderers that draw
+// characters, it shows fields of thousands of cells across. Terminals that don't understand sixels ignore them or
+// show them as garbage, so it is only to be used when the terminal is known to support them.
+// A SixelRenderer reuses its buffers from frame to frame, so it must be used through a pointer and not from
+// several goroutines at once.
+type SixelRenderer struct {
+	// CellSize is the width and height of a cell in pixels. If zero, DefaultSixelCellSize is used.
+	CellSize int
+	// Alive and Dead are the colours of live and dead cells. If nil, white and black are used.
+	Alive, Dead color.Color
+
+	buf  []byte
+	band []byte
+}
+
+// Render writes f to w as a single sixel image, through Field.Image.
+func (s *SixelRenderer) Render(w io.Writer, f *Field) error {
+	size := s.CellSize
+	if size == 0 {
+		size = DefaultSixelCellSize
+	}
+	img := f.Image(size, s.Alive, s.Dead)
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	// Pixels are square, and the bits left 0 in a sixel are transparent, as both colours are drawn.
+	b := append(s.buf[:0], "\x1bP0;1;0q\"1;1;"...)
+	b = strconv.AppendInt(b, int64(width), 10)
+	b = append(b, ';')
+	b = strconv.AppendInt(b, int64(height), 10)
+	for i, c := range img.palette {
+		b = appendSixelColour(b, i, c)
+	}
+	if cap(s.band) < width {
+		s.band = make([]byte, width)
+	}
+	band := s.band[:width]
+	// Every sixel is a column of 6 pixels, and a band is a row of them. A band holds the bits of the live pixels,
+	// which are drawn as they are in the alive colour and inverted in the dead one.
+	for top := 0; top < height; top += 6 {
+		rows := height - top
+		if rows > 6 {
+			rows = 6
+		}
+		for x := range band {
+			var bits byte
+			for y := 0; y < rows; y++ {
+				bits |= img.ColorIndexAt(x, top+y) << y
+			}
+			band[x] = bits
+		}
+		if top > 0 {
+			b = append(b, '-')
+		}
+		b = append(b, "#0"...)
+		b = appendSixels(b, band, 1<<rows-1)
+		// '$' returns to the start of the band to draw it again in the other colour.
+		b = append(b, "$#1"...)
+		b = appendSixels(b, band, 0)
+	}
+	b = append(b, "\x1b\\"...)
+	s.buf = b
+	_, err := w.Write(b)
+	return err
+}
+
+// appendSixelColour appends the definition of colour register i as c, in RGB percentages.
+func appendSixelColour(b []byte, i int, c color.Color) []byte {
+	b = append(b, '#')
+	b = strconv.AppendInt(b, int64(i), 10)
+	b = append(b, ";2"...)
+	r, g, bl, _ := c.RGBA()
+	for _, v := range [3]uint32{r, g, bl} {
+		b = append(b, ';')
+		b = strconv.AppendInt(b, int64((v*100+0x7fff)/0xffff), 10)
+	}
+	return b
+}
+
+// appendSixels appends the sixels of band with its bits flipped by mask, repeats of more than three sixels
+// run-length encoded. Empty sixels at the end, which draw nothing, are left out.
+func appendSixels(b, band []byte, mask byte) []byte {
+	end := len(band)
+	for end > 0 && band[end-1]^mask == 0 {
+		end--
+	}
+	for x := 0; x < end; {
+		bits := band[x] ^ mask
+		n := 1
+		for x+n < end && band[x+n]^mask == bits {
+			n++
+		}
+		if n > 3 {
+			b = append(b, '!')
+			b = strconv.AppendInt(b, int64(n), 10)
+			b = append(b, '?'+bits)
+		} else {
+			for i := 0; i < n; i++ {
+				b = append(b, '?'+bits)
+			}
+		}
+		x += n
+	}
+	return b
+}
@@ -0,0 +1,66 @@
+package life
+
+import (
+	"bytes"
+	"image/color"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSixelRenderer(t *testing.T) {
+	f := fieldFromRows(false,
+		"o..",
+		".o.",
+	)
+	var b bytes.Buffer
+	s := &SixelRenderer{CellSize: 1}
+	if err := s.Render(&b, f); err != nil {
+		t.Fatal(err)
+	}
+	// Dead pixels are bits 0 of the live ones inverted, the live ones the bits 1. The empty sixel at the end of the
+	// live pass is left out.
+	want := "\x1bP0;1;0q\"1;1;3;2#0;2;0;0;0#1;2;100;100;100#0A@B$#1@A\x1b\\"
+	if got := b.String(); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestSixelRendererBands(t *testing.T) {
+	// 2 cells of 4 pixels are 8 pixels high: a full band and one of 2 rows.
+	f := fieldFromRows(false,
+		"oooooo",
+		"......",
+	)
+	var b bytes.Buffer
+	s := &SixelRenderer{Alive: color.RGBA{0xff, 0, 0, 0xff}}
+	if err := s.Render(&b, f); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"\"1;1;24;8",
+		"#1;2;100;0;0",
+		// The first band holds 4 live rows and 2 dead ones, the second only 2 dead ones.
+		"#0!24o$#1!24N-#0!24B$#1\x1b\\",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("%q doesn't contain %q", got, want)
+		}
+	}
+}
+
+func TestSixelRendererReusesBuffers(t *testing.T) {
+	f := NewRandomGame(200, 100, true, DefaultDensity, rand.New(rand.NewSource(1))).Field()
+	s := &SixelRenderer{}
+	var b bytes.Buffer
+	s.Render(&b, f)
+	allocs := testing.AllocsPerRun(10, func() {
+		b.Reset()
+		s.Render(&b, f)
+	})
+	// Only the image adapter is allocated.
+	if allocs > 2 {
+		t.Errorf("got %v allocations per frame, wanted at most 2", allocs)
+	}
+}
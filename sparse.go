@@ -0,0 +1,215 @@
+package life
+
+import "io"
+
+// Point is the position of a cell on an unbounded plane.
+type Point struct {
+	X, Y int64
+}
+
+// SparseField is an unbounded plane that only stores the positions of its live cells.
+// Memory use and the cost of a step depend on the number of live cells, not on the size of the area they are
+// spread over, which makes it suitable for a few small patterns on a huge plane.
+// Rules that give birth to cells without any live neighbours (B0) are not supported.
+type SparseField struct {
+	cells  map[Point]struct{}
+	rule   Rule
+	counts map[Point]uint8
+}
+
+// NewSparseField returns an empty plane.
+func NewSparseField() *SparseField {
+	return &SparseField{
+		cells:  make(map[Point]struct{}),
+		rule:   Conway,
+		counts: make(map[Point]uint8),
+	}
+}
+
+// Sparse returns a sparse copy of f with the top-left cell of f at the origin.
+// The copy does not wrap, whatever the wrapping of f is.
+func Sparse(f *Field) *SparseField {
+	s := NewSparseField()
+	s.rule = f.rule
+	s.Place(f, 0, 0)
+	return s
+}
+
+// Set sets the value v to the cell with position x,y on the plane.
+func (s *SparseField) Set(x, y int64, v bool) {
+	if v {
+		s.cells[Point{x, y}] = struct{}{}
+	} else {
+		delete(s.cells, Point{x, y})
+	}
+}
+
+// Alive reports whether the cell at position x,y is alive or dead.
+func (s *SparseField) Alive(x, y int64) bool {
+	_, ok := s.cells[Point{x, y}]
+	return ok
+}
+
+// Population returns the number of live cells on the plane.
+func (s *SparseField) Population() uint {
+	return uint(len(s.cells))
+}
+
+// BoundingBox returns the top-left and bottom-right corners of the smallest rectangle containing all live cells.
+// ok is false if there are no live cells.
+func (s *SparseField) BoundingBox() (min, max Point, ok bool) {
+	for p := range s.cells {
+		if !ok {
+			min, max, ok = p, p, true
+			continue
+		}
+		if p.X < min.X {
+			min.X = p.X
+		}
+		if p.X > max.X {
+			max.X = p.X
+		}
+		if p.Y < min.Y {
+			min.Y = p.Y
+		}
+		if p.Y > max.Y {
+			max.Y = p.Y
+		}
+	}
+	return min, max, ok
+}
+
+// EachLive calls fn for every live cell, in no particular order.
+func (s *SparseField) EachLive(fn func(x, y int64)) {
+	for p := range s.cells {
+		fn(p.X, p.Y)
+	}
+}
+
+// Place copies the live cells of pattern onto the plane with the top-left corner of pattern at position x,y.
+// Cells that are dead in pattern are left untouched.
+func (s *SparseField) Place(pattern *Field, x, y int64) {
+	pattern.EachLive(func(px, py uint) {
+		s.cells[Point{x + int64(px), y + int64(py)}] = struct{}{}
+	})
+}
+
+// Step advances the plane by one generation.
+func (s *SparseField) Step() {
+	// Only cells next to a live cell can be alive in the next generation.
+	for p := range s.cells {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for dx := int64(-1); dx <= 1; dx++ {
+				if dx != 0 || dy != 0 {
+					s.counts[Point{p.X + dx, p.Y + dy}]++
+				}
+			}
+		}
+	}
+	next := make(map[Point]struct{}, len(s.cells))
+	for p, n := range s.counts {
+		_, alive := s.cells[p]
+		if s.rule.Next(alive, n) {
+			next[p] = struct{}{}
+		}
+	}
+	// Live cells without any live neighbours weren't counted.
+	if s.rule.Survival&1 != 0 {
+		for p := range s.cells {
+			if _, ok := s.counts[p]; !ok {
+				next[p] = struct{}{}
+			}
+		}
+	}
+	for p := range s.counts {
+		delete(s.counts, p)
+	}
+	s.cells = next
+}
+
+// Viewport returns a dense copy of the rectangle of the given size with its top-left corner at position x,y.
+func (s *SparseField) Viewport(x, y int64, width, height uint) *Field {
+	f := NewField(width, height, false)
+	f.rule = s.rule
+	if uint64(len(s.cells)) < uint64(width)*uint64(height) {
+		for p := range s.cells {
+			if p.X >= x && p.Y >= y && uint64(p.X-x) < uint64(width) && uint64(p.Y-y) < uint64(height) {
+				f.Set(uint(p.X-x), uint(p.Y-y), true)
+			}
+		}
+		return f
+	}
+	for fy := uint(0); fy < height; fy++ {
+		for fx := uint(0); fx < width; fx++ {
+			if s.Alive(x+int64(fx), y+int64(fy)) {
+				f.Set(fx, fy, true)
+			}
+		}
+	}
+	return f
+}
+
+// Crop returns a dense copy of the smallest rectangle containing all live cells, and the position of its top-left corner.
+// An empty plane results in a field of a single dead cell, as fields are at least 1x1.
+func (s *SparseField) Crop() (f *Field, origin Point) {
+	min, max, ok := s.BoundingBox()
+	if !ok {
+		return s.Viewport(0, 0, 1, 1), Point{}
+	}
+	return s.Viewport(min.X, min.Y, uint(max.X-min.X+1), uint(max.Y-min.Y+1)), min
+}
+
+// WriteRLE writes the live cells of the plane, cropped to their bounding box, in the run-length encoded format,
+// see Field.WriteRLE.
+func (s *SparseField) WriteRLE(w io.Writer, comments ...string) error {
+	f, _ := s.Crop()
+	return f.WriteRLE(w, comments...)
+}
+
+// SparseEngine computes generations on a sparse copy of the field, see SparseField.
+// Cells leaving the field are removed after every step, which matches a field that doesn't wrap. Wrapping fields,
+// and fields with a B0 rule that SparseField doesn't support, are computed by NaiveEngine instead.
+type SparseEngine struct {
+	s *SparseField
+}
+
+// Step writes the generation following current into next.
+func (e *SparseEngine) Step(current, next *Field) {
+	if current.wrap || current.rule.Birth&1 != 0 {
+		NaiveEngine{}.Step(current, next)
+		return
+	}
+	if e.s == nil {
+		e.s = NewSparseField()
+	}
+	e.s.rule = current.rule
+	for p := range e.s.cells {
+		delete(e.s.cells, p)
+	}
+	e.s.Place(current, 0, 0)
+	// Step replaces the set of cells, so before keeps those of current to find the cells that died.
+	before := e.s.cells
+	e.s.Step()
+	for _, row := range next.s {
+		for x := range row {
+			row[x] = false
+		}
+	}
+	next.pop = 0
+	var c changes
+	for p := range e.s.cells {
+		if p.X >= 0 && p.Y >= 0 && p.X < int64(next.width) && p.Y < int64(next.height) {
+			next.s[p.Y][p.X] = true
+			next.pop++
+			if !current.s[p.Y][p.X] {
+				c.flip(uint(p.X), uint(p.Y), true)
+			}
+		}
+	}
+	for p := range before {
+		if !next.s[p.Y][p.X] {
+			c.flip(uint(p.X), uint(p.Y), false)
+		}
+	}
+	c.apply(current, next)
+}
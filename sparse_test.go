@@ -0,0 +1,67 @@
+package life
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSparseField(t *testing.T) {
+	rPentomino := fieldFromRows(false,
+		".oo",
+		"oo.",
+		".o.",
+	)
+	dense := &Game{current: NewField(160, 160, false), next: NewField(160, 160, false), width: 160, height: 160}
+	if err := dense.current.Place(rPentomino, 80, 80); err != nil {
+		t.Fatal(err)
+	}
+	sparse := NewSparseField()
+	sparse.Place(rPentomino, 1_000_000+80, -1_000_000+80)
+	for gen := 1; gen <= 100; gen++ {
+		dense.Tick()
+		sparse.Step()
+		if got := sparse.Viewport(1_000_000, -1_000_000, 160, 160); !equalCells(got, dense.Field()) {
+			t.Fatalf("generation %d: got:\n%s\nwanted:\n%s", gen, got, dense.Field())
+		}
+		if sparse.Population() != dense.Population() {
+			t.Fatalf("generation %d: got population %d, wanted %d", gen, sparse.Population(), dense.Population())
+		}
+	}
+
+	// The dense field never reached its edges, so its bounding box must match.
+	min, max, _ := sparse.BoundingBox()
+	dmin, dmax, _ := dense.Field().BoundingBox()
+	if min.X-1_000_000 != int64(dmin.X) || min.Y+1_000_000 != int64(dmin.Y) || max.X-1_000_000 != int64(dmax.X) || max.Y+1_000_000 != int64(dmax.Y) {
+		t.Errorf("bounding box: got %v %v, wanted %v %v", min, max, dmin, dmax)
+	}
+}
+
+func TestSparseEngine(t *testing.T) {
+	assertSameEngine(t, func() Engine { return new(SparseEngine) })
+}
+
+func TestSparseFieldRLE(t *testing.T) {
+	s := NewSparseField()
+	s.Place(fieldFromRows(false,
+		".o.",
+		"..o",
+		"ooo",
+	), -500_000, 500_000)
+	for i := 0; i < 4; i++ {
+		s.Step()
+	}
+	f, origin := s.Crop()
+	if origin != (Point{-500_000 + 1, 500_000 + 1}) {
+		t.Errorf("glider origin after 4 generations: got %v", origin)
+	}
+	if f.Population() != 5 {
+		t.Errorf("glider population after 4 generations: got %d", f.Population())
+	}
+	b := new(strings.Builder)
+	if err := s.WriteRLE(b, "A glider."); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := b.String(), "#C A glider.\nx = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"; got != want {
+		t.Errorf("got:\n%s\nwanted:\n%s", got, want)
+	}
+}
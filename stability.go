@@ -0,0 +1,68 @@
+package life
+
+// Stability is what a run has settled into.
+type Stability int
+
+const (
+	// Unsettled means no generation has repeated yet.
+	Unsettled Stability = iota
+	// Extinct means every cell has died.
+	Extinct
+	// StillLife means a generation is the same as the one before it.
+	StillLife
+	// Cycle means a generation repeats after a period of more than one generation.
+	Cycle
+)
+
+func (s Stability) String() string {
+	switch s {
+	case Extinct:
+		return "extinct"
+	case StillLife:
+		return "still life"
+	case Cycle:
+		return "cycle"
+	default:
+		return "unsettled"
+	}
+}
+
+// StabilityDetector finds the generation at which a game starts to repeat itself.
+// It remembers the hash and population of every generation it observes, so a repeat of any period is found the
+// first time it happens. Two different generations with the same hash and population would be mistaken for a
+// repeat, which is unlikely enough with 64-bit hashes to be ignored.
+type StabilityDetector struct {
+	seen map[seenKey]uint
+}
+
+type seenKey struct {
+	hash       uint64
+	population uint
+}
+
+// NewStabilityDetector returns a StabilityDetector that hasn't observed any generations.
+func NewStabilityDetector() *StabilityDetector {
+	return &StabilityDetector{seen: make(map[seenKey]uint)}
+}
+
+// Observe records the current generation of g, which should be observed after every tick starting at generation
+// zero. Once g has settled it returns what it settled into, the generation from which on it repeats and the
+// period of the repetition, which is 1 for extinct and still life patterns.
+func (d *StabilityDetector) Observe(g *Game) (s Stability, since, period uint) {
+	gen := g.Generation()
+	k := seenKey{g.Hash(), g.Population()}
+	first, ok := d.seen[k]
+	if !ok {
+		d.seen[k], first = gen, gen
+	}
+	switch {
+	case k.population == 0:
+		return Extinct, first, 1
+	case !ok:
+		return Unsettled, 0, 0
+	case gen-first == 1:
+		return StillLife, first, 1
+	default:
+		return Cycle, first, gen - first
+	}
+}
@@ -0,0 +1,61 @@
+package life
+
+import "testing"
+
+func TestStabilityDetector(t *testing.T) {
+	tests := []struct {
+		name          string
+		g             *Game
+		want          Stability
+		since, period uint
+	}{
+		{"block", gameFromRows(true,
+			"....",
+			".oo.",
+			".oo.",
+			"....",
+		), StillLife, 0, 1},
+		{"blinker", gameFromRows(true,
+			".....",
+			"..o..",
+			"..o..",
+			"..o..",
+			".....",
+		), Cycle, 0, 2},
+		{"dying domino", gameFromRows(true,
+			"....",
+			".oo.",
+			"....",
+		), Extinct, 1, 1},
+		{"pre-block", gameFromRows(false,
+			".....",
+			".oo..",
+			".o...",
+			".....",
+		), StillLife, 1, 1},
+		// A glider on a wrapping field moves one cell diagonally every four generations.
+		{"glider", gameFromRows(true,
+			".o......",
+			"..o.....",
+			"ooo.....",
+			"........",
+			"........",
+			"........",
+			"........",
+			"........",
+		), Cycle, 0, 32},
+	}
+	for _, tt := range tests {
+		d := NewStabilityDetector()
+		var s Stability
+		var since, period uint
+		for s == Unsettled && tt.g.Generation() < 100 {
+			if s, since, period = d.Observe(tt.g); s == Unsettled {
+				tt.g.Tick()
+			}
+		}
+		if s != tt.want || since != tt.since || period != tt.period {
+			t.Errorf("%s: got %v since %d with period %d, wanted %v since %d with period %d", tt.name, s, since, period, tt.want, tt.since, tt.period)
+		}
+	}
+}
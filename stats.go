@@ -0,0 +1,31 @@
+package life
+
+// Stats are statistics of a generation of a game, e.g. to plot how the population develops.
+type Stats struct {
+	Generation uint
+	Population uint
+	// Births and Deaths are the numbers of cells that came to life and died in the tick that computed the
+	// generation. Both are 0 for the initial generation, and cells changed with Field.Set aren't counted.
+	Births, Deaths uint
+	// Density is the share of the cells of the field that are alive, between 0 and 1.
+	Density float64
+	// BoundingBoxArea is the number of cells of the smallest rectangle holding all live cells, see
+	// Field.BoundingBox, or 0 if there are none.
+	BoundingBoxArea uint
+}
+
+// Stats returns the statistics of the current generation. The births and deaths are counted by the engine as it goes,
+// only the bounding box takes a pass over the field.
+func (g *Game) Stats() Stats {
+	s := Stats{
+		Generation: g.generation,
+		Population: g.current.pop,
+		Births:     g.current.births,
+		Deaths:     g.current.deaths,
+		Density:    float64(g.current.pop) / float64(g.width*g.height),
+	}
+	if min, max, ok := g.current.BoundingBox(); ok {
+		s.BoundingBoxArea = (max.X - min.X + 1) * (max.Y - min.Y + 1)
+	}
+	return s
+}
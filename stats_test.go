@@ -0,0 +1,71 @@
+package life
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	g := gameFromRows(true,
+		".....",
+		"..o..",
+		"..o..",
+		"..o..",
+		".....",
+	)
+	want := Stats{Population: 3, Density: 3.0 / 25, BoundingBoxArea: 3}
+	if got := g.Stats(); got != want {
+		t.Errorf("initial generation: got %+v, wanted %+v", got, want)
+	}
+	for gen := uint(1); gen <= 3; gen++ {
+		g.Tick()
+		want := Stats{Generation: gen, Population: 3, Births: 2, Deaths: 2, Density: 3.0 / 25, BoundingBoxArea: 3}
+		if got := g.Stats(); got != want {
+			t.Errorf("generation %d: got %+v, wanted %+v", gen, got, want)
+		}
+	}
+
+	g = gameFromRows(false,
+		"oo.",
+		"...",
+		"...",
+	)
+	g.Tick()
+	if got, want := g.Stats(), (Stats{Generation: 1, Deaths: 2}); got != want {
+		t.Errorf("extinct: got %+v, wanted %+v", got, want)
+	}
+}
+
+func TestStatsBirthsAndDeaths(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, name := range EngineNames() {
+		for _, wrap := range []bool{true, false} {
+			e, err := NewEngine(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := (&Game{current: randomField(rng, 37, 23, wrap), next: NewField(37, 23, wrap), width: 37, height: 23}).WithEngine(e)
+			for i := 0; i < 50; i++ {
+				before := g.Field().Clone()
+				g.Tick()
+				s := g.Stats()
+				var births, deaths uint
+				for y, row := range g.Field().s {
+					for x, alive := range row {
+						if alive != before.s[y][x] {
+							if alive {
+								births++
+							} else {
+								deaths++
+							}
+						}
+					}
+				}
+				if s.Births != births || s.Deaths != deaths {
+					t.Fatalf("%s wrap %t: generation %d: got %d births and %d deaths, wanted %d and %d",
+						name, wrap, s.Generation, s.Births, s.Deaths, births, deaths)
+				}
+			}
+		}
+	}
+}
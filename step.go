@@ -0,0 +1,74 @@
+package life
+
+import "fmt"
+
+// Next returns the generation following cells, a grid of rows of cells that are true if alive, for code that has
+// grids of its own and only needs the rule. Cells are computed as by Field.Future, on a field that wraps if wrap is
+// set. The option WithRule sets the rule, which is Conway by default, and WithWrap overrides wrap; the others are an
+// error. An error is also returned if the grid is empty or its rows aren't all of the same length.
+func Next(cells [][]bool, wrap bool, opts ...Option) ([][]bool, error) {
+	if err := checkGrid(cells, "cells"); err != nil {
+		return nil, err
+	}
+	next := make([][]bool, len(cells))
+	for y := range next {
+		next[y] = make([]bool, len(cells[0]))
+	}
+	if err := NextInto(next, cells, wrap, opts...); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// NextInto is like Next, but writes the generation following src into dst, which must be of the same size as src and
+// must not share any cells with it, instead of allocating a new grid.
+func NextInto(dst, src [][]bool, wrap bool, opts ...Option) error {
+	o := defaultOptions()
+	o.wrap = wrap
+	if err := o.apply(opts); err != nil {
+		return err
+	}
+	switch {
+	case o.random != "":
+		return fmt.Errorf("%s doesn't apply to Next", o.random)
+	case o.loaded != "":
+		return fmt.Errorf("%s doesn't apply to Next", o.loaded)
+	case o.engine != nil:
+		return fmt.Errorf("WithEngine doesn't apply to Next")
+	}
+	if err := checkGrid(src, "src"); err != nil {
+		return err
+	}
+	if err := checkGrid(dst, "dst"); err != nil {
+		return err
+	}
+	width, height := uint(len(src[0])), uint(len(src))
+	if uint(len(dst[0])) != width || uint(len(dst)) != height {
+		return fmt.Errorf("dst is %dx%d, but src is %dx%d", len(dst[0]), len(dst), width, height)
+	}
+	// The field only borrows the rows of src, which Future doesn't change.
+	f := &Field{s: src, width: width, height: height, wrap: o.wrap, rule: o.rule}
+	for y, row := range dst {
+		for x := range row {
+			row[x] = f.Future(uint(x), uint(y))
+		}
+	}
+	return nil
+}
+
+// checkGrid returns an error unless the rows of the grid of the given name are all of the same length and make up a
+// valid size, see CheckSize.
+func checkGrid(cells [][]bool, name string) error {
+	if len(cells) == 0 {
+		return fmt.Errorf("%s has no rows", name)
+	}
+	for y, row := range cells {
+		if len(row) != len(cells[0]) {
+			return fmt.Errorf("row %d of %s has %d cells, but row 0 has %d", y, name, len(row), len(cells[0]))
+		}
+	}
+	if err := CheckSize(uint(len(cells[0])), uint(len(cells))); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
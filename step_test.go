@@ -0,0 +1,74 @@
+package life
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestNext(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	highLife, _ := ParseRule("B36/S23")
+	for _, wrap := range []bool{false, true} {
+		for _, rule := range []Rule{Conway, highLife} {
+			for _, size := range [][2]uint{{1, 1}, {2, 3}, {17, 9}, {40, 40}} {
+				f := randomField(rng, size[0], size[1], wrap)
+				f.rule = rule
+				cells := make([][]bool, len(f.s))
+				for y, row := range f.s {
+					cells[y] = append([]bool(nil), row...)
+				}
+				g := NewGameFromField(f)
+				g.Tick()
+				next, err := Next(cells, wrap, WithRule(rule))
+				if err != nil {
+					t.Fatal(err)
+				}
+				for y, row := range next {
+					for x, alive := range row {
+						if alive != g.Field().s[y][x] {
+							t.Fatalf("%dx%d, wrap %t, rule %s: cell %d,%d is %t, wanted %t",
+								size[0], size[1], wrap, rule, x, y, alive, !alive)
+						}
+					}
+				}
+				if err := NextInto(cells, next, wrap, WithRule(rule)); err != nil {
+					t.Fatal(err)
+				}
+				g.Tick()
+				for y, row := range cells {
+					for x, alive := range row {
+						if alive != g.Field().s[y][x] {
+							t.Fatalf("NextInto: %dx%d, wrap %t, rule %s: cell %d,%d is %t, wanted %t",
+								size[0], size[1], wrap, rule, x, y, alive, !alive)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNextErrors(t *testing.T) {
+	square := [][]bool{{false, true}, {true, false}}
+	for _, test := range []struct {
+		dst, src [][]bool
+		opts     []Option
+		err      string
+	}{
+		{square, nil, nil, "src has no rows"},
+		{square, [][]bool{{}}, nil, "src: invalid size 0x1"},
+		{square, [][]bool{{true, true}, {true}}, nil, "row 1 of src has 1 cells, but row 0 has 2"},
+		{[][]bool{{true}}, square, nil, "dst is 1x1, but src is 2x2"},
+		{nil, square, nil, "dst has no rows"},
+		{square, square, []Option{WithDensity(0.5)}, "WithDensity doesn't apply"},
+		{square, square, []Option{WithEngine(NaiveEngine{})}, "WithEngine doesn't apply"},
+	} {
+		if err := NextInto(test.dst, test.src, true, test.opts...); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("got the error %v, wanted one containing %q", err, test.err)
+		}
+	}
+	if _, err := Next([][]bool{{true}, {true, false}}, false); err == nil {
+		t.Error("Next: expected an error for rows of different lengths")
+	}
+}
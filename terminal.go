@@ -0,0 +1,180 @@
+package life
+
+import (
+	"io"
+	"strconv"
+)
+
+// Escape sequences for drawing frames on ANSI terminals. Only the first frame needs to clear the screen, later
+// ones are drawn over the previous frame, so neither the screen flashes nor terminals such as tmux fill the
+// scrollback with a copy of every frame.
+const (
+	// ClearScreen moves the cursor to the top-left corner and erases the screen. Unlike the full reset "\x1bc" it
+	// leaves the scrollback and the modes of the terminal alone.
+	ClearScreen = "\x1b[H\x1b[2J"
+	// CursorHome moves the cursor to the top-left corner.
+	CursorHome = "\x1b[H"
+	// ClearLine erases the rest of the line the cursor is on.
+	ClearLine = "\x1b[K"
+	// ClearBelow erases everything from the cursor to the end of the screen.
+	ClearBelow = "\x1b[J"
+)
+
+// DiffRenderer draws fields to an ANSI terminal, one character per cell like BlockRenderer.
+// After the first full frame it only moves the cursor to the cells that changed since the previous frame
+// and redraws those, which avoids flicker and saves a lot of bandwidth for large, mostly settled, fields.
+// A DiffRenderer keeps track of what is on screen and must only be used for a single terminal.
+type DiffRenderer struct {
+	// Top and Left are the zero-based row and column of the terminal where the top-left cell is drawn.
+	Top, Left uint
+	// Threshold is the fraction of changed cells above which the whole frame is redrawn instead.
+	// If zero, 0.25 is used.
+	Threshold float64
+	// Scale draws every cell as a block of characters, like the Scale of BlockRenderer.
+	Scale uint
+
+	prev   [][]bool
+	full   bool
+	buf    []byte
+	hidden bool
+	// row and col are the cursor position, if known.
+	row, col uint
+	known    bool
+}
+
+// NewDiffRenderer returns a DiffRenderer that draws the field in the top-left corner of the terminal.
+func NewDiffRenderer() *DiffRenderer {
+	return &DiffRenderer{full: true}
+}
+
+// Invalidate forces the next frame to be redrawn completely, e.g. after the terminal was resized or
+// something else was written to it.
+func (d *DiffRenderer) Invalidate() {
+	d.full = true
+}
+
+// Render updates the terminal connected to w so it shows f.
+func (d *DiffRenderer) Render(w io.Writer, f *Field) error {
+	d.buf = d.buf[:0]
+	d.known = false
+	if !d.hidden {
+		d.buf = append(d.buf, "\x1b[?25l"...)
+		d.hidden = true
+	}
+	if uint(len(d.prev)) != f.height || (f.height > 0 && uint(len(d.prev[0])) != f.width) {
+		// A frame of a different size doesn't cover the previous one.
+		d.full = true
+	}
+	if d.full || d.tooManyChanges(f) {
+		d.redraw(f)
+	} else {
+		d.update(f)
+	}
+	// Park the cursor below the field so anything else written to the terminal doesn't end up inside it.
+	_, rows := scaleSize(d.Scale)
+	d.buf = d.moveTo(d.buf, d.Top+f.height*rows, 0)
+	_, err := w.Write(d.buf)
+	return err
+}
+
+// Close shows the cursor again and leaves it on the line below the field.
+func (d *DiffRenderer) Close(w io.Writer) error {
+	if !d.hidden {
+		return nil
+	}
+	d.hidden = false
+	_, err := io.WriteString(w, "\x1b[?25h")
+	return err
+}
+
+func (d *DiffRenderer) tooManyChanges(f *Field) bool {
+	threshold := d.Threshold
+	if threshold == 0 {
+		threshold = 0.25
+	}
+	limit := uint(threshold * float64(f.width*f.height))
+	var changed uint
+	for y, row := range f.s {
+		for x, alive := range row {
+			if alive != d.prev[y][x] {
+				if changed++; changed > limit {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// redraw draws every cell of f, over the previous frame unless the screen has to be cleared first.
+func (d *DiffRenderer) redraw(f *Field) {
+	if d.full {
+		d.buf = append(d.buf, ClearScreen...)
+	}
+	if uint(len(d.prev)) != f.height || (f.height > 0 && uint(len(d.prev[0])) != f.width) {
+		d.prev = make([][]bool, f.height)
+		for i := range d.prev {
+			d.prev[i] = make([]bool, f.width)
+		}
+	}
+	cols, rows := scaleSize(d.Scale)
+	for y, row := range f.s {
+		for x, alive := range row {
+			d.prev[y][x] = alive
+		}
+		for i := uint(0); i < rows; i++ {
+			d.buf = d.moveTo(d.buf, d.Top+uint(y)*rows+i, d.Left)
+			for _, alive := range row {
+				d.buf = appendCell(d.buf, alive, cols)
+			}
+			d.col += f.width * cols
+		}
+	}
+	d.full = false
+}
+
+func (d *DiffRenderer) update(f *Field) {
+	cols, rows := scaleSize(d.Scale)
+	for y, row := range f.s {
+		for x, alive := range row {
+			if alive == d.prev[y][x] {
+				continue
+			}
+			for i := uint(0); i < rows; i++ {
+				d.buf = d.moveTo(d.buf, d.Top+uint(y)*rows+i, d.Left+uint(x)*cols)
+				d.buf = appendCell(d.buf, alive, cols)
+				d.col += cols
+			}
+			d.prev[y][x] = alive
+		}
+	}
+}
+
+// moveTo appends the sequence that moves the cursor to the zero-based row and column,
+// unless the cursor is already there.
+func (d *DiffRenderer) moveTo(buf []byte, row, col uint) []byte {
+	if d.known && d.row == row && d.col == col {
+		return buf
+	}
+	d.row, d.col, d.known = row, col, true
+	buf = append(buf, "\x1b["...)
+	buf = strconv.AppendUint(buf, uint64(row+1), 10)
+	buf = append(buf, ';')
+	buf = strconv.AppendUint(buf, uint64(col+1), 10)
+	return append(buf, 'H')
+}
+
+// appendCell appends the glyph of a cell n times.
+func appendCell(buf []byte, alive bool, n uint) []byte {
+	for i := uint(0); i < n; i++ {
+		buf = appendGlyph(buf, alive)
+	}
+	return buf
+}
+
+func appendGlyph(buf []byte, alive bool) []byte {
+	if alive {
+		return append(buf, aliveGlyph...)
+	}
+	return append(buf, deadGlyph...)
+}
@@ -0,0 +1,104 @@
+package life
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffRenderer(t *testing.T) {
+	d := NewDiffRenderer()
+	d.Top = 1
+	f := fieldFromRows(true,
+		"o...",
+		"....",
+		"....",
+	)
+	render := func() string {
+		b := new(strings.Builder)
+		if err := d.Render(b, f); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+
+	want := "\x1b[?25l\x1b[H\x1b[2J" +
+		"\x1b[2;1H█   " +
+		"\x1b[3;1H    " +
+		"\x1b[4;1H    " +
+		"\x1b[5;1H"
+	if got := render(); got != want {
+		t.Errorf("first frame: got %q, wanted %q", got, want)
+	}
+
+	f.Set(0, 0, false)
+	f.Set(1, 1, true)
+	f.Set(2, 1, true)
+	want = "\x1b[2;1H \x1b[3;2H██\x1b[5;1H"
+	if got := render(); got != want {
+		t.Errorf("update: got %q, wanted %q", got, want)
+	}
+
+	if got, want := render(), "\x1b[5;1H"; got != want {
+		t.Errorf("unchanged: got %q, wanted %q", got, want)
+	}
+
+	// Changing more than a quarter of the cells redraws everything, over the previous frame.
+	for x := uint(0); x < 4; x++ {
+		f.Set(x, 2, true)
+	}
+	want = "\x1b[2;1H    " +
+		"\x1b[3;1H ██ " +
+		"\x1b[4;1H████" +
+		"\x1b[5;1H"
+	if got := render(); got != want {
+		t.Errorf("large update: got %q, wanted %q", got, want)
+	}
+
+	d.Invalidate()
+	if got := render(); !strings.HasPrefix(got, ClearScreen) {
+		t.Errorf("after Invalidate: got %q, wanted the screen to be cleared", got)
+	}
+
+	f = fieldFromRows(true, "...")
+	if got := render(); !strings.HasPrefix(got, ClearScreen) {
+		t.Errorf("smaller field: got %q, wanted the screen to be cleared", got)
+	}
+
+	b := new(strings.Builder)
+	d.Close(b)
+	if got, want := b.String(), "\x1b[?25h"; got != want {
+		t.Errorf("Close: got %q, wanted %q", got, want)
+	}
+}
+
+func TestScaledDiffRenderer(t *testing.T) {
+	d := NewDiffRenderer()
+	d.Scale = 3
+	f := fieldFromRows(true,
+		"o.",
+		"..",
+	)
+	render := func() string {
+		b := new(strings.Builder)
+		if err := d.Render(b, f); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+
+	want := "\x1b[?25l\x1b[H\x1b[2J" +
+		"\x1b[1;1H███   " +
+		"\x1b[2;1H███   " +
+		"\x1b[3;1H      " +
+		"\x1b[4;1H      " +
+		"\x1b[5;1H"
+	if got := render(); got != want {
+		t.Errorf("first frame: got %q, wanted %q", got, want)
+	}
+
+	f.Set(1, 1, true)
+	want = "\x1b[3;4H███\x1b[4;4H███\x1b[5;1H"
+	if got := render(); got != want {
+		t.Errorf("update: got %q, wanted %q", got, want)
+	}
+}
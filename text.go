@@ -0,0 +1,71 @@
+package life
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MarshalText implements encoding.TextMarshaler, e.g. to embed games in JSON or YAML files. The text is a line with
+// the size, topology and rule of the game, e.g. "3x3 torus rule B3/S23", followed by the field as String draws it:
+// a line per row with '█' for alive cells and ' ' for dead ones. Only the state of the cells, the rule and the
+// topology are kept, the generation and the settings of the game aren't.
+func (g *Game) MarshalText() ([]byte, error) {
+	topology := "plane"
+	if g.wrap {
+		topology = "torus"
+	}
+	b := []byte(fmt.Sprintf("%dx%d %s rule %s\n", g.width, g.height, topology, g.current.rule))
+	return g.current.AppendTo(b), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It replaces g with a new game of the text written by
+// MarshalText. Every row must hold as many cells as the first line declares, trailing spaces included.
+func (g *Game) UnmarshalText(text []byte) error {
+	lines := strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	var width, height uint
+	var topology, ruleString string
+	_, err := fmt.Sscanf(lines[0], "%dx%d %s rule %s", &width, &height, &topology, &ruleString)
+	if err != nil {
+		return fmt.Errorf("line 1: %q isn't of the form WIDTHxHEIGHT torus|plane rule RULE", lines[0])
+	}
+	if width == 0 || height == 0 {
+		return fmt.Errorf("line 1: width and height must be positive")
+	}
+	if topology != "torus" && topology != "plane" {
+		return fmt.Errorf("line 1: unknown topology %q, expected torus or plane", topology)
+	}
+	r, err := ParseRule(ruleString)
+	if err != nil {
+		return fmt.Errorf("line 1: %w", err)
+	}
+	if rows := uint(len(lines) - 1); rows != height {
+		return fmt.Errorf("got %d rows, expected %d", rows, height)
+	}
+	f, err := NewFieldE(width, height, topology == "torus")
+	if err != nil {
+		return fmt.Errorf("line 1: %w", err)
+	}
+	for y, line := range lines[1:] {
+		if n := uint(utf8.RuneCountInString(line)); n != width {
+			return fmt.Errorf("line %d: got %d cells, expected %d", y+2, n, width)
+		}
+		x := uint(0)
+		for _, c := range line {
+			switch string(c) {
+			case aliveGlyph:
+				f.Set(x, uint(y), true)
+			case deadGlyph:
+			default:
+				return fmt.Errorf("line %d: unexpected %q, expected %q or %q", y+2, c, aliveGlyph, deadGlyph)
+			}
+			x++
+		}
+	}
+	*g = *NewGameFromField(f)
+	g.SetRule(r)
+	return nil
+}
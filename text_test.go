@@ -0,0 +1,81 @@
+package life
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestGameMarshalText(t *testing.T) {
+	g := NewGameFromField(fieldFromRows(true,
+		".o..",
+		"..o.",
+		"ooo.",
+	))
+	rule, _ := ParseRule("B36/S23")
+	g.SetRule(rule)
+	got, err := g.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The format is stable, as it ends up in files.
+	want := "4x3 torus rule B36/S23\n" +
+		" █  \n" +
+		"  █ \n" +
+		"███ \n"
+	if string(got) != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestGameTextRoundTrip(t *testing.T) {
+	for _, wrap := range []bool{true, false} {
+		g := NewRandomGame(17, 9, wrap, 0.4, rand.New(rand.NewSource(1)))
+		rule, _ := ParseRule("B3678/S34678")
+		g.SetRule(rule)
+		// Through JSON, as games embedded in configuration files are.
+		b, err := json.Marshal(map[string]*Game{"game": g})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded map[string]*Game
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		c := decoded["game"]
+		if c.Field().String() != g.Field().String() || c.Rule() != g.Rule() || c.Field().wrap != wrap {
+			t.Errorf("wrap=%v: got %s with rule %v and wrap=%v, wanted %s with rule %v", wrap, c.Field(), c.Rule(),
+				c.Field().wrap, g.Field(), g.Rule())
+		}
+		if c.Population() != g.Population() {
+			t.Errorf("wrap=%v: got a population of %d, wanted %d", wrap, c.Population(), g.Population())
+		}
+	}
+}
+
+func TestGameUnmarshalTextErrors(t *testing.T) {
+	for _, tt := range []struct {
+		text, err string
+	}{
+		{"", "line 1"},
+		{"3x2 torus\n   \n   \n", "line 1"},
+		{"0x2 torus rule B3/S23\n", "positive"},
+		{"3x2 sphere rule B3/S23\n   \n   \n", "unknown topology"},
+		{"3x2 torus rule B9/S\n   \n   \n", "line 1"},
+		{"3x2 torus rule B3/S23\n   \n", "got 1 rows, expected 2"},
+		{"3x2 torus rule B3/S23\n   \n  \n", "line 3: got 2 cells, expected 3"},
+		{"3x2 torus rule B3/S23\n   \n o \n", "line 3: unexpected 'o'"},
+	} {
+		var g Game
+		err := g.UnmarshalText([]byte(tt.text))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%q: got error %v, wanted one containing %q", tt.text, err, tt.err)
+		}
+	}
+	// Line endings of Windows are fine.
+	var g Game
+	if err := g.UnmarshalText([]byte("2x1 plane rule B3/S23\r\n█ \r\n")); err != nil || g.Population() != 1 {
+		t.Errorf("got %v and a population of %d, wanted a single cell", err, g.Population())
+	}
+}
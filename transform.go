@@ -0,0 +1,68 @@
+package life
+
+import "fmt"
+
+// Transform is a rotation or reflection of a field.
+type Transform int
+
+const (
+	// Identity leaves a field as it is.
+	Identity Transform = iota
+	// Rotate90 rotates a field clockwise by 90 degrees.
+	Rotate90
+	// Rotate180 rotates a field by 180 degrees.
+	Rotate180
+	// Rotate270 rotates a field clockwise by 270 degrees, which is counterclockwise by 90 degrees.
+	Rotate270
+	// FlipX reflects a field from left to right.
+	FlipX
+	// FlipY reflects a field upside down.
+	FlipY
+)
+
+var transformNames = [...]string{Identity: "", Rotate90: "r90", Rotate180: "r180", Rotate270: "r270", FlipX: "fx", FlipY: "fy"}
+
+// String returns the short name of t, e.g. "r90" for Rotate90 and "fx" for FlipX, or "" for Identity.
+func (t Transform) String() string {
+	if t < 0 || int(t) >= len(transformNames) {
+		return fmt.Sprintf("Transform(%d)", int(t))
+	}
+	return transformNames[t]
+}
+
+// ParseTransform returns the transform with the given short name, see Transform.String.
+func ParseTransform(s string) (Transform, error) {
+	for t, name := range transformNames {
+		if name == s {
+			return Transform(t), nil
+		}
+	}
+	return Identity, fmt.Errorf("unknown transform %q (available: r90, r180, r270, fx, fy)", s)
+}
+
+// Transformed returns a copy of f with t applied to it, with the same wrapping and rule but without ages.
+// Rotating by 90 or 270 degrees swaps the width and height.
+func (f *Field) Transformed(t Transform) *Field {
+	w, h := f.width, f.height
+	if t == Rotate90 || t == Rotate270 {
+		w, h = h, w
+	}
+	c := NewField(w, h, f.wrap)
+	c.rule = f.rule
+	f.EachLive(func(x, y uint) {
+		switch t {
+		case Rotate90:
+			x, y = f.height-1-y, x
+		case Rotate180:
+			x, y = f.width-1-x, f.height-1-y
+		case Rotate270:
+			x, y = y, f.width-1-x
+		case FlipX:
+			x = f.width - 1 - x
+		case FlipY:
+			y = f.height - 1 - y
+		}
+		c.Set(x, y, true)
+	})
+	return c
+}
@@ -0,0 +1,50 @@
+package life
+
+import "testing"
+
+func TestTransformed(t *testing.T) {
+	f := fieldFromRows(false,
+		"oo.",
+		"..o",
+	)
+	tests := []struct {
+		t    Transform
+		want *Field
+	}{
+		{Identity, f},
+		{Rotate90, fieldFromRows(false,
+			".o",
+			".o",
+			"o.",
+		)},
+		{Rotate180, fieldFromRows(false,
+			"o..",
+			".oo",
+		)},
+		{Rotate270, fieldFromRows(false,
+			".o",
+			"o.",
+			"o.",
+		)},
+		{FlipX, fieldFromRows(false,
+			".oo",
+			"o..",
+		)},
+		{FlipY, fieldFromRows(false,
+			"..o",
+			"oo.",
+		)},
+	}
+	for _, tt := range tests {
+		got := f.Transformed(tt.t)
+		if !equalCells(got, tt.want) {
+			t.Errorf("%q: got:\n%s\nwanted:\n%s", tt.t, got, tt.want)
+		}
+		if p, err := ParseTransform(tt.t.String()); err != nil || p != tt.t {
+			t.Errorf("ParseTransform(%q) = %v, %v", tt.t, p, err)
+		}
+	}
+	if _, err := ParseTransform("r45"); err == nil {
+		t.Error("ParseTransform(\"r45\") succeeded")
+	}
+}
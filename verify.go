@@ -0,0 +1,106 @@
+package life
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Overlays of DivergenceError are cropped to at most this many columns and rows, and show this many cells around the
+// differences.
+const (
+	overlayColumns = 80
+	overlayRows    = 40
+	overlayMargin  = 2
+)
+
+// DivergenceError is returned by Verify when two engines compute different generations.
+type DivergenceError struct {
+	// Generation is the first generation that differs.
+	Generation uint
+	// Differing is the number of cells that differ, 0 if only the populations counted by the engines do.
+	Differing uint
+	// A and B are the generations computed by the first and the second engine.
+	A, B *Field
+}
+
+func (e *DivergenceError) Error() string {
+	if e.Differing == 0 {
+		return fmt.Sprintf("engines diverge at generation %d: the cells are the same, but the populations are %d and %d",
+			e.Generation, e.A.pop, e.B.pop)
+	}
+	return fmt.Sprintf("engines diverge at generation %d: %d cells differ\n%s", e.Generation, e.Differing, e.Overlay())
+}
+
+// Overlay draws the cells around the differences, with 'o' for cells alive in both generations, '.' for those dead
+// in both, and 'A' or 'B' for those alive only in A or only in B. The drawing is cropped to a window of at most 80x40
+// cells around the first rows of differences, whose top-left cell is given in the first line.
+func (e *DivergenceError) Overlay() string {
+	xor, err := e.A.Xor(e.B)
+	if err != nil {
+		return err.Error()
+	}
+	min, max, ok := xor.BoundingBox()
+	if !ok {
+		return ""
+	}
+	x0, y0 := saturatingSub(min.X, overlayMargin), saturatingSub(min.Y, overlayMargin)
+	x1, y1 := max.X+overlayMargin+1, max.Y+overlayMargin+1
+	if x1 > e.A.width {
+		x1 = e.A.width
+	}
+	if y1 > e.A.height {
+		y1 = e.A.height
+	}
+	if x1-x0 > overlayColumns {
+		x1 = x0 + overlayColumns
+	}
+	if y1-y0 > overlayRows {
+		y1 = y0 + overlayRows
+	}
+	b := new(strings.Builder)
+	fmt.Fprintf(b, "cells from %d,%d (o alive in both, A or B alive in one):\n", x0, y0)
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			a, bb := e.A.s[y][x], e.B.s[y][x]
+			switch {
+			case a && bb:
+				b.WriteByte('o')
+			case a:
+				b.WriteByte('A')
+			case bb:
+				b.WriteByte('B')
+			default:
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func saturatingSub(a, b uint) uint {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// Verify runs a and b side by side for the given number of generations, each on its own copy of initial, and
+// compares the generations they compute, to make sure that an engine computes the same ones as another that is
+// trusted. The hashes and populations are compared every generation, which costs next to nothing beside computing
+// the generations. At the first difference, a *DivergenceError is returned. A nil engine is NaiveEngine, and a and b
+// must not be the same engine, as engines may keep state, see Engine.
+func Verify(a, b Engine, initial *Field, generations uint) error {
+	ga := NewGameFromField(initial.Clone()).WithEngine(a)
+	gb := NewGameFromField(initial.Clone()).WithEngine(b)
+	for gen := uint(1); gen <= generations; gen++ {
+		ga.Tick()
+		gb.Tick()
+		if ga.current.hash == gb.current.hash && ga.current.pop == gb.current.pop {
+			continue
+		}
+		d, _ := ga.current.HammingDistance(gb.current)
+		return &DivergenceError{Generation: gen, Differing: d, A: ga.current.Clone(), B: gb.current.Clone()}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package life
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// brokenEngine computes generations like NaiveEngine, but flips the cell at x,y in generation at.
+type brokenEngine struct {
+	x, y, at, gen uint
+}
+
+func (e *brokenEngine) Step(current, next *Field) {
+	NaiveEngine{}.Step(current, next)
+	e.gen++
+	if e.gen == e.at {
+		if next.s[e.y][e.x] {
+			next.pop--
+		} else {
+			next.pop++
+		}
+		next.s[e.y][e.x] = !next.s[e.y][e.x]
+		next.hash ^= cellKey(e.x, e.y)
+	}
+}
+
+// miscountingEngine computes the right cells, but a population that is one too high.
+type miscountingEngine struct{}
+
+func (miscountingEngine) Step(current, next *Field) {
+	NaiveEngine{}.Step(current, next)
+	next.pop++
+}
+
+func TestVerify(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	for _, wrap := range []bool{true, false} {
+		soup := randomField(rng, 96, 64, wrap)
+		for _, name := range EngineNames() {
+			e, _ := NewEngine(name)
+			if err := Verify(nil, e, soup, 300); err != nil {
+				t.Errorf("%s, wrap %t: %v", name, wrap, err)
+			}
+		}
+	}
+
+	soup := randomField(rng, 200, 100, true)
+	err := Verify(NaiveEngine{}, &brokenEngine{x: 150, y: 98, at: 37}, soup, 1000)
+	var d *DivergenceError
+	if !errors.As(err, &d) {
+		t.Fatalf("got the error %v, wanted a divergence", err)
+	}
+	if d.Generation != 37 || d.Differing != 1 || d.A.s[98][150] == d.B.s[98][150] {
+		t.Errorf("got a divergence at generation %d with %d differing cells, wanted 1 at generation 37",
+			d.Generation, d.Differing)
+	}
+	// The overlay shows the cell in the middle of a window cut off by the bottom edge.
+	lines := strings.Split(strings.TrimSuffix(d.Overlay(), "\n"), "\n")
+	cells := strings.Join(lines[1:], "")
+	if lines[0] != "cells from 148,96 (o alive in both, A or B alive in one):" || len(cells) != 5*4 ||
+		strings.IndexAny(cells, "AB") != 2*5+2 || strings.Count(cells, "A")+strings.Count(cells, "B") != 1 {
+		t.Errorf("got the overlay:\n%s", d.Overlay())
+	}
+	if !strings.HasPrefix(err.Error(), "engines diverge at generation 37: 1 cells differ\ncells from 148,96") {
+		t.Errorf("got the error %q", err)
+	}
+
+	err = Verify(miscountingEngine{}, nil, soup, 10)
+	if !errors.As(err, &d) || d.Generation != 1 || d.Differing != 0 ||
+		!strings.Contains(err.Error(), "the cells are the same, but the populations are") {
+		t.Errorf("got the error %v, wanted one for the populations", err)
+	}
+	if err := Verify(nil, nil, soup, 0); err != nil {
+		t.Errorf("got the error %v without generations", err)
+	}
+}
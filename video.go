@@ -0,0 +1,169 @@
+package life
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+)
+
+// DefaultVideoCellSize is the width and height in pixels of the cells drawn by a VideoRenderer without a CellSize.
+const DefaultVideoCellSize = 4
+
+// DefaultFrameRate is the frame rate of a Y4M stream written by a VideoRenderer without a FrameRate.
+const DefaultFrameRate = 30
+
+// VideoFormat is the format of the frames written by a VideoRenderer.
+type VideoFormat int
+
+const (
+	// Y4M is a YUV4MPEG2 stream of uncompressed frames in full-range YCbCr 4:4:4, which carries its frame rate and is
+	// read by ffmpeg and mpv as it is.
+	Y4M VideoFormat = iota
+	// PPM is a sequence of binary PPM (P6) images in RGB. It carries no frame rate, which is given to ffmpeg with
+	// -framerate instead.
+	PPM
+)
+
+func (v VideoFormat) String() string {
+	switch v {
+	case Y4M:
+		return "y4m"
+	case PPM:
+		return "ppm"
+	}
+	return "VideoFormat(" + strconv.Itoa(int(v)) + ")"
+}
+
+// VideoRenderer writes fields as frames of an uncompressed video, one frame per call to Render, with every cell drawn
+// as a square of pixels, to be piped into an encoder like ffmpeg. The first frame of a Y4M stream is preceded by the
+// header of the stream, which sets the size of the frames, so a VideoRenderer writes a single stream, and every frame
+// after the first must be of the same size; Render returns an error for fields of another size.
+// A VideoRenderer reuses its buffer from frame to frame, so it must be used through a pointer and not from several
+// goroutines at once.
+type VideoRenderer struct {
+	// Format is the format of the frames.
+	Format VideoFormat
+	// CellSize is the width and height of a cell in pixels. If zero, DefaultVideoCellSize is used.
+	CellSize int
+	// Alive and Dead are the colours of live and dead cells. If nil, white and black are used.
+	Alive, Dead color.Color
+	// FrameRate is the number of frames per second given in the header of a Y4M stream. If zero, DefaultFrameRate is
+	// used.
+	FrameRate float64
+
+	// width and height are the size of the frames in pixels, set by the first frame.
+	width, height int
+	buf           []byte
+}
+
+// Render writes f to w as the next frame.
+func (v *VideoRenderer) Render(w io.Writer, f *Field) error {
+	size := v.CellSize
+	if size == 0 {
+		size = DefaultVideoCellSize
+	}
+	if size < 0 {
+		return fmt.Errorf("negative cell size %d", size)
+	}
+	width, height := int(f.width)*size, int(f.height)*size
+	first := v.width == 0
+	if first {
+		v.width, v.height = width, height
+	} else if width != v.width || height != v.height {
+		return fmt.Errorf("the frames are %dx%d pixels, but a field of %dx%d cells is %dx%d", v.width, v.height,
+			f.width, f.height, width, height)
+	}
+	alive, dead := [3]byte{0xff, 0xff, 0xff}, [3]byte{}
+	if v.Alive != nil {
+		alive = rgb(v.Alive)
+	}
+	if v.Dead != nil {
+		dead = rgb(v.Dead)
+	}
+	b := v.buf[:0]
+	switch v.Format {
+	case Y4M:
+		if first {
+			b = v.appendY4MHeader(b)
+		}
+		b = append(b, "FRAME\n"...)
+		// The planes of Y, Cb and Cr follow each other.
+		a, d := yCbCr(alive), yCbCr(dead)
+		for i := range a {
+			b = appendPixels(b, f, size, a[i:i+1], d[i:i+1])
+		}
+	case PPM:
+		b = append(b, "P6\n"...)
+		b = strconv.AppendInt(b, int64(width), 10)
+		b = append(b, ' ')
+		b = strconv.AppendInt(b, int64(height), 10)
+		b = append(b, "\n255\n"...)
+		b = appendPixels(b, f, size, alive[:], dead[:])
+	default:
+		return fmt.Errorf("unknown video format %v", v.Format)
+	}
+	v.buf = b
+	_, err := w.Write(b)
+	return err
+}
+
+// appendY4MHeader appends the header of a Y4M stream of frames of the size of v. The frame rate is given as a
+// fraction, in thousandths of a frame per second unless it is whole.
+func (v *VideoRenderer) appendY4MHeader(b []byte) []byte {
+	rate := v.FrameRate
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		rate = DefaultFrameRate
+	}
+	num, den := int64(rate), int64(1)
+	if rate != math.Trunc(rate) {
+		num, den = int64(math.Round(rate*1000)), 1000
+		if num == 0 {
+			num = 1
+		}
+	}
+	b = append(b, "YUV4MPEG2 W"...)
+	b = strconv.AppendInt(b, int64(v.width), 10)
+	b = append(b, " H"...)
+	b = strconv.AppendInt(b, int64(v.height), 10)
+	b = append(b, " F"...)
+	b = strconv.AppendInt(b, num, 10)
+	b = append(b, ':')
+	b = strconv.AppendInt(b, den, 10)
+	return append(b, " Ip A1:1 C444 XCOLORRANGE=FULL\n"...)
+}
+
+// appendPixels appends the pixels of f, row by row, with every cell a square of size by size pixels, each of them
+// the bytes of alive or dead. Every row of pixels is appended once and then repeated for the rest of its cells.
+func appendPixels(b []byte, f *Field, size int, alive, dead []byte) []byte {
+	for _, row := range f.s {
+		start := len(b)
+		for _, c := range row {
+			px := dead
+			if c {
+				px = alive
+			}
+			for i := 0; i < size; i++ {
+				b = append(b, px...)
+			}
+		}
+		end := len(b)
+		for i := 1; i < size; i++ {
+			b = append(b, b[start:end]...)
+		}
+	}
+	return b
+}
+
+// rgb returns the 8-bit red, green and blue components of c.
+func rgb(c color.Color) [3]byte {
+	r, g, b, _ := c.RGBA()
+	return [3]byte{byte(r >> 8), byte(g >> 8), byte(b >> 8)}
+}
+
+// yCbCr returns the full-range Y'CbCr components of the RGB colour px, as in JPEG.
+func yCbCr(px [3]byte) [3]byte {
+	y, cb, cr := color.RGBToYCbCr(px[0], px[1], px[2])
+	return [3]byte{y, cb, cr}
+}
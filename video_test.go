@@ -0,0 +1,101 @@
+package life
+
+import (
+	"bytes"
+	"image/color"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestVideoRendererPPM(t *testing.T) {
+	f := fieldFromRows(false,
+		"o.",
+	)
+	var b bytes.Buffer
+	v := &VideoRenderer{Format: PPM, CellSize: 2, Alive: color.RGBA{0xff, 0x80, 0, 0xff}}
+	for i := 0; i < 2; i++ {
+		if err := v.Render(&b, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Every frame is an image of its own, 2 cells of 2x2 pixels.
+	row := "\xff\x80\x00\xff\x80\x00\x00\x00\x00\x00\x00\x00"
+	frame := "P6\n4 2\n255\n" + row + row
+	if got, want := b.String(), frame+frame; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestVideoRendererY4M(t *testing.T) {
+	f := fieldFromRows(false,
+		"o.",
+		"..",
+	)
+	var b bytes.Buffer
+	v := &VideoRenderer{CellSize: 1, FrameRate: 12.5}
+	for i := 0; i < 2; i++ {
+		if err := v.Render(&b, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Only the first frame is preceded by the header. White is Y 255 and black Y 0, both with neutral chroma.
+	frame := "FRAME\n\xff\x00\x00\x00" + "\x80\x80\x80\x80" + "\x80\x80\x80\x80"
+	want := "YUV4MPEG2 W2 H2 F12500:1000 Ip A1:1 C444 XCOLORRANGE=FULL\n" + frame + frame
+	if got := b.String(); got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestVideoRendererFrameRate(t *testing.T) {
+	for _, tt := range []struct {
+		rate float64
+		want string
+	}{
+		{0, " F30:1 "},
+		{60, " F60:1 "},
+		{0.5, " F500:1000 "},
+	} {
+		var b bytes.Buffer
+		if err := (&VideoRenderer{FrameRate: tt.rate}).Render(&b, NewField(1, 1, false)); err != nil {
+			t.Fatal(err)
+		}
+		if header := strings.SplitN(b.String(), "\n", 2)[0]; !strings.Contains(header, tt.want) {
+			t.Errorf("frame rate %v: header %q doesn't contain %q", tt.rate, header, tt.want)
+		}
+	}
+}
+
+func TestVideoRendererSize(t *testing.T) {
+	var b bytes.Buffer
+	v := &VideoRenderer{}
+	if err := v.Render(&b, NewField(10, 5, true)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(b.String(), "YUV4MPEG2 W40 H20 ") {
+		t.Errorf("header of %q isn't that of 40x20 frames", b.String()[:30])
+	}
+	if got, want := b.Len(), strings.IndexByte(b.String(), '\n')+1+len("FRAME\n")+3*40*20; got != want {
+		t.Errorf("%d bytes, wanted %d", got, want)
+	}
+	// The size of the frames is set by the first one.
+	if err := v.Render(&b, NewField(5, 10, true)); err == nil {
+		t.Error("no error for a frame of another size")
+	}
+}
+
+func TestVideoRendererReusesBuffer(t *testing.T) {
+	f := NewRandomGame(200, 100, true, DefaultDensity, rand.New(rand.NewSource(1))).Field()
+	for _, format := range []VideoFormat{Y4M, PPM} {
+		v := &VideoRenderer{Format: format}
+		var b bytes.Buffer
+		v.Render(&b, f)
+		allocs := testing.AllocsPerRun(10, func() {
+			b.Reset()
+			v.Render(&b, f)
+		})
+		if allocs != 0 {
+			t.Errorf("%v: %v allocations per frame", format, allocs)
+		}
+	}
+}
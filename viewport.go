@@ -0,0 +1,165 @@
+package life
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Viewport is a rectangle of a field, with its top-left corner at X,Y, to draw instead of the whole field.
+type Viewport struct {
+	X, Y          uint
+	Width, Height uint
+}
+
+// ParseViewport parses a viewport of the form X,Y,WxH, e.g. "100,40,80x24".
+func ParseViewport(s string) (Viewport, error) {
+	var v Viewport
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("viewport %q isn't of the form X,Y,WxH", s)
+	}
+	x := strings.IndexByte(parts[2], 'x')
+	if x < 0 {
+		return v, fmt.Errorf("viewport %q isn't of the form X,Y,WxH", s)
+	}
+	n := [4]uint64{}
+	for i, p := range [4]string{parts[0], parts[1], parts[2][:x], parts[2][x+1:]} {
+		var err error
+		if n[i], err = strconv.ParseUint(p, 10, strconv.IntSize); err != nil {
+			return v, fmt.Errorf("viewport %q: %w", s, err)
+		}
+	}
+	if n[2] == 0 || n[3] == 0 {
+		return v, fmt.Errorf("viewport %q: width and height must be positive", s)
+	}
+	return Viewport{uint(n[0]), uint(n[1]), uint(n[2]), uint(n[3])}, nil
+}
+
+// String returns v in the form ParseViewport parses.
+func (v Viewport) String() string {
+	return fmt.Sprintf("%d,%d,%dx%d", v.X, v.Y, v.Width, v.Height)
+}
+
+// Within returns v fitted to f: no larger than f and, on a plane, moved back within its edges. On a torus, the
+// viewport may cross an edge and show the cells on the other side of it, so its position is only wrapped.
+func (v Viewport) Within(f *Field) Viewport {
+	if v.Width > f.width {
+		v.Width = f.width
+	}
+	if v.Height > f.height {
+		v.Height = f.height
+	}
+	if f.wrap {
+		v.X %= f.width
+		v.Y %= f.height
+		return v
+	}
+	if v.X > f.width-v.Width {
+		v.X = f.width - v.Width
+	}
+	if v.Y > f.height-v.Height {
+		v.Y = f.height - v.Height
+	}
+	return v
+}
+
+// Panned returns v moved by dx columns and dy rows across f, wrapping around the edges of a torus and stopping at
+// those of a plane.
+func (v Viewport) Panned(f *Field, dx, dy int) Viewport {
+	v = v.Within(f)
+	move := func(pos uint, d int, size uint) uint {
+		if d >= 0 {
+			return pos + uint(d)
+		}
+		if back := uint(-d); back <= pos {
+			return pos - back
+		} else if f.wrap {
+			return pos + size - back%size
+		}
+		return 0
+	}
+	v.X = move(v.X, dx, f.width)
+	v.Y = move(v.Y, dy, f.height)
+	return v.Within(f)
+}
+
+// Follow returns v moved so that it shows the live cells of f, for a camera that tracks a pattern as it wanders
+// across a large field. To keep the view from jittering every generation, v only moves once the live cells come
+// closer than an eighth of its size to one of its edges, and then centres on them again. Live cells that don't fit
+// into v move it once their centre strays that far from the centre of v. Like Panned, v wraps around the edges of a
+// torus and stops at those of a plane. Without live cells, v stays where it is.
+func (v Viewport) Follow(f *Field) Viewport {
+	v = v.Within(f)
+	min, max, ok := f.BoundingBox()
+	if !ok {
+		return v
+	}
+	follow := func(pos, size, lo, hi, fieldSize uint) uint {
+		margin := size / 8
+		centre := int(lo+hi+1) / 2
+		if hi-lo+1+2*margin <= size {
+			// The cells are still well inside the view, with pos as its first column or row within the field. On a
+			// torus, the view may have wrapped, so the cells are looked for in the copy of the field it shows.
+			start := pos
+			if f.wrap && lo < start {
+				lo, hi = lo+fieldSize, hi+fieldSize
+			}
+			if lo >= start+margin && hi+margin < start+size {
+				return pos
+			}
+			centre = int(lo+hi+1) / 2
+		} else if d := centre - int(pos+size/2); d <= int(margin) && -d <= int(margin) {
+			return pos
+		}
+		p := centre - int(size/2)
+		switch {
+		case f.wrap:
+			p %= int(fieldSize)
+			if p < 0 {
+				p += int(fieldSize)
+			}
+		case p < 0:
+			p = 0
+		case p > int(fieldSize-size):
+			p = int(fieldSize - size)
+		}
+		return uint(p)
+	}
+	v.X = follow(v.X, v.Width, min.X, max.X, f.width)
+	v.Y = follow(v.Y, v.Height, min.Y, max.Y, f.height)
+	return v
+}
+
+// View returns a copy of the cells of f within v, like Resized, as a field of the size of v with the top-left
+// corner of v at 0,0. On a torus, a viewport that crosses an edge continues on the other side; on a plane, the cells
+// beyond the edges are dead.
+func (f *Field) View(v Viewport) *Field {
+	c := NewField(v.Width, v.Height, f.wrap)
+	c.rule = f.rule
+	if f.age != nil {
+		c.age = newAges(v.Width, v.Height)
+	}
+	for y := uint(0); y < v.Height; y++ {
+		fy := v.Y + y
+		if f.wrap {
+			fy %= f.height
+		} else if fy >= f.height {
+			break
+		}
+		for x := uint(0); x < v.Width; x++ {
+			fx := v.X + x
+			if f.wrap {
+				fx %= f.width
+			} else if fx >= f.width {
+				break
+			}
+			c.s[y][x] = f.s[fy][fx]
+			if f.age != nil {
+				c.age[y][x] = f.age[fy][fx]
+			}
+		}
+	}
+	c.recount()
+	return c
+}
@@ -0,0 +1,99 @@
+package life
+
+import "testing"
+
+func TestParseViewport(t *testing.T) {
+	v, err := ParseViewport("100,40,80x24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Viewport{100, 40, 80, 24}); v != want {
+		t.Errorf("got %+v, wanted %+v", v, want)
+	}
+	if got := v.String(); got != "100,40,80x24" {
+		t.Errorf("String: got %q", got)
+	}
+	for _, s := range []string{"", "1,2", "1,2,3", "1,2,0x3", "-1,2,3x3", "1,2,3x3,4"} {
+		if _, err := ParseViewport(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestViewportPanned(t *testing.T) {
+	plane, torus := NewField(10, 6, false), NewField(10, 6, true)
+	for _, tt := range []struct {
+		f      *Field
+		v      Viewport
+		dx, dy int
+		want   Viewport
+	}{
+		{plane, Viewport{2, 2, 4, 3}, 1, -1, Viewport{3, 1, 4, 3}},
+		// A plane stops the viewport at its edges.
+		{plane, Viewport{1, 0, 4, 3}, -2, -1, Viewport{0, 0, 4, 3}},
+		{plane, Viewport{5, 2, 4, 3}, 3, 3, Viewport{6, 3, 4, 3}},
+		// A torus wraps it around them.
+		{torus, Viewport{1, 0, 4, 3}, -2, -1, Viewport{9, 5, 4, 3}},
+		{torus, Viewport{8, 4, 4, 3}, 3, 3, Viewport{1, 1, 4, 3}},
+		// Viewports larger than the field are shrunk to it.
+		{plane, Viewport{3, 3, 20, 20}, 0, 0, Viewport{0, 0, 10, 6}},
+	} {
+		if got := tt.v.Panned(tt.f, tt.dx, tt.dy); got != tt.want {
+			t.Errorf("%v panned by %d,%d on wrap=%v: got %v, wanted %v", tt.v, tt.dx, tt.dy, tt.f.wrap, got, tt.want)
+		}
+	}
+}
+
+func TestViewportFollow(t *testing.T) {
+	// cells returns a field of 20x20 with live cells from x0,y0 to x1,y1.
+	cells := func(wrap bool, x0, y0, x1, y1 uint) *Field {
+		f := NewField(20, 20, wrap)
+		f.Set(x0, y0, true)
+		f.Set(x1, y1, true)
+		return f
+	}
+	for _, tt := range []struct {
+		name string
+		f    *Field
+		v    Viewport
+		want Viewport
+	}{
+		// The margin of a viewport of 8 cells is 1.
+		{"inside the margin", cells(false, 2, 2, 4, 4), Viewport{0, 0, 8, 8}, Viewport{0, 0, 8, 8}},
+		{"at the edge", cells(false, 6, 2, 7, 4), Viewport{0, 0, 8, 8}, Viewport{3, 0, 8, 8}},
+		{"stopped by a plane", cells(false, 18, 18, 19, 19), Viewport{0, 0, 8, 8}, Viewport{12, 12, 8, 8}},
+		{"across the edge of a torus", cells(true, 1, 1, 2, 2), Viewport{16, 16, 8, 8}, Viewport{16, 16, 8, 8}},
+		{"wrapped by a torus", cells(true, 0, 0, 1, 1), Viewport{10, 10, 8, 8}, Viewport{17, 17, 8, 8}},
+		{"larger than the viewport", cells(false, 0, 2, 19, 4), Viewport{0, 0, 8, 8}, Viewport{6, 0, 8, 8}},
+		{"nearly centred", cells(false, 0, 2, 19, 4), Viewport{5, 0, 8, 8}, Viewport{5, 0, 8, 8}},
+		{"no live cells", NewField(20, 20, false), Viewport{4, 5, 8, 8}, Viewport{4, 5, 8, 8}},
+	} {
+		if got := tt.v.Follow(tt.f); got != tt.want {
+			t.Errorf("%s: got %v, wanted %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFieldView(t *testing.T) {
+	rows := []string{
+		"o...",
+		".o..",
+		"..o.",
+	}
+	for _, tt := range []struct {
+		wrap bool
+		v    Viewport
+		want []string
+	}{
+		{false, Viewport{1, 1, 2, 2}, []string{"o.", ".o"}},
+		{true, Viewport{3, 2, 2, 2}, []string{"..", ".o"}},
+		{true, Viewport{2, 1, 3, 2}, []string{"...", "o.."}},
+		{false, Viewport{3, 2, 2, 2}, []string{"..", ".."}},
+	} {
+		got := fieldFromRows(tt.wrap, rows...).View(tt.v)
+		want := fieldFromRows(tt.wrap, tt.want...)
+		if got.String() != want.String() || got.Population() != want.Population() {
+			t.Errorf("%v with wrap=%v: got\n%s\nwanted\n%s", tt.v, tt.wrap, got, want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package life
+
+import (
+	"archive/zip"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the most names the error of LoadGameZip suggests for a name that isn't in the archive.
+const maxSuggestions = 5
+
+// LoadGameZip loads a Life game state from the file of the given name in a zip archive, like LoadGame loads it from a
+// file, so that patterns don't have to be unpacked from collections first. The name is matched case-insensitively
+// unless a file has exactly that name. If no file matches, the error suggests files with similar names.
+func LoadGameZip(archive, name string, wrap bool, opts ...Option) (*Game, error) {
+	o, err := loadOptions(wrap, opts)
+	if err != nil {
+		return nil, err
+	}
+	z, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, err
+	}
+	defer z.Close()
+	zf, err := findZipFile(z.File, strings.TrimPrefix(path.Clean("/"+name), "/"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", archive, err)
+	}
+	r, err := zf.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", archive, err)
+	}
+	defer r.Close()
+	g, err := readFile(r, zf.Name, o)
+	if err != nil {
+		return nil, fmt.Errorf("%s!%s: %w", archive, zf.Name, err)
+	}
+	return g, nil
+}
+
+// findZipFile returns the file of the given name in files, or the only one whose name only differs in case.
+func findZipFile(files []*zip.File, name string) (*zip.File, error) {
+	var matches []*zip.File
+	for _, f := range files {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if f.Name == name {
+			return f, nil
+		}
+		if strings.EqualFold(f.Name, name) {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 0:
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, f := range matches {
+			names[i] = f.Name
+		}
+		return nil, fmt.Errorf("%s matches several files: %s", name, strings.Join(names, ", "))
+	}
+	if s := suggest(files, name); len(s) != 0 {
+		return nil, fmt.Errorf("no file %s (did you mean %s?)", name, strings.Join(s, ", "))
+	}
+	return nil, fmt.Errorf("no file %s", name)
+}
+
+// suggest returns the names of the files of files that are closest to name, either as a whole or without their
+// directories, which takes typos and files in other directories into account.
+func suggest(files []*zip.File, name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	lower := strings.ToLower(name)
+	base := path.Base(lower)
+	max := len(base) / 3
+	if max < 2 {
+		max = 2
+	}
+	var candidates []candidate
+	for _, f := range files {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		l := strings.ToLower(f.Name)
+		d := editDistance(lower, l)
+		if b := editDistance(base, path.Base(l)); b < d {
+			d = b
+		}
+		if d <= max {
+			candidates = append(candidates, candidate{f.Name, d})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	if len(candidates) > maxSuggestions {
+		candidates = candidates[:maxSuggestions]
+	}
+	names := make([]string, len(candidates))
+	for i, c := range candidates {
+		names[i] = c.name
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance of a and b: the fewest characters to insert, delete or replace to
+// turn one into the other.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev, cur := make([]int, len(t)+1), make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range s {
+		cur[0] = i + 1
+		for j := range t {
+			cost := 1
+			if s[i] == t[j] {
+				cost = 0
+			}
+			cur[j+1] = prev[j] + cost
+			if d := prev[j+1] + 1; d < cur[j+1] {
+				cur[j+1] = d
+			}
+			if d := cur[j] + 1; d < cur[j+1] {
+				cur[j+1] = d
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(t)]
+}
@@ -0,0 +1,101 @@
+package life
+
+import (
+	"archive/zip"
+	"os"
+	"strings"
+	"testing"
+)
+
+// writeZip writes an archive with the given files to a temporary directory and returns its name.
+func writeZip(t *testing.T, files map[string]string) string {
+	name := t.TempDir() + "/collection.zip"
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestLoadGameZip(t *testing.T) {
+	archive := writeZip(t, map[string]string{
+		"Patterns/Glider.rle":  "#C The smallest spaceship.\nx = 3, y = 3\nbo$2bo$3o!\n",
+		"patterns/broken.rle":  "x = 3, y = 3\n5o!\n",
+		"patterns/gliders.rle": "x = 1, y = 1\no!\n",
+		"README.txt":           "Patterns of Conway's Game of Life.\n",
+	})
+	want := fieldFromRows(false, ".o.", "..o", "ooo")
+	for _, load := range []func() (*Game, error){
+		func() (*Game, error) { return LoadGameZip(archive, "Patterns/Glider.rle", false) },
+		func() (*Game, error) { return LoadGameZip(archive, "/patterns/glider.RLE", false) },
+		func() (*Game, error) { return LoadGame(archive+"!patterns/glider.rle", false) },
+	} {
+		g, err := load()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if !equalCells(g.Field(), want) || g.Comment() != "The smallest spaceship.\n" {
+			t.Errorf("got:\n%s\nwith the comment %q, wanted the glider", g.Field(), g.Comment())
+		}
+	}
+	g, err := LoadGame(archive+"!patterns/glider.rle", true, WithWrap(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Field().wrap {
+		t.Error("got a torus with WithWrap(false)")
+	}
+
+	for _, test := range []struct{ name, err string }{
+		{"patterns/broken.rle", "collection.zip!patterns/broken.rle: pattern exceeds the width of 3"},
+		{"patterns/glidr.rle", "no file patterns/glidr.rle (did you mean Patterns/Glider.rle, patterns/gliders.rle?)"},
+		{"glider.rle", "no file glider.rle (did you mean Patterns/Glider.rle, patterns/gliders.rle?)"},
+		{"acorn.rle", "no file acorn.rle"},
+		{"readme.txt", "only RLE files and PBM images are supported"},
+	} {
+		_, err := LoadGame(archive+"!"+test.name, false)
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got the error %v, wanted one containing %q", test.name, err, test.err)
+		}
+	}
+	if _, err := LoadGameZip(archive, "Patterns/Glider.rle", false, WithDensity(0.5)); err == nil {
+		t.Error("expected an error for WithDensity")
+	}
+	if _, err := LoadGameZip(archive+".missing", "Patterns/Glider.rle", false); err == nil {
+		t.Error("expected an error for a missing archive")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		d    int
+	}{
+		{"", "", 0},
+		{"glider", "glider", 0},
+		{"glider", "glidr", 1},
+		{"glider", "gliders", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"éa", "ea", 1},
+	} {
+		if d := editDistance(test.a, test.b); d != test.d {
+			t.Errorf("editDistance(%q, %q) = %d, wanted %d", test.a, test.b, d, test.d)
+		}
+	}
+}